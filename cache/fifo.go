@@ -53,6 +53,18 @@ func (f *FIFO[K, V]) Get(key K) (V, bool) {
 	return v, ok
 }
 
+// Remove deletes [key] from the cache (returning true if it existed).
+//
+// [key] still counts towards [limit] until it would have been evicted.
+func (f *FIFO[K, V]) Remove(key K) bool {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	_, ok := f.m[key]
+	delete(f.m, key)
+	return ok
+}
+
 // remove is used as the callback in [BoundedBuffer]. It is assumed that the
 // [WriteLock] is held when this is accessed.
 func (f *FIFO[K, V]) remove(key K) {
@@ -4,14 +4,21 @@
 package chain
 
 import (
+	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
 )
 
-const BaseSize = consts.Uint64Len*2 + consts.IDLen
+// BaseSize is the size of a [Base] that does not specify [Base.Replaces] (which
+// adds [consts.IDLen]).
+const BaseSize = consts.Uint64Len*4 + consts.IDLen + consts.BoolLen
 
 type Base struct {
 	// Timestamp is the expiry of the transaction (inclusive). Once this time passes and the
@@ -26,6 +33,38 @@ type Base struct {
 	//
 	// If the fee is too low to pay all fees, the transaction will be dropped.
 	MaxFee uint64 `json:"maxFee"`
+
+	// Nonce is an optional sequence number for transactions issued by the sponsor. If
+	// non-zero, the transaction is only valid if [Nonce] is exactly one greater than the
+	// [Nonce] of the last transaction executed by the sponsor (see [GetNonce]).
+	//
+	// This allows the sponsor to submit a pipeline of dependent transactions that will
+	// be executed in order and ensures a transaction can't be executed again if it is
+	// resubmitted after it expires. If zero, the transaction is only protected from
+	// replay by the uniqueness of its ID within the validity window.
+	Nonce uint64 `json:"sequence"`
+
+	// PriorityFee is an optional tip paid to the recipient configured by [StateManager]
+	// (in addition to the fee computed from unit prices). Unlike the unit-price fee, it
+	// is never refunded.
+	//
+	// When many transactions are competing for inclusion, transactions that pay a higher
+	// [PriorityFee] are preferred over those that arrived at the same time.
+	PriorityFee uint64 `json:"priorityFee"`
+
+	// Replaces is the ID of a pending transaction from the same sponsor that should be
+	// removed from the mempool when this transaction is added (or [ids.Empty]). To
+	// replace a pending transaction, [MaxFee] must be greater than the [MaxFee] of the
+	// pending transaction and [PriorityFee] must be at least as large. A transaction
+	// without [Actions] that [Replaces] a pending transaction cancels it.
+	//
+	// [Replaces] is only enforced by the mempool of each node, so both transactions
+	// could still be included if the pending transaction was already gossiped. Use the
+	// same [Nonce] for both transactions to ensure that only one can be executed.
+	//
+	// [Replaces] is only encoded if it is set (behind a presence byte), so transactions
+	// that don't replace anything don't pay for it.
+	Replaces ids.ID `json:"replaces"`
 }
 
 func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
@@ -44,14 +83,24 @@ func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
 	}
 }
 
-func (*Base) Size() int {
-	return BaseSize
+func (b *Base) Size() int {
+	if b.Replaces == ids.Empty {
+		return BaseSize
+	}
+	return BaseSize + consts.IDLen
 }
 
 func (b *Base) Marshal(p *codec.Packer) {
 	p.PackInt64(b.Timestamp)
 	p.PackID(b.ChainID)
 	p.PackUint64(b.MaxFee)
+	p.PackUint64(b.Nonce)
+	p.PackUint64(b.PriorityFee)
+	replaces := b.Replaces != ids.Empty
+	p.PackBool(replaces)
+	if replaces {
+		p.PackID(b.Replaces)
+	}
 }
 
 func UnmarshalBase(p *codec.Packer) (*Base, error) {
@@ -63,5 +112,27 @@ func UnmarshalBase(p *codec.Packer) (*Base, error) {
 	}
 	p.UnpackID(true, &base.ChainID)
 	base.MaxFee = p.UnpackUint64(true)
+	base.Nonce = p.UnpackUint64(false)
+	base.PriorityFee = p.UnpackUint64(false)
+	if p.UnpackBool() {
+		// Must be non-empty so that each [Base] has a single encoding
+		p.UnpackID(true, &base.Replaces)
+	}
 	return &base, p.Err()
 }
+
+// GetNonce returns the [Base.Nonce] of the last transaction executed by [addr] that
+// specified a [Base.Nonce] (or 0 if there is no such transaction).
+func GetNonce(ctx context.Context, im state.Immutable, sm StateManager, addr codec.Address) (uint64, error) {
+	v, err := im.GetValue(ctx, NonceKey(sm.NonceKey(addr)))
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(v) != consts.Uint64Len {
+		return 0, fmt.Errorf("%w: invalid nonce", ErrInvalidObject)
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
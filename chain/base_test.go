@@ -0,0 +1,80 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+func TestBaseMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		base *Base
+		size int
+	}{
+		{
+			name: "no replaces",
+			base: &Base{
+				Timestamp:   1_000,
+				ChainID:     ids.GenerateTestID(),
+				MaxFee:      10,
+				Nonce:       1,
+				PriorityFee: 2,
+			},
+			size: BaseSize,
+		},
+		{
+			name: "replaces",
+			base: &Base{
+				Timestamp: 1_000,
+				ChainID:   ids.GenerateTestID(),
+				MaxFee:    10,
+				Replaces:  ids.GenerateTestID(),
+			},
+			size: BaseSize + consts.IDLen,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.Equal(tt.size, tt.base.Size())
+			p := codec.NewWriter(tt.base.Size(), tt.base.Size())
+			tt.base.Marshal(p)
+			require.NoError(p.Err())
+			require.Len(p.Bytes(), tt.size)
+
+			r := codec.NewReader(p.Bytes(), tt.size)
+			base, err := UnmarshalBase(r)
+			require.NoError(err)
+			require.True(r.Empty())
+			require.Equal(tt.base, base)
+		})
+	}
+}
+
+func TestBaseUnmarshalEmptyReplaces(t *testing.T) {
+	require := require.New(t)
+
+	// [Replaces] can't be marked present but empty (so that each [Base] has a
+	// single encoding)
+	p := codec.NewWriter(BaseSize+consts.IDLen, BaseSize+consts.IDLen)
+	p.PackInt64(1_000)
+	p.PackID(ids.GenerateTestID())
+	p.PackUint64(10)
+	p.PackUint64(0)
+	p.PackUint64(0)
+	p.PackBool(true)
+	p.PackID(ids.Empty)
+	require.NoError(p.Err())
+
+	_, err := UnmarshalBase(codec.NewReader(p.Bytes(), BaseSize+consts.IDLen))
+	require.ErrorIs(err, codec.ErrFieldNotPopulated)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/window"
 	"github.com/ava-labs/hypersdk/workers"
@@ -117,6 +118,7 @@ type StatelessBlock struct {
 	vdrState     validators.State
 
 	results    []*Result
+	receipts   []*Receipt
 	feeManager *FeeManager
 
 	vm   VM
@@ -192,7 +194,9 @@ func (b *StatelessBlock) populateTxs(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
-			batchVerifier.Add(txDigest, tx.Auth)
+			for _, auth := range tx.Auths() {
+				batchVerifier.Add(txDigest, auth)
+			}
 		}
 
 		// Check if we need the block context to verify the block (which contains
@@ -268,6 +272,7 @@ func (b *StatelessBlock) initializeBuilt(
 	ctx context.Context,
 	view merkledb.TrieView,
 	results []*Result,
+	receipts []*Receipt,
 	feeManager *FeeManager,
 ) error {
 	_, span := b.vm.Tracer().Start(ctx, "StatelessBlock.initializeBuilt")
@@ -282,6 +287,7 @@ func (b *StatelessBlock) initializeBuilt(
 	b.view = view
 	b.t = time.UnixMilli(b.StatefulBlock.Tmstmp)
 	b.results = results
+	b.receipts = receipts
 	b.feeManager = feeManager
 	b.txsSet = set.NewSet[ids.ID](len(b.Txs))
 	for _, tx := range b.Txs {
@@ -433,6 +439,42 @@ func (b *StatelessBlock) verifyWarpMessage(ctx context.Context, r Rules, msg *wa
 	return true
 }
 
+// commitMetadata records the height, timestamp, and fees of [b] in [ts].
+func (b *StatelessBlock) commitMetadata(
+	ctx context.Context,
+	ts *tstate.TState,
+	parentHeightRaw []byte,
+	parentTimestampRaw []byte,
+	parentFeeManager *FeeManager,
+	feeManager *FeeManager,
+) error {
+	var (
+		sm              = b.vm.StateManager()
+		heightKey       = HeightKey(sm.HeightKey())
+		timestampKey    = TimestampKey(sm.TimestampKey())
+		feeKey          = FeeKey(sm.FeeKey())
+		heightKeyStr    = string(heightKey)
+		timestampKeyStr = string(timestampKey)
+		feeKeyStr       = string(feeKey)
+	)
+	tsv := ts.NewView(state.Keys{heightKeyStr: state.All, timestampKeyStr: state.All, feeKeyStr: state.All}, map[string][]byte{
+		heightKeyStr:    parentHeightRaw,
+		timestampKeyStr: parentTimestampRaw,
+		feeKeyStr:       parentFeeManager.Bytes(),
+	})
+	if err := tsv.Insert(ctx, heightKey, binary.BigEndian.AppendUint64(nil, b.Hght)); err != nil {
+		return err
+	}
+	if err := tsv.Insert(ctx, timestampKey, binary.BigEndian.AppendUint64(nil, uint64(b.Tmstmp))); err != nil {
+		return err
+	}
+	if err := tsv.Insert(ctx, feeKey, feeManager.Bytes()); err != nil {
+		return err
+	}
+	tsv.Commit()
+	return nil
+}
+
 // innerVerify executes the block on top of the provided [VerifyContext].
 //
 // Invariants:
@@ -464,6 +506,12 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 		return fmt.Errorf("%w: unable to load parent view", err)
 	}
 
+	// Load rules that depend on parent state
+	r, err = LoadRules(ctx, r, parentView)
+	if err != nil {
+		return fmt.Errorf("%w: unable to load rules", err)
+	}
+
 	// Fetch parent height key and ensure block height is valid
 	heightKey := HeightKey(b.vm.StateManager().HeightKey())
 	parentHeightRaw, err := parentView.GetValue(ctx, heightKey)
@@ -580,12 +628,13 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 	}
 
 	// Process transactions
-	results, ts, err := b.Execute(ctx, b.vm.Tracer(), parentView, feeManager, r)
+	results, receipts, ts, err := b.Execute(ctx, b.vm.Tracer(), parentView, feeManager, r)
 	if err != nil {
 		log.Error("failed to execute block", zap.Error(err))
 		return err
 	}
 	b.results = results
+	b.receipts = receipts
 	b.feeManager = feeManager
 
 	// Ensure warp results are correct
@@ -606,24 +655,9 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 	}
 
 	// Update chain metadata
-	heightKeyStr := string(heightKey)
-	timestampKeyStr := string(timestampKey)
-	feeKeyStr := string(feeKey)
-	tsv := ts.NewView(set.Of(heightKeyStr, timestampKeyStr, feeKeyStr), map[string][]byte{
-		heightKeyStr:    parentHeightRaw,
-		timestampKeyStr: parentTimestampRaw,
-		feeKeyStr:       parentFeeManager.Bytes(),
-	})
-	if err := tsv.Insert(ctx, heightKey, binary.BigEndian.AppendUint64(nil, b.Hght)); err != nil {
+	if err := b.commitMetadata(ctx, ts, parentHeightRaw, parentTimestampRaw, parentFeeManager, feeManager); err != nil {
 		return err
 	}
-	if err := tsv.Insert(ctx, timestampKey, binary.BigEndian.AppendUint64(nil, uint64(b.Tmstmp))); err != nil {
-		return err
-	}
-	if err := tsv.Insert(ctx, feeKey, feeManager.Bytes()); err != nil {
-		return err
-	}
-	tsv.Commit()
 
 	// Compare state root
 	//
@@ -967,6 +1001,12 @@ func (b *StatelessBlock) Results() []*Result {
 	return b.results
 }
 
+// Receipts returns the [Receipt] of each [Transaction] in the block (in order). It
+// is nil if [VM.GetStoreReceipts] is false.
+func (b *StatelessBlock) Receipts() []*Receipt {
+	return b.receipts
+}
+
 func (b *StatelessBlock) FeeManager() *FeeManager {
 	return b.feeManager
 }
@@ -989,7 +1029,9 @@ func (b *StatefulBlock) Marshal() ([]byte, error) {
 		if err := tx.Marshal(p); err != nil {
 			return nil, err
 		}
-		b.authCounts[tx.Auth.GetTypeID()]++
+		for _, auth := range tx.Auths() {
+			b.authCounts[auth.GetTypeID()]++
+		}
 	}
 
 	p.PackID(b.StateRoot)
@@ -1024,7 +1066,9 @@ func UnmarshalBlock(raw []byte, parser Parser) (*StatefulBlock, error) {
 			return nil, err
 		}
 		b.Txs = append(b.Txs, tx)
-		b.authCounts[tx.Auth.GetTypeID()]++
+		for _, auth := range tx.Auths() {
+			b.authCounts[auth.GetTypeID()]++
+		}
 	}
 
 	p.UnpackID(false, &b.StateRoot)
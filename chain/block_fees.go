@@ -0,0 +1,92 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// newBlockView returns a [tstate.TStateView] that can be used to access [scope]
+// after all transactions in a block are executed. Any modifications made by
+// transactions are read from [ts] and all other values are read from [im].
+func newBlockView(
+	ctx context.Context,
+	ts *tstate.TState,
+	im state.Immutable,
+	scope state.Keys,
+) (*tstate.TStateView, error) {
+	storage := make(map[string][]byte, len(scope))
+	for k := range scope {
+		v, err := im.GetValue(ctx, []byte(k))
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		storage[k] = v
+	}
+	return ts.NewView(scope, storage), nil
+}
+
+// allKeys returns a [state.Keys] that allows [stateKeys] to be read and written.
+func allKeys(stateKeys []string) state.Keys {
+	scope := make(state.Keys, len(stateKeys))
+	for _, k := range stateKeys {
+		scope.Add(k, state.All)
+	}
+	return scope
+}
+
+// distributeFees provides the fees paid for all units consumed in a block (as tracked
+// by [feeManager]) and the [forfeited] prepaid fees of scheduled actions to [fd]. Like
+// other block-level changes, this is applied to [ts] after all transactions are executed.
+func distributeFees(
+	ctx context.Context,
+	ts *tstate.TState,
+	im state.Immutable,
+	fd FeeDistributor,
+	feeManager *FeeManager,
+	forfeited uint64,
+) error {
+	fees, err := feeManager.Fees()
+	if err != nil {
+		return err
+	}
+	tsv, err := newBlockView(ctx, ts, im, allKeys(fd.StateKeys()))
+	if err != nil {
+		return err
+	}
+	if err := fd.Distribute(ctx, fees, forfeited, tsv); err != nil {
+		return err
+	}
+	tsv.Commit()
+	return nil
+}
+
+// distributeBlockFees pays the priority fees of [txs] and then distributes the fees
+// paid for all units consumed in the block (and any [forfeited] fees).
+func distributeBlockFees(
+	ctx context.Context,
+	vm VM,
+	ts *tstate.TState,
+	im state.Immutable,
+	feeManager *FeeManager,
+	txs []*Transaction,
+	forfeited uint64,
+) error {
+	if err := payPriorityFees(ctx, ts, im, vm.StateManager(), txs); err != nil {
+		return fmt.Errorf("%w: unable to pay priority fees", err)
+	}
+	if err := distributeFees(ctx, ts, im, vm.FeeDistributor(), feeManager, forfeited); err != nil {
+		return fmt.Errorf("%w: unable to distribute fees", err)
+	}
+	return nil
+}
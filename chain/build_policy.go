@@ -0,0 +1,127 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import "context"
+
+var _ BlockBuildPolicy = (*DefaultBlockBuildPolicy)(nil)
+
+// Reservation is capacity in each block that can only be consumed by
+// transactions that [Match]. Once a [Reservation] is exhausted, matching
+// transactions consume unreserved capacity.
+type Reservation struct {
+	Units Dimensions
+	Match func(*Transaction) bool
+}
+
+// DefaultBlockBuildPolicy includes transactions in the order they are streamed
+// (from the [BundleMempool] and then the [Mempool]) and does not reserve any
+// capacity.
+type DefaultBlockBuildPolicy struct{}
+
+func (*DefaultBlockBuildPolicy) Reservations(Rules) []*Reservation {
+	return nil
+}
+
+func (*DefaultBlockBuildPolicy) Include(context.Context, *Transaction) bool {
+	return true
+}
+
+func (*DefaultBlockBuildPolicy) Order(_ context.Context, groups [][]*Transaction) [][]*Transaction {
+	return groups
+}
+
+// blockCapacity tracks the units that can be consumed by each transaction
+// considered by [BuildBlock] given the [Reservation]s of the [BlockBuildPolicy].
+type blockCapacity struct {
+	max          Dimensions
+	reservations []*Reservation
+	remaining    []Dimensions
+}
+
+func newBlockCapacity(max Dimensions, reservations []*Reservation) *blockCapacity {
+	remaining := make([]Dimensions, len(reservations))
+	for i, reservation := range reservations {
+		remaining[i] = reservation.Units
+	}
+	return &blockCapacity{
+		max:          max,
+		reservations: reservations,
+		remaining:    remaining,
+	}
+}
+
+// match returns the index of the first [Reservation] matched by all [txs]
+// (or -1 if there is no such [Reservation]).
+func (c *blockCapacity) match(txs []*Transaction) int {
+	for i, reservation := range c.reservations {
+		matched := true
+		for _, tx := range txs {
+			if !reservation.Match(tx) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return i
+		}
+	}
+	return -1
+}
+
+// limit returns the max units that can be consumed by the block after
+// including transactions that matched the [Reservation] at [index].
+func (c *blockCapacity) limit(index int) Dimensions {
+	return c.unreserved(index, c.max)
+}
+
+// target returns the units that should be consumed by the block (given
+// [target] units) before it stops considering transactions that matched the
+// [Reservation] at [index]. This excludes any units still reserved for other
+// [Reservation]s, which may never be consumed.
+func (c *blockCapacity) target(index int, target Dimensions) Dimensions {
+	return c.unreserved(index, target)
+}
+
+// unreserved returns [units] minus the unconsumed units of all [Reservation]s
+// other than the one at [index].
+func (c *blockCapacity) unreserved(index int, units Dimensions) Dimensions {
+	for i, remaining := range c.remaining {
+		if i == index {
+			continue
+		}
+		for d := Dimension(0); d < FeeDimensions; d++ {
+			if remaining[d] >= units[d] {
+				units[d] = 0
+				continue
+			}
+			units[d] -= remaining[d]
+		}
+	}
+	return units
+}
+
+// reserved returns the unconsumed units reserved in [d].
+func (c *blockCapacity) reserved(d Dimension) uint64 {
+	var reserved uint64
+	for _, remaining := range c.remaining {
+		reserved += remaining[d]
+	}
+	return reserved
+}
+
+// consume records [units] consumed by transactions that matched the
+// [Reservation] at [index].
+func (c *blockCapacity) consume(index int, units Dimensions) {
+	if index < 0 {
+		return
+	}
+	for d := Dimension(0); d < FeeDimensions; d++ {
+		if units[d] >= c.remaining[index][d] {
+			c.remaining[index][d] = 0
+			continue
+		}
+		c.remaining[index][d] -= units[d]
+	}
+}
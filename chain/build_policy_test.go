@@ -0,0 +1,230 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// priorityTx returns a [Transaction] that pays [priorityFee].
+func priorityTx(priorityFee uint64) *Transaction {
+	return &Transaction{Base: &Base{PriorityFee: priorityFee}}
+}
+
+// priorityReservation reserves [units] for transactions that pay at least [minFee].
+func priorityReservation(minFee uint64, units Dimensions) *Reservation {
+	return &Reservation{
+		Units: units,
+		Match: func(tx *Transaction) bool { return tx.PriorityFee() >= minFee },
+	}
+}
+
+func TestBlockCapacityMatch(t *testing.T) {
+	overlapping := []*Reservation{
+		priorityReservation(100, Dimensions{1, 1, 1, 1, 1}),
+		priorityReservation(10, Dimensions{1, 1, 1, 1, 1}),
+	}
+	tests := []struct {
+		name         string
+		reservations []*Reservation
+		txs          []*Transaction
+		index        int
+	}{
+		{
+			name:  "no reservations",
+			txs:   []*Transaction{priorityTx(100)},
+			index: -1,
+		},
+		{
+			name:         "no match",
+			reservations: overlapping,
+			txs:          []*Transaction{priorityTx(1)},
+			index:        -1,
+		},
+		{
+			name:         "first overlapping reservation",
+			reservations: overlapping,
+			txs:          []*Transaction{priorityTx(100)},
+			index:        0,
+		},
+		{
+			name:         "second overlapping reservation",
+			reservations: overlapping,
+			txs:          []*Transaction{priorityTx(50)},
+			index:        1,
+		},
+		{
+			name:         "all txs must match",
+			reservations: overlapping,
+			txs:          []*Transaction{priorityTx(100), priorityTx(50)},
+			index:        1,
+		},
+		{
+			name:         "any tx without match",
+			reservations: overlapping,
+			txs:          []*Transaction{priorityTx(100), priorityTx(1)},
+			index:        -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newBlockCapacity(Dimensions{10, 10, 10, 10, 10}, tt.reservations)
+			require.Equal(t, tt.index, c.match(tt.txs))
+		})
+	}
+}
+
+func TestBlockCapacityLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		reservations []*Reservation
+		consumed     map[int]Dimensions
+		index        int
+		limit        Dimensions
+		target       Dimensions
+	}{
+		{
+			name:   "no reservations",
+			index:  -1,
+			limit:  Dimensions{100, 100, 100, 100, 100},
+			target: Dimensions{50, 50, 50, 50, 50},
+		},
+		{
+			name: "unmatched excludes all reservations",
+			reservations: []*Reservation{
+				priorityReservation(100, Dimensions{10, 0, 20, 0, 0}),
+				priorityReservation(10, Dimensions{5, 0, 0, 30, 0}),
+			},
+			index:  -1,
+			limit:  Dimensions{85, 100, 80, 70, 100},
+			target: Dimensions{35, 50, 30, 20, 50},
+		},
+		{
+			name: "matched excludes other reservations",
+			reservations: []*Reservation{
+				priorityReservation(100, Dimensions{10, 0, 20, 0, 0}),
+				priorityReservation(10, Dimensions{5, 0, 0, 30, 0}),
+			},
+			index:  1,
+			limit:  Dimensions{90, 100, 80, 100, 100},
+			target: Dimensions{40, 50, 30, 50, 50},
+		},
+		{
+			name: "consumed reservations are released",
+			reservations: []*Reservation{
+				priorityReservation(100, Dimensions{10, 0, 20, 0, 0}),
+				priorityReservation(10, Dimensions{5, 0, 0, 30, 0}),
+			},
+			consumed: map[int]Dimensions{
+				0: {4, 0, 20, 0, 0},
+			},
+			index:  -1,
+			limit:  Dimensions{89, 100, 100, 70, 100},
+			target: Dimensions{39, 50, 50, 20, 50},
+		},
+		{
+			name: "saturated dimension",
+			reservations: []*Reservation{
+				priorityReservation(100, Dimensions{60, 0, 0, 0, 200}),
+				priorityReservation(10, Dimensions{60, 0, 0, 0, 0}),
+			},
+			index:  0,
+			limit:  Dimensions{40, 100, 100, 100, 100},
+			target: Dimensions{0, 50, 50, 50, 50},
+		},
+		{
+			name: "saturated by unconsumed reservations",
+			reservations: []*Reservation{
+				priorityReservation(100, Dimensions{60, 0, 0, 0, 200}),
+				priorityReservation(10, Dimensions{60, 0, 0, 0, 0}),
+			},
+			index:  -1,
+			limit:  Dimensions{0, 100, 100, 100, 0},
+			target: Dimensions{0, 50, 50, 50, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			c := newBlockCapacity(Dimensions{100, 100, 100, 100, 100}, tt.reservations)
+			for index, units := range tt.consumed {
+				c.consume(index, units)
+			}
+			require.Equal(tt.limit, c.limit(tt.index))
+			require.Equal(tt.target, c.target(tt.index, Dimensions{50, 50, 50, 50, 50}))
+
+			// The max is never modified
+			require.Equal(Dimensions{100, 100, 100, 100, 100}, c.max)
+		})
+	}
+}
+
+func TestBlockCapacityConsume(t *testing.T) {
+	reservations := func() []*Reservation {
+		return []*Reservation{
+			priorityReservation(100, Dimensions{10, 10, 10, 10, 10}),
+			priorityReservation(10, Dimensions{5, 5, 5, 5, 5}),
+		}
+	}
+	tests := []struct {
+		name      string
+		index     int
+		units     []Dimensions
+		remaining []Dimensions
+		reserved  Dimensions
+	}{
+		{
+			name:      "unmatched",
+			index:     -1,
+			units:     []Dimensions{{1, 2, 3, 4, 5}},
+			remaining: []Dimensions{{10, 10, 10, 10, 10}, {5, 5, 5, 5, 5}},
+			reserved:  Dimensions{15, 15, 15, 15, 15},
+		},
+		{
+			name:      "partial",
+			index:     0,
+			units:     []Dimensions{{1, 2, 3, 4, 5}},
+			remaining: []Dimensions{{9, 8, 7, 6, 5}, {5, 5, 5, 5, 5}},
+			reserved:  Dimensions{14, 13, 12, 11, 10},
+		},
+		{
+			name:      "repeated",
+			index:     1,
+			units:     []Dimensions{{1, 2, 3, 4, 5}, {1, 2, 3, 4, 5}},
+			remaining: []Dimensions{{10, 10, 10, 10, 10}, {3, 1, 0, 0, 0}},
+			reserved:  Dimensions{13, 11, 10, 10, 10},
+		},
+		{
+			name:      "saturated",
+			index:     0,
+			units:     []Dimensions{{100, 10, 0, 9, 11}},
+			remaining: []Dimensions{{0, 0, 10, 1, 0}, {5, 5, 5, 5, 5}},
+			reserved:  Dimensions{5, 5, 15, 6, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			c := newBlockCapacity(Dimensions{100, 100, 100, 100, 100}, reservations())
+			for _, units := range tt.units {
+				c.consume(tt.index, units)
+			}
+			require.Equal(tt.remaining, c.remaining)
+			for d := Dimension(0); d < FeeDimensions; d++ {
+				require.Equal(tt.reserved[d], c.reserved(d))
+			}
+		})
+	}
+}
+
+func TestBlockCapacityDoesNotModifyReservations(t *testing.T) {
+	require := require.New(t)
+	reservation := priorityReservation(10, Dimensions{5, 5, 5, 5, 5})
+	c := newBlockCapacity(Dimensions{100, 100, 100, 100, 100}, []*Reservation{reservation})
+	c.consume(0, Dimensions{5, 5, 5, 5, 5})
+	require.Equal(Dimensions{5, 5, 5, 5, 5}, reservation.Units)
+	require.Equal(Dimensions{}, c.remaining[0])
+}
@@ -19,9 +19,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/tstate"
 )
 
@@ -31,6 +33,7 @@ const (
 	// TODO: make these tunable
 	streamBatch             = 256
 	streamPrefetchThreshold = streamBatch / 2
+	bundleStreamBatch       = 16
 	stopBuildingThreshold   = 2_048 // units
 )
 
@@ -52,6 +55,12 @@ func HandlePreExecute(log logging.Logger, err error) bool {
 		return false
 	case errors.Is(err, ErrActionNotActivated):
 		return false
+	case errors.Is(err, ErrNonceTooLow):
+		return false
+	case errors.Is(err, ErrNonceTooHigh):
+		// May be executable once the transactions that fill the nonce gap are
+		// executed
+		return true
 	default:
 		// If unknown error, drop
 		log.Warn("unknown PreExecute error", zap.Error(err))
@@ -93,6 +102,18 @@ func BuildBlock(
 		return nil, err
 	}
 
+	// Load rules that depend on parent state (this must happen before we use
+	// [r] for anything other than the preliminary gap check above)
+	r, err = LoadRules(ctx, r, parentView)
+	if err != nil {
+		log.Warn("block building failed: couldn't load rules", zap.Error(err))
+		return nil, err
+	}
+	if nextTime < parent.Tmstmp+r.GetMinBlockGap() {
+		log.Warn("block building failed", zap.Error(ErrTimestampTooEarly))
+		return nil, ErrTimestampTooEarly
+	}
+
 	// Compute next unit prices to use
 	feeKey := FeeKey(vm.StateManager().FeeKey())
 	feeRaw, err := parentView.GetValue(ctx, feeKey)
@@ -111,11 +132,22 @@ func BuildBlock(
 		ts            = tstate.New(changesEstimate)
 		oldestAllowed = nextTime - r.GetValidityWindow()
 
-		mempool = vm.Mempool()
+		mempool    = vm.Mempool()
+		bundlePool = vm.BundleMempool()
+
+		// restorable txs (and bundles) after block attempt finishes
+		restorableLock    sync.Mutex
+		restorable        = []*Transaction{}
+		restorableBundles = []*Bundle{}
+		restoredBundles   = set.Set[ids.ID]{}
 
-		// restorable txs after block attempt finishes
-		restorableLock sync.Mutex
-		restorable     = []*Transaction{}
+		// bundles tracks the [Bundle] of each streamed transaction that
+		// was streamed as part of a [Bundle].
+		bundles = map[ids.ID]*Bundle{}
+
+		// streamed contains the IDs of all streamed transactions, so we can
+		// skip transactions that are in both the [Mempool] and a [Bundle].
+		streamed = set.Set[ids.ID]{}
 
 		// cache contains keys already fetched from state that can be
 		// used during prefetching.
@@ -127,6 +159,12 @@ func BuildBlock(
 		start        = time.Now()
 		txsAttempted = 0
 		results      = []*Result{}
+		receipts     []*Receipt
+
+		storeReceipts = vm.GetStoreReceipts()
+
+		policy   = vm.BlockBuildPolicy()
+		capacity = newBlockCapacity(maxUnits, policy.Reservations(r))
 
 		vdrState = vm.ValidatorState()
 		sm       = vm.StateManager()
@@ -136,54 +174,161 @@ func BuildBlock(
 		prepareStreamLock sync.Mutex
 	)
 
+	// restoreTxs returns [txs] to [restorable] (any transaction streamed as part
+	// of a [Bundle] is restored with the rest of its [Bundle]).
+	restoreTxs := func(txs []*Transaction) {
+		restorableLock.Lock()
+		defer restorableLock.Unlock()
+
+		for _, tx := range txs {
+			bundle, ok := bundles[tx.ID()]
+			if !ok {
+				restorable = append(restorable, tx)
+				continue
+			}
+			if restoredBundles.Contains(bundle.ID()) {
+				continue
+			}
+			restoredBundles.Add(bundle.ID())
+			restorableBundles = append(restorableBundles, bundle)
+		}
+	}
+
+	// Execute scheduled actions before any transactions
+	forfeited, err := executeScheduled(ctx, vm, ts, parentView, feeManager, r, nextTime)
+	if err != nil {
+		log.Warn("block building failed: unable to execute scheduled actions", zap.Error(err))
+		return nil, err
+	}
+
 	// Batch fetch items from mempool to unblock incoming RPC/Gossip traffic
 	mempool.StartStreaming(ctx)
+	bundlePool.StartStreaming(ctx)
 	b.Txs = []*Transaction{}
 	for time.Since(start) < vm.GetTargetBuildDuration() {
 		prepareStreamLock.Lock()
 		txs := mempool.Stream(ctx, streamBatch)
 		prepareStreamLock.Unlock()
-		if len(txs) == 0 {
+		streamedBundles := bundlePool.Stream(ctx, bundleStreamBatch)
+		if len(txs) == 0 && len(streamedBundles) == 0 {
 			b.vm.RecordClearedMempool()
 			break
 		}
 		ctx, executeSpan := vm.Tracer().Start(ctx, "chain.BuildBlock.Execute")
 
-		// Perform a batch repeat check
-		dup, err := parent.IsRepeat(ctx, oldestAllowed, txs, set.NewBits(), false)
+		// Perform a batch repeat check (bundled transactions are checked
+		// before all other transactions)
+		all := make([]*Transaction, 0, len(txs)+len(streamedBundles)*MaxBundleTxs)
+		for _, bundle := range streamedBundles {
+			all = append(all, bundle.Txs...)
+		}
+		all = append(all, txs...)
+		dup, err := parent.IsRepeat(ctx, oldestAllowed, all, set.NewBits(), false)
 		if err != nil {
 			restorable = append(restorable, txs...)
+			restorableBundles = append(restorableBundles, streamedBundles...)
 			break
 		}
 
 		e := executor.New(streamBatch, vm.GetTransactionExecutionCores(), vm.GetExecutorBuildRecorder())
 		pending := make(map[ids.ID]*Transaction, streamBatch)
 		var pendingLock sync.Mutex
-		for li, ltx := range txs {
+
+		// Filter bundles before going async
+		//
+		// A [Bundle] is dropped if any of its transactions is a duplicate and is
+		// restored if any of its transactions can't be included in this block (or
+		// was already streamed).
+		candidates := make([][]*Transaction, 0, len(streamedBundles)+len(txs))
+		offset := 0
+		for _, bundle := range streamedBundles {
+			txsAttempted += len(bundle.Txs)
+			var drop, skip bool
+			for i, tx := range bundle.Txs {
+				if dup.Contains(offset + i) {
+					drop = true
+					break
+				}
+				if streamed.Contains(tx.ID()) || (tx.WarpMessage != nil && blockContext == nil) || !policy.Include(ctx, tx) {
+					skip = true
+				}
+			}
+			offset += len(bundle.Txs)
+			if drop {
+				log.Debug("dropping bundle: contains duplicate", zap.Stringer("bundleID", bundle.ID()))
+				continue
+			}
+			if skip {
+				restorableBundles = append(restorableBundles, bundle)
+				restoredBundles.Add(bundle.ID())
+				continue
+			}
+			for _, tx := range bundle.Txs {
+				streamed.Add(tx.ID())
+				bundles[tx.ID()] = bundle
+			}
+			candidates = append(candidates, bundle.Txs)
+		}
+
+		// Filter transactions before going async
+		for li, tx := range txs {
+			i := offset + li
 			txsAttempted++
-			i := li
-			tx := ltx
 
-			// Skip any duplicates before going async
+			// Skip any duplicates
 			if dup.Contains(i) {
 				continue
 			}
 
+			// Skip any transactions already streamed in a [Bundle]
+			if streamed.Contains(tx.ID()) {
+				restorable = append(restorable, tx)
+				continue
+			}
+			streamed.Add(tx.ID())
+
 			// Ensure we can process if transaction includes a warp message
 			if tx.WarpMessage != nil && blockContext == nil {
 				log.Info(
 					"dropping pending warp message because no context provided",
 					zap.Stringer("txID", tx.ID()),
 				)
-				restorableLock.Lock()
 				restorable = append(restorable, tx)
-				restorableLock.Unlock()
 				continue
 			}
 
-			stateKeys, err := tx.StateKeys(sm)
-			if err != nil {
-				// Drop bad transaction and continue
+			// Skip any transactions the policy does not want to include
+			if !policy.Include(ctx, tx) {
+				restorable = append(restorable, tx)
+				continue
+			}
+			candidates = append(candidates, []*Transaction{tx})
+		}
+
+		queued := 0
+		for _, lgroup := range policy.Order(ctx, candidates) {
+			group := lgroup
+
+			// Each transaction is only allowed to access the keys it specifies, but
+			// the group must be executed with exclusive access to all of them.
+			var (
+				stateKeys = make(state.Keys)
+				txKeys    = make([]state.Keys, len(group))
+				invalid   bool
+			)
+			for i, tx := range group {
+				txStateKeys, err := tx.StateKeys(sm)
+				if err != nil {
+					invalid = true
+					break
+				}
+				txKeys[i] = txStateKeys
+				for k, permissions := range txStateKeys {
+					stateKeys.Add(k, permissions)
+				}
+			}
+			if invalid {
+				// Drop bad group and continue
 				//
 				// This should not happen because we check this before
 				// adding a transaction to the mempool.
@@ -192,18 +337,21 @@ func BuildBlock(
 
 			// Once we get part way through a prefetching job, we start
 			// to prepare for the next stream.
-			if i == streamPrefetchThreshold {
+			if queued < streamPrefetchThreshold && queued+len(group) >= streamPrefetchThreshold {
 				prepareStreamLock.Lock()
 				go func() {
 					mempool.PrepareStream(ctx, streamBatch)
 					prepareStreamLock.Unlock()
 				}()
 			}
+			queued += len(group)
 
 			// We track pending transactions because an error may cause us
 			// not to execute restorable transactions.
 			pendingLock.Lock()
-			pending[tx.ID()] = tx
+			for _, tx := range group {
+				pending[tx.ID()] = tx
+			}
 			pendingLock.Unlock()
 			e.Run(stateKeys, func() error {
 				// We use defer here instead of covering all returns because it is
@@ -211,15 +359,15 @@ func BuildBlock(
 				var restore bool
 				defer func() {
 					pendingLock.Lock()
-					delete(pending, tx.ID())
+					for _, tx := range group {
+						delete(pending, tx.ID())
+					}
 					pendingLock.Unlock()
 
 					if !restore {
 						return
 					}
-					restorableLock.Lock()
-					restorable = append(restorable, tx)
-					restorableLock.Unlock()
+					restoreTxs(group)
 				}()
 
 				// Fetch keys from cache
@@ -271,87 +419,149 @@ func BuildBlock(
 					}()
 				}
 
-				// Execute block
-				tsv := ts.NewView(stateKeys, storage)
-				authCUs, err := tx.PreExecute(ctx, feeManager, sm, r, tsv, nextTime)
-				if err != nil {
-					// We don't need to rollback [tsv] here because it will never
-					// be committed.
-					if HandlePreExecute(log, err) {
-						restore = true
+				// Transactions in a group are executed on top of a temporary [tstate.TState]
+				// (populated with the latest values of the group's keys), so that none of their
+				// changes are committed unless all of them can be included.
+				var (
+					groupView    *tstate.TStateView
+					groupTState  *tstate.TState
+					groupStorage map[string][]byte
+				)
+				if len(group) > 1 {
+					groupView = ts.NewView(allKeys(maps.Keys(stateKeys)), storage)
+					groupStorage = make(map[string][]byte, len(stateKeys))
+					for k := range stateKeys {
+						v, err := groupView.GetValue(ctx, []byte(k))
+						if errors.Is(err, database.ErrNotFound) {
+							continue
+						} else if err != nil {
+							return err
+						}
+						groupStorage[k] = v
 					}
-					return nil
+					groupTState = tstate.New(len(stateKeys))
 				}
 
-				// Verify warp message, if it exists
-				//
-				// We don't drop invalid warp messages because we must collect fees for
-				// the work the sender made us do (otherwise this would be a DoS).
-				//
-				// We wait as long as possible to verify the signature to ensure we don't
-				// spend unnecessary time on an invalid tx.
-				var warpErr error
-				if tx.WarpMessage != nil {
-					// We do not check the validity of [SourceChainID] because a VM could send
-					// itself a message to trigger a chain upgrade.
-					allowed, num, denom := r.GetWarpConfig(tx.WarpMessage.SourceChainID)
-					if allowed {
-						warpErr = tx.WarpMessage.Signature.Verify(
-							ctx, &tx.WarpMessage.UnsignedMessage, r.NetworkID(),
-							vdrState, blockContext.PChainHeight, num, denom,
-						)
+				// Execute transactions
+				var (
+					tsvs        = make([]*tstate.TStateView, len(group))
+					txResults   = make([]*Result, len(group))
+					txReceipts  = make([]*Receipt, len(group))
+					warpErrs    = make([]error, len(group))
+					txsConsumed Dimensions
+				)
+				for i, tx := range group {
+					var tsv *tstate.TStateView
+					if groupTState != nil {
+						tsv = groupTState.NewView(txKeys[i], groupStorage)
 					} else {
-						warpErr = ErrDisabledChainID
+						tsv = ts.NewView(txKeys[i], storage)
 					}
-					if warpErr != nil {
-						log.Warn(
-							"warp verification failed",
-							zap.Stringer("txID", tx.ID()),
-							zap.Error(warpErr),
-						)
+					if storeReceipts {
+						tsv.EnableReadTracking()
+					}
+					authCUs, err := tx.PreExecute(ctx, feeManager, sm, r, tsv, nextTime)
+					if err != nil {
+						// We don't need to rollback [tsv] here because it will never
+						// be committed.
+						if HandlePreExecute(log, err) {
+							restore = true
+						}
+						return nil
 					}
-				}
 
-				// If execution works, keep moving forward with new state
-				//
-				// Note, these calculations must match block verification exactly
-				// otherwise they will produce a different state root.
-				blockLock.RLock()
-				reads := make(map[string]uint16, len(stateKeys))
-				var invalidStateKeys bool
-				for k := range stateKeys {
-					v := storage[k]
-					numChunks, ok := keys.NumChunks(v)
-					if !ok {
-						invalidStateKeys = true
-						break
+					// Verify warp message, if it exists
+					//
+					// We don't drop invalid warp messages because we must collect fees for
+					// the work the sender made us do (otherwise this would be a DoS).
+					//
+					// We wait as long as possible to verify the signature to ensure we don't
+					// spend unnecessary time on an invalid tx.
+					var warpErr error
+					if tx.WarpMessage != nil {
+						// We do not check the validity of [SourceChainID] because a VM could send
+						// itself a message to trigger a chain upgrade.
+						allowed, num, denom := r.GetWarpConfig(tx.WarpMessage.SourceChainID)
+						if allowed {
+							warpErr = tx.WarpMessage.Signature.Verify(
+								ctx, &tx.WarpMessage.UnsignedMessage, r.NetworkID(),
+								vdrState, blockContext.PChainHeight, num, denom,
+							)
+						} else {
+							warpErr = ErrDisabledChainID
+						}
+						if warpErr != nil {
+							log.Warn(
+								"warp verification failed",
+								zap.Stringer("txID", tx.ID()),
+								zap.Error(warpErr),
+							)
+						}
 					}
-					reads[k] = numChunks
-				}
-				blockLock.RUnlock()
-				if invalidStateKeys {
-					// This should not happen because we check this before
-					// adding a transaction to the mempool.
-					log.Warn("invalid tx: invalid state keys")
-					return nil
-				}
-				result, err := tx.Execute(
-					ctx,
-					feeManager,
-					authCUs,
-					reads,
-					sm,
-					r,
-					tsv,
-					nextTime,
-					tx.WarpMessage != nil && warpErr == nil,
-				)
-				if err != nil {
-					// Returning an error here should be avoided at all costs (can be a DoS). Rather,
-					// all units for the transaction should be consumed and a fee should be charged.
-					log.Warn("unexpected post-execution error", zap.Error(err))
-					restore = true
-					return err
+
+					// If execution works, keep moving forward with new state
+					//
+					// Note, these calculations must match block verification exactly
+					// otherwise they will produce a different state root.
+					reads := make(map[string]uint16, len(txKeys[i]))
+					var invalidStateKeys bool
+					for k := range txKeys[i] {
+						v := storage[k]
+						numChunks, ok := keys.NumChunks(v)
+						if !ok {
+							invalidStateKeys = true
+							break
+						}
+						reads[k] = numChunks
+					}
+					if invalidStateKeys {
+						// This should not happen because we check this before
+						// adding a transaction to the mempool.
+						log.Warn("invalid tx: invalid state keys")
+						return nil
+					}
+					result, err := tx.Execute(
+						ctx,
+						feeManager,
+						authCUs,
+						reads,
+						sm,
+						r,
+						tsv,
+						nextTime,
+						tx.WarpMessage != nil && warpErr == nil,
+					)
+					if err != nil {
+						// Returning an error here should be avoided at all costs (can be a DoS). Rather,
+						// all units for the transaction should be consumed and a fee should be charged.
+						log.Warn("unexpected post-execution error", zap.Error(err))
+						restore = true
+						return err
+					}
+					if groupTState != nil {
+						// A group is only included if all of its transactions succeed
+						if !result.Success {
+							log.Debug(
+								"dropping group: transaction failed",
+								zap.Stringer("txID", tx.ID()),
+								zap.Int("size", len(group)),
+							)
+							return nil
+						}
+
+						// Record changes before they are committed to [groupTState]
+						if storeReceipts {
+							txReceipts[i] = newReceipt(ctx, tx.ID(), tsv)
+						}
+						tsv.Commit()
+					}
+					txsConsumed, err = Add(txsConsumed, result.Consumed)
+					if err != nil {
+						return err
+					}
+					tsvs[i] = tsv
+					txResults[i] = result
+					warpErrs[i] = warpErr
 				}
 
 				// Need to atomically check there aren't too many warp messages and add to block
@@ -359,34 +569,64 @@ func BuildBlock(
 				defer blockLock.Unlock()
 
 				// Ensure block isn't too big
-				if ok, dimension := feeManager.Consume(result.Consumed, maxUnits); !ok {
+				reservation := capacity.match(group)
+				if ok, dimension := feeManager.Consume(txsConsumed, capacity.limit(reservation)); !ok {
 					log.Debug(
 						"skipping tx: too many units",
 						zap.Int("dimension", int(dimension)),
-						zap.Uint64("tx", result.Consumed[dimension]),
+						zap.Uint64("tx", txsConsumed[dimension]),
 						zap.Uint64("block units", feeManager.LastConsumed(dimension)),
 						zap.Uint64("max block units", maxUnits[dimension]),
+						zap.Uint64("reserved block units", capacity.reserved(dimension)),
+						zap.Int("size", len(group)),
 					)
 					restore = true
 
-					// If we are above the target for the dimension we can't consume, we will
-					// stop building. This prevents a full mempool iteration looking for the
-					// "perfect fit".
-					if feeManager.LastConsumed(dimension) >= targetUnits[dimension] {
+					// If we are above the target for the dimension we can't consume (less any
+					// capacity still reserved for other transactions, which may never show up),
+					// we will stop building. This prevents a full mempool iteration looking for
+					// the "perfect fit".
+					if feeManager.LastConsumed(dimension) >= capacity.target(reservation, targetUnits)[dimension] {
 						return errBlockFull
 					}
+					return nil
 				}
-
-				// Update block with new transaction
-				tsv.Commit()
-				b.Txs = append(b.Txs, tx)
-				results = append(results, result)
-				if tx.WarpMessage != nil {
-					if warpErr == nil {
-						// Add a bit if the warp message was verified
-						b.WarpResults.Add(warpAdded)
+				capacity.consume(reservation, txsConsumed)
+
+				// Update block with new transactions
+				if groupView != nil {
+					for k, v := range groupTState.ChangedKeys() {
+						var err error
+						if v.HasValue() {
+							err = groupView.Insert(ctx, []byte(k), v.Value())
+						} else {
+							err = groupView.Remove(ctx, []byte(k))
+						}
+						if err != nil {
+							return err
+						}
+					}
+					groupView.Commit()
+				} else {
+					if storeReceipts {
+						// Record changes before they are committed
+						txReceipts[0] = newReceipt(ctx, group[0].ID(), tsvs[0])
+					}
+					tsvs[0].Commit()
+				}
+				for i, tx := range group {
+					if storeReceipts {
+						receipts = append(receipts, txReceipts[i])
+					}
+					b.Txs = append(b.Txs, tx)
+					results = append(results, txResults[i])
+					if tx.WarpMessage != nil {
+						if warpErrs[i] == nil {
+							// Add a bit if the warp message was verified
+							b.WarpResults.Add(warpAdded)
+						}
+						warpAdded++
 					}
-					warpAdded++
 				}
 				return nil
 			})
@@ -396,17 +636,17 @@ func BuildBlock(
 
 		// Handle execution result
 		if execErr != nil {
-			for _, tx := range pending {
-				// If we stopped executing, make sure to add those txs back
-				restorable = append(restorable, tx)
-			}
+			// If we stopped executing, make sure to add those txs back
+			restoreTxs(maps.Values(pending))
 			if !errors.Is(execErr, errBlockFull) {
 				// Wait for stream preparation to finish to make
 				// sure all transactions are returned to the mempool.
+				restoreTxs(b.Txs)
 				go func() {
 					prepareStreamLock.Lock() // we never need to unlock this as it will not be used after this
-					restored := mempool.FinishStreaming(ctx, append(b.Txs, restorable...))
-					b.vm.Logger().Debug("transactions restored to mempool", zap.Int("count", restored))
+					restored := mempool.FinishStreaming(ctx, restorable)
+					bundlesRestored := bundlePool.FinishStreaming(ctx, restorableBundles)
+					b.vm.Logger().Debug("transactions restored to mempool", zap.Int("count", restored), zap.Int("bundles", bundlesRestored))
 				}()
 				b.vm.Logger().Warn("build failed", zap.Error(execErr))
 				return nil, execErr
@@ -420,7 +660,8 @@ func BuildBlock(
 	go func() {
 		prepareStreamLock.Lock()
 		restored := mempool.FinishStreaming(ctx, restorable)
-		b.vm.Logger().Debug("transactions restored to mempool", zap.Int("count", restored))
+		bundlesRestored := bundlePool.FinishStreaming(ctx, restorableBundles)
+		b.vm.Logger().Debug("transactions restored to mempool", zap.Int("count", restored), zap.Int("bundles", bundlesRestored))
 	}()
 
 	// Update tracking metrics
@@ -440,13 +681,18 @@ func BuildBlock(
 		vm.RecordEmptyBlockBuilt()
 	}
 
+	// Distribute fees
+	if err := distributeBlockFees(ctx, vm, ts, parentView, feeManager, b.Txs, forfeited); err != nil {
+		return nil, err
+	}
+
 	// Update chain metadata
 	heightKey := HeightKey(sm.HeightKey())
 	heightKeyStr := string(heightKey)
 	timestampKey := TimestampKey(b.vm.StateManager().TimestampKey())
 	timestampKeyStr := string(timestampKey)
 	feeKeyStr := string(feeKey)
-	tsv := ts.NewView(set.Of(heightKeyStr, timestampKeyStr, feeKeyStr), map[string][]byte{
+	tsv := ts.NewView(state.Keys{heightKeyStr: state.All, timestampKeyStr: state.All, feeKeyStr: state.All}, map[string][]byte{
 		heightKeyStr:    binary.BigEndian.AppendUint64(nil, parent.Hght),
 		timestampKeyStr: binary.BigEndian.AppendUint64(nil, uint64(parent.Tmstmp)),
 		feeKeyStr:       parentFeeManager.Bytes(),
@@ -477,7 +723,7 @@ func BuildBlock(
 	}
 
 	// Compute block hash and marshaled representation
-	if err := b.initializeBuilt(ctx, view, results, feeManager); err != nil {
+	if err := b.initializeBuilt(ctx, view, results, receipts, feeManager); err != nil {
 		log.Warn("block failed", zap.Int("txs", len(b.Txs)), zap.Any("consumed", feeManager.UnitsConsumed()))
 		return nil, err
 	}
@@ -0,0 +1,173 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ mempool.MultiSponsorItem = (*Bundle)(nil)
+
+// Bundle is an ordered group of transactions that must be included in the
+// same block (contiguously and in order) or not at all.
+//
+// Bundles are only a block building (and gossip) concept. Once included in a
+// block, the transactions in a [Bundle] are verified like any other
+// transaction.
+type Bundle struct {
+	Txs []*Transaction
+
+	id   ids.ID
+	size int
+}
+
+// NewBundle creates a [Bundle] containing [txs] (in order).
+func NewBundle(txs []*Transaction) (*Bundle, error) {
+	if len(txs) < 2 {
+		return nil, ErrBundleTooSmall
+	}
+	if len(txs) > MaxBundleTxs {
+		return nil, ErrBundleTooLarge
+	}
+	var (
+		txIDs = set.NewSet[ids.ID](len(txs))
+		idb   = make([]byte, 0, len(txs)*consts.IDLen)
+		size  = consts.IntLen
+	)
+	for _, tx := range txs {
+		txID := tx.ID()
+		if txIDs.Contains(txID) {
+			return nil, ErrDuplicateInBundle
+		}
+		txIDs.Add(txID)
+		idb = append(idb, txID[:]...)
+		size += tx.Size()
+	}
+	return &Bundle{
+		Txs:  txs,
+		id:   utils.ToID(idb),
+		size: size,
+	}, nil
+}
+
+// ID is the hash of the IDs of all transactions in the [Bundle].
+func (b *Bundle) ID() ids.ID { return b.id }
+
+func (b *Bundle) Size() int { return b.size }
+
+// Expiry is the earliest expiry of any transaction in the [Bundle].
+func (b *Bundle) Expiry() int64 {
+	expiry := b.Txs[0].Expiry()
+	for _, tx := range b.Txs[1:] {
+		if tx.Expiry() < expiry {
+			expiry = tx.Expiry()
+		}
+	}
+	return expiry
+}
+
+// Sponsor is the sponsor of the first transaction in the [Bundle].
+func (b *Bundle) Sponsor() codec.Address { return b.Txs[0].Sponsor() }
+
+// Nonce is always 0 because bundles are not sequenced (the transactions
+// in a [Bundle] are already ordered).
+func (*Bundle) Nonce() uint64 { return 0 }
+
+// PriorityFee is the sum of the [PriorityFee] of all transactions in the [Bundle].
+func (b *Bundle) PriorityFee() uint64 {
+	var fee uint64
+	for _, tx := range b.Txs {
+		fee += tx.PriorityFee()
+	}
+	return fee
+}
+
+// MaxFee is the sum of the [MaxFee] of all transactions in the [Bundle].
+func (b *Bundle) MaxFee() uint64 {
+	var fee uint64
+	for _, tx := range b.Txs {
+		fee += tx.MaxFee()
+	}
+	return fee
+}
+
+// Fees is the sum of the [MaxFee] and [PriorityFee] of the transactions in
+// the [Bundle] paid by each sponsor.
+func (b *Bundle) Fees() map[codec.Address]uint64 {
+	fees := make(map[codec.Address]uint64, len(b.Txs))
+	for _, tx := range b.Txs {
+		fees[tx.Sponsor()] += tx.MaxFee() + tx.PriorityFee()
+	}
+	return fees
+}
+
+func (b *Bundle) Marshal(p *codec.Packer) error {
+	p.PackInt(len(b.Txs))
+	for _, tx := range b.Txs {
+		if err := tx.Marshal(p); err != nil {
+			return err
+		}
+	}
+	return p.Err()
+}
+
+func MarshalBundles(bundles []*Bundle) ([]byte, error) {
+	if len(bundles) == 0 {
+		return nil, ErrNoTxs
+	}
+	size := consts.IntLen + codec.CummSize(bundles)
+	p := codec.NewWriter(size, consts.NetworkSizeLimit)
+	p.PackInt(len(bundles))
+	for _, bundle := range bundles {
+		if err := bundle.Marshal(p); err != nil {
+			return nil, err
+		}
+	}
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalBundles(
+	raw []byte,
+	initialCapacity int,
+	actionRegistry ActionRegistry,
+	authRegistry AuthRegistry,
+) (map[uint8]int, []*Bundle, error) {
+	p := codec.NewReader(raw, consts.NetworkSizeLimit)
+	bundleCount := p.UnpackInt(true)
+	authCounts := map[uint8]int{}
+	bundles := make([]*Bundle, 0, initialCapacity) // DoS to set size to bundleCount
+	for i := 0; i < bundleCount; i++ {
+		txCount := p.UnpackInt(true)
+		if txCount > MaxBundleTxs {
+			return nil, nil, ErrBundleTooLarge
+		}
+		txs := make([]*Transaction, 0, txCount)
+		for j := 0; j < txCount; j++ {
+			tx, err := UnmarshalTx(p, actionRegistry, authRegistry)
+			if err != nil {
+				return nil, nil, err
+			}
+			txs = append(txs, tx)
+			for _, auth := range tx.Auths() {
+				authCounts[auth.GetTypeID()]++
+			}
+		}
+		bundle, err := NewBundle(txs)
+		if err != nil {
+			return nil, nil, err
+		}
+		bundles = append(bundles, bundle)
+	}
+	if !p.Empty() {
+		// Ensure no leftover bytes
+		return nil, nil, ErrInvalidObject
+	}
+	return authCounts, bundles, p.Err()
+}
@@ -0,0 +1,246 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const bundleAuthTypeID uint8 = 1
+
+var _ Auth = (*bundleAuth)(nil)
+
+// bundleAuth is a minimal [Auth] that can be marshaled and unmarshaled.
+type bundleAuth struct {
+	actor codec.Address
+}
+
+func (*bundleAuth) GetTypeID() uint8                                         { return bundleAuthTypeID }
+func (*bundleAuth) ValidRange(Rules) (int64, int64)                          { return -1, -1 }
+func (*bundleAuth) MaxComputeUnits(Rules) uint64                             { return 1 }
+func (*bundleAuth) StateKeys() []string                                      { return nil }
+func (*bundleAuth) AsyncVerify([]byte) error                                 { return nil }
+func (a *bundleAuth) Actor() codec.Address                                   { return a.actor }
+func (a *bundleAuth) Sponsor() codec.Address                                 { return a.actor }
+func (a *bundleAuth) Marshal(p *codec.Packer)                                { p.PackAddress(a.actor) }
+func (*bundleAuth) Size() int                                                { return codec.AddressLen }
+func (*bundleAuth) CanDeduct(context.Context, state.Immutable, uint64) error { return nil }
+func (*bundleAuth) Deduct(context.Context, state.Mutable, uint64) error      { return nil }
+func (*bundleAuth) Refund(context.Context, state.Mutable, uint64) error      { return nil }
+
+func (*bundleAuth) Verify(context.Context, Rules, state.Immutable, []Action) (uint64, error) {
+	return 1, nil
+}
+
+func unmarshalBundleAuth(p *codec.Packer, _ *warp.Message) (Auth, error) {
+	var a bundleAuth
+	p.UnpackAddress(&a.actor)
+	return &a, p.Err()
+}
+
+func bundleRegistries(t *testing.T) (ActionRegistry, AuthRegistry) {
+	actionRegistry := codec.NewTypeParser[Action, *warp.Message, bool]()
+	authRegistry := codec.NewTypeParser[Auth, *warp.Message, bool]()
+	require.NoError(t, authRegistry.Register(bundleAuthTypeID, unmarshalBundleAuth, false))
+	return actionRegistry, authRegistry
+}
+
+// bundleTx returns a signed [Transaction] (that cancels a random transaction)
+// from [actor].
+func bundleTx(t *testing.T, actor codec.Address, timestamp int64, maxFee uint64, priorityFee uint64) *Transaction {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	actionRegistry, authRegistry := bundleRegistries(t)
+
+	factory := NewMockAuthFactory(ctrl)
+	factory.EXPECT().Sign(gomock.Any(), gomock.Any()).Return(&bundleAuth{actor: actor}, nil)
+	tx, err := NewCancelTx(&Base{
+		Timestamp:   timestamp,
+		ChainID:     ids.GenerateTestID(),
+		MaxFee:      maxFee,
+		PriorityFee: priorityFee,
+	}, ids.GenerateTestID()).Sign(factory, actionRegistry, authRegistry)
+	require.NoError(err)
+	return tx
+}
+
+func TestNewBundle(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		sponsor = codec.CreateAddress(bundleAuthTypeID, ids.GenerateTestID())
+		other   = codec.CreateAddress(bundleAuthTypeID, ids.GenerateTestID())
+		tx0     = bundleTx(t, sponsor, 3_000, 10, 1)
+		tx1     = bundleTx(t, other, 1_000, 20, 2)
+		tx2     = bundleTx(t, sponsor, 2_000, 30, 3)
+	)
+	bundle, err := NewBundle([]*Transaction{tx0, tx1, tx2})
+	require.NoError(err)
+
+	idb := make([]byte, 0, 3*consts.IDLen)
+	for _, tx := range []*Transaction{tx0, tx1, tx2} {
+		txID := tx.ID()
+		idb = append(idb, txID[:]...)
+	}
+	require.Equal(utils.ToID(idb), bundle.ID())
+	require.Equal(consts.IntLen+tx0.Size()+tx1.Size()+tx2.Size(), bundle.Size())
+	require.Equal(int64(1_000), bundle.Expiry())
+	require.Equal(sponsor, bundle.Sponsor())
+	require.Zero(bundle.Nonce())
+	require.Equal(uint64(6), bundle.PriorityFee())
+	require.Equal(uint64(60), bundle.MaxFee())
+	require.Equal(map[codec.Address]uint64{sponsor: 44, other: 22}, bundle.Fees())
+
+	// The ID depends on the order of transactions
+	reordered, err := NewBundle([]*Transaction{tx1, tx0, tx2})
+	require.NoError(err)
+	require.NotEqual(bundle.ID(), reordered.ID())
+	require.Equal(other, reordered.Sponsor())
+}
+
+func TestNewBundleInvalid(t *testing.T) {
+	sponsor := codec.CreateAddress(bundleAuthTypeID, ids.GenerateTestID())
+	tx := bundleTx(t, sponsor, 1_000, 10, 0)
+	tooLarge := make([]*Transaction, 0, MaxBundleTxs+1)
+	for i := 0; i < MaxBundleTxs+1; i++ {
+		tooLarge = append(tooLarge, bundleTx(t, sponsor, 1_000, 10, 0))
+	}
+	tests := []struct {
+		name string
+		txs  []*Transaction
+		err  error
+	}{
+		{
+			name: "empty",
+			err:  ErrBundleTooSmall,
+		},
+		{
+			name: "single tx",
+			txs:  []*Transaction{tx},
+			err:  ErrBundleTooSmall,
+		},
+		{
+			name: "too many txs",
+			txs:  tooLarge,
+			err:  ErrBundleTooLarge,
+		},
+		{
+			name: "duplicate tx",
+			txs:  []*Transaction{tx, bundleTx(t, sponsor, 1_000, 10, 0), tx},
+			err:  ErrDuplicateInBundle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBundle(tt.txs)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestMarshalUnmarshalBundles(t *testing.T) {
+	require := require.New(t)
+	actionRegistry, authRegistry := bundleRegistries(t)
+
+	sponsor := codec.CreateAddress(bundleAuthTypeID, ids.GenerateTestID())
+	bundle0, err := NewBundle([]*Transaction{
+		bundleTx(t, sponsor, 1_000, 10, 1),
+		bundleTx(t, sponsor, 1_000, 10, 1),
+	})
+	require.NoError(err)
+	bundle1, err := NewBundle([]*Transaction{
+		bundleTx(t, sponsor, 2_000, 20, 2),
+		bundleTx(t, sponsor, 2_000, 20, 2),
+		bundleTx(t, sponsor, 2_000, 20, 2),
+	})
+	require.NoError(err)
+
+	raw, err := MarshalBundles([]*Bundle{bundle0, bundle1})
+	require.NoError(err)
+	require.Len(raw, consts.IntLen+bundle0.Size()+bundle1.Size())
+
+	authCounts, bundles, err := UnmarshalBundles(raw, 2, actionRegistry, authRegistry)
+	require.NoError(err)
+	require.Equal(map[uint8]int{bundleAuthTypeID: 5}, authCounts)
+	require.Len(bundles, 2)
+	for i, expected := range []*Bundle{bundle0, bundle1} {
+		require.Equal(expected.ID(), bundles[i].ID())
+		require.Equal(expected.Size(), bundles[i].Size())
+		require.Len(bundles[i].Txs, len(expected.Txs))
+		for j, tx := range expected.Txs {
+			require.Equal(tx.ID(), bundles[i].Txs[j].ID())
+			require.Equal(tx.Bytes(), bundles[i].Txs[j].Bytes())
+		}
+	}
+}
+
+func TestMarshalUnmarshalBundlesInvalid(t *testing.T) {
+	actionRegistry, authRegistry := bundleRegistries(t)
+
+	sponsor := codec.CreateAddress(bundleAuthTypeID, ids.GenerateTestID())
+	tx0 := bundleTx(t, sponsor, 1_000, 10, 0)
+	tx1 := bundleTx(t, sponsor, 1_000, 10, 0)
+	bundle, err := NewBundle([]*Transaction{tx0, tx1})
+	require.NoError(t, err)
+	valid, err := MarshalBundles([]*Bundle{bundle})
+	require.NoError(t, err)
+
+	// encode packs [txs] as a single bundle (without validation)
+	encode := func(txCount int, txs ...*Transaction) []byte {
+		p := codec.NewWriter(0, consts.NetworkSizeLimit)
+		p.PackInt(1)
+		p.PackInt(txCount)
+		for _, tx := range txs {
+			require.NoError(t, tx.Marshal(p))
+		}
+		require.NoError(t, p.Err())
+		return p.Bytes()
+	}
+	tests := []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{
+			name: "leftover bytes",
+			raw:  append(append([]byte{}, valid...), 0),
+			err:  ErrInvalidObject,
+		},
+		{
+			name: "too many txs",
+			raw:  encode(MaxBundleTxs + 1),
+			err:  ErrBundleTooLarge,
+		},
+		{
+			name: "too few txs",
+			raw:  encode(1, tx0),
+			err:  ErrBundleTooSmall,
+		},
+		{
+			name: "duplicate tx",
+			raw:  encode(2, tx0, tx0),
+			err:  ErrDuplicateInBundle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := UnmarshalBundles(tt.raw, 1, actionRegistry, authRegistry)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err = MarshalBundles(nil)
+	require.ErrorIs(t, err, ErrNoTxs)
+}
@@ -18,6 +18,21 @@ const (
 	// nodes may not build during their allocated window to avoid increasing the skew of the
 	// chain time.
 	FutureBound = 1 * time.Second
+	// MaxAccessListKeys is the maximum number of keys that can be included in
+	// the [Transaction.AccessList].
+	MaxAccessListKeys = 64
+	// MaxAccessListKeySize is the maximum size of a key in the [Transaction.AccessList].
+	MaxAccessListKeySize = 1 * units.KiB
+	// MaxScheduledActionsPerSecond is the maximum number of actions that can be scheduled
+	// to execute in any one second.
+	MaxScheduledActionsPerSecond = 32
+	// MaxScheduleScan is the maximum number of seconds of scheduled actions that are
+	// checked in a single block. If more time than this has passed since the last block,
+	// the remaining scheduled actions are checked in subsequent blocks.
+	MaxScheduleScan = 600
+	// MaxBundleTxs is the maximum number of transactions that can be included in
+	// a [Bundle].
+	MaxBundleTxs = 16
 	// MaxWarpMessageSize is the maximum size of a warp message.
 	MaxWarpMessageSize = 256 * units.KiB
 	// MaxWarpMessages is the maximum number of warp messages allows in a single
@@ -34,6 +49,10 @@ const (
 	HeightKeyChunks       = 1
 	TimestampKeyChunks    = 1
 	FeeKeyChunks          = 8 // 96 (per dimension) * 5 (num dimensions)
+	NonceKeyChunks        = 1
+	SchedulerKeyChunks    = 1
+	ScheduleKeyChunks     = 17 // 32 (actions per second) * 32 (ID length)
+	ScheduledActionChunks = 16
 )
 
 func HeightKey(prefix []byte) []byte {
@@ -47,3 +66,7 @@ func TimestampKey(prefix []byte) []byte {
 func FeeKey(prefix []byte) []byte {
 	return keys.EncodeChunks(prefix, FeeKeyChunks)
 }
+
+func NonceKey(prefix []byte) []byte {
+	return keys.EncodeChunks(prefix, NonceKeyChunks)
+}
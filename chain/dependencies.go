@@ -52,13 +52,26 @@ type VM interface {
 
 	State() (merkledb.MerkleDB, error)
 	StateManager() StateManager
+	FeeDistributor() FeeDistributor
+	BlockBuildPolicy() BlockBuildPolicy
 	ValidatorState() validators.State
 
 	Mempool() Mempool
+	BundleMempool() BundleMempool
 	IsRepeat(context.Context, []*Transaction, set.Bits, bool) set.Bits
 	GetTargetBuildDuration() time.Duration
 	GetTransactionExecutionCores() int
 
+	// GetOptimisticExecution returns true if transactions should be executed
+	// speculatively (without regard for the keys they specify) when verifying
+	// a block. Transactions that observe stale state are re-executed, so the
+	// result of execution is identical to serial execution.
+	GetOptimisticExecution() bool
+
+	// GetStoreReceipts returns true if a [Receipt] should be generated for each
+	// [Transaction] executed in a block.
+	GetStoreReceipts() bool
+
 	Verified(context.Context, *StatelessBlock)
 	Rejected(context.Context, *StatelessBlock)
 	Accepted(context.Context, *StatelessBlock)
@@ -109,6 +122,24 @@ type Mempool interface {
 	FinishStreaming(context.Context, []*Transaction) int
 }
 
+// BundleMempool holds [Bundle]s separately from other transactions, so that
+// they are always streamed (and restored) as a unit.
+type BundleMempool interface {
+	Len(context.Context) int  // bundles
+	Size(context.Context) int // bytes
+	Add(context.Context, []*Bundle)
+
+	Top(
+		context.Context,
+		time.Duration,
+		func(context.Context, *Bundle) (cont bool, rest bool, err error),
+	) error
+
+	StartStreaming(context.Context)
+	Stream(context.Context, int) []*Bundle
+	FinishStreaming(context.Context, []*Bundle) int
+}
+
 type Rules interface {
 	// Should almost always be constant (unless there is a fork of
 	// a live network)
@@ -119,6 +150,8 @@ type Rules interface {
 	GetMinEmptyBlockGap() int64 // in milliseconds
 	GetValidityWindow() int64   // in milliseconds
 
+	GetMaxActionsPerTx() uint8
+
 	GetMinUnitPrice() Dimensions
 	GetUnitPriceChangeDenominator() Dimensions
 	GetWindowTargetUnits() Dimensions
@@ -150,9 +183,38 @@ type Rules interface {
 
 	GetWarpConfig(sourceChainID ids.ID) (bool, uint64, uint64)
 
+	// Scheduled actions are executed at the start of each block (before any transactions)
+	// until either of these limits is reached. Any remaining scheduled actions are executed
+	// in subsequent blocks.
+	GetMaxScheduledActions() int      // per block
+	GetMaxScheduledUnits() Dimensions // per block
+
+	// GetActionActivation returns the timestamp (in ms) at which the [Action] with
+	// [typeID] is activated by an upgrade. Before this time, any transaction that
+	// includes the [Action] is invalid.
+	//
+	// -1 means the [Action] is always active
+	GetActionActivation(typeID uint8) int64
+	// GetAuthActivation is like [GetActionActivation] but for the [Auth] with [typeID].
+	GetAuthActivation(typeID uint8) int64
+
 	FetchCustom(string) (any, bool)
 }
 
+// StateRules are [Rules] that depend on state (i.e. parameters that can be
+// modified on-chain).
+//
+// [Load] is called with the state of the parent of any block being built or
+// verified, so changes made by a block apply to its children (and not to the
+// block itself).
+type StateRules interface {
+	Rules
+
+	// Load returns the [Rules] to use on top of [im]. It must be deterministic
+	// and should not modify [im].
+	Load(ctx context.Context, im state.Immutable) (Rules, error)
+}
+
 // StateManager allows [Chain] to safely store certain types of items in state
 // in a structured manner. If we did not use [StateManager], we may overwrite
 // state written by actions or auth.
@@ -164,10 +226,79 @@ type StateManager interface {
 	TimestampKey() []byte
 	FeeKey() []byte
 
+	// NonceKey stores the [Base.Nonce] of the last transaction executed by [addr]
+	// that specified a [Base.Nonce].
+	NonceKey(addr codec.Address) []byte
+
+	// SchedulerPrefix is the prefix of all keys used to store scheduled actions (see
+	// [Schedule]).
+	SchedulerPrefix() []byte
+
+	// PriorityFeeStateKeys are the keys that could be modified by [PayPriorityFees]. All
+	// keys must be suffixed with the number of chunks that could be read from them (like
+	// [Action.StateKeys]).
+	PriorityFeeStateKeys() []string
+	// PayPriorityFees credits [amount] to the recipient of priority fees. It is called once
+	// per block (after all transactions are executed) with the sum of [Base.PriorityFee]
+	// paid by all transactions in the block.
+	//
+	// If there is no recipient, [PayPriorityFees] should do nothing (priority fees are
+	// burned).
+	PayPriorityFees(ctx context.Context, mu state.Mutable, amount uint64) error
+
 	IncomingWarpKeyPrefix(sourceChainID ids.ID, msgID ids.ID) []byte
 	OutgoingWarpKeyPrefix(txID ids.ID) []byte
 }
 
+// FeeDistributor determines what happens to the fees paid by all transactions in a
+// block (which are removed from state by [Auth.Deduct]).
+type FeeDistributor interface {
+	// StateKeys are the keys that could be modified by [Distribute]. All keys must be
+	// suffixed with the number of chunks that could be read from them (like
+	// [Action.StateKeys]).
+	StateKeys() []string
+
+	// Distribute is called once per block (after all transactions are executed and
+	// priority fees are paid) with the total fees paid for the units consumed in each
+	// dimension. It can be used to pay block producers or a treasury. To burn fees,
+	// [Distribute] should do nothing.
+	//
+	// [forfeited] is the sum of the fees prepaid by scheduled actions that were dropped
+	// and could not be refunded (because their [Auth] could no longer be parsed). Like
+	// [fees], it was already removed from state by [Auth.Deduct].
+	//
+	// Any changes made to [mu] are included in the state root of the block, so
+	// [Distribute] must be deterministic.
+	Distribute(ctx context.Context, fees Dimensions, forfeited uint64, mu state.Mutable) error
+}
+
+// BlockBuildPolicy determines which transactions [BuildBlock] attempts to include
+// in a block (and in what order). Verification does not depend on the policy used
+// to build a block, so each node can use a different policy.
+type BlockBuildPolicy interface {
+	// Reservations returns the capacity of each block that is reserved for specific
+	// transactions (like oracle updates). Transactions that do not match a [Reservation]
+	// can't consume its capacity, even if they would otherwise fit in the block.
+	//
+	// A group of transactions (see [Order]) consumes the first [Reservation] that
+	// all of its transactions match.
+	Reservations(r Rules) []*Reservation
+
+	// Include returns false if [tx] should not be included in the block being built.
+	// Excluded transactions are returned to the [Mempool].
+	Include(ctx context.Context, tx *Transaction) bool
+
+	// Order sorts a batch of transactions streamed from the [Mempool] and
+	// [BundleMempool] into groups. Each group is included contiguously (in the
+	// order provided) or not at all. Groups are attempted in the order they are
+	// returned, although non-conflicting groups may be executed concurrently.
+	//
+	// Each [Bundle] is provided as a single group (all other transactions are
+	// provided in their own group). Every group in [groups] must be returned
+	// (bundles may be merged with other groups but not split).
+	Order(ctx context.Context, groups [][]*Transaction) [][]*Transaction
+}
+
 type Action interface {
 	// GetTypeID uniquely identifies each supported [Action]. We use IDs to avoid
 	// reflection.
@@ -191,14 +322,20 @@ type Action interface {
 	OutputsWarpMessage() bool
 
 	// StateKeys is a full enumeration of all database keys that could be touched during execution
-	// of an [Action]. This is used to prefetch state and will be used to parallelize execution (making
-	// an execution tree is trivial).
+	// of an [Action] (and how they could be touched). This is used to prefetch state and will be used
+	// to parallelize execution (making an execution tree is trivial).
+	//
+	// Keys that are only read should be specified with [state.Read]. Transactions that only read
+	// the same key can be executed concurrently and are not charged to allocate or write the key.
+	// Keys that could be modified should be specified with [state.All] (or [state.Write]).
 	//
 	// All keys specified must be suffixed with the number of chunks that could ever be read from that
 	// key (formatted as a big-endian uint16). This is used to automatically calculate storage usage.
 	//
 	// If any key is removed and then re-created, this will count as a creation instead of a modification.
-	StateKeys(auth Auth, txID ids.ID) []string
+	//
+	// [actionID] is unique to each [Action] in a [Transaction] (see [CreateActionID]).
+	StateKeys(auth Auth, actionID ids.ID) state.Keys
 
 	// StateKeysMaxChunks is used to estimate the fee a transaction should pay. It includes the max
 	// chunks each state key could use without requiring the state keys to actually be provided (may
@@ -213,13 +350,16 @@ type Action interface {
 	//
 	// An error should only be returned if a fatal error was encountered, otherwise [success] should
 	// be marked as false and fees will still be charged.
+	//
+	// If any [Action] in a [Transaction] is not successful, the changes made by all actions in
+	// the [Transaction] are reverted.
 	Execute(
 		ctx context.Context,
 		r Rules,
 		mu state.Mutable,
 		timestamp int64,
 		auth Auth,
-		txID ids.ID,
+		actionID ids.ID,
 		warpVerified bool,
 	) (success bool, computeUnits uint64, output []byte, warpMessage *warp.UnsignedMessage, err error)
 
@@ -278,7 +418,7 @@ type Auth interface {
 		ctx context.Context,
 		r Rules,
 		im state.Immutable,
-		action Action,
+		actions []Action,
 	) (computeUnits uint64, err error)
 
 	// Actor is the subject of [Action].
@@ -316,9 +456,38 @@ type Auth interface {
 	Size() int
 }
 
+// DigestAuth is an [Auth] that needs the digest of the [Transaction] (the
+// message passed to [AuthFactory.Sign]) to perform checks against state.
+//
+// If an [Auth] implements [DigestAuth], [VerifyDigest] is called instead of
+// [Verify].
+type DigestAuth interface {
+	Auth
+
+	VerifyDigest(
+		ctx context.Context,
+		r Rules,
+		im state.Immutable,
+		digest []byte,
+		actions []Action,
+	) (computeUnits uint64, err error)
+}
+
+// RecordingAuth is an [Auth] that records the [Action]s it authorizes in
+// state (for example, the amount spent by a session key).
+//
+// If [Transaction.Auth] implements [RecordingAuth], [RecordActions] is called
+// after the fee is deducted (regardless of whether the actions succeed or who
+// pays the fee). It may only modify keys returned by [StateKeys].
+type RecordingAuth interface {
+	Auth
+
+	RecordActions(ctx context.Context, mu state.Mutable) error
+}
+
 type AuthFactory interface {
 	// Sign is used by helpers, auth object should store internally to be ready for marshaling
-	Sign(msg []byte, action Action) (Auth, error)
+	Sign(msg []byte, actions []Action) (Auth, error)
 
 	MaxUnits() (bandwidth uint64, compute uint64, stateKeysCount []uint16)
 }
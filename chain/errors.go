@@ -30,26 +30,33 @@ var (
 	ErrInvalidBlockHeight   = errors.New("invalid block height")
 
 	// Tx Correctness
-	ErrInvalidSignature     = errors.New("invalid signature")
-	ErrDuplicateTx          = errors.New("duplicate transaction")
-	ErrInsufficientPrice    = errors.New("insufficient price")
-	ErrInvalidType          = errors.New("invalid tx type")
-	ErrInvalidID            = errors.New("invalid content ID")
-	ErrInvalidSchema        = errors.New("invalid schema")
-	ErrInvalidContent       = errors.New("invalid content")
-	ErrContentAlreadyExists = errors.New("content already exists")
-	ErrContentMissing       = errors.New("content does not exist")
-	ErrWrongOwner           = errors.New("wrong owner")
-	ErrInsufficientTip      = errors.New("insufficient tip")
-	ErrAccountNotEmpty      = errors.New("account not empty")
-	ErrServicerMissing      = errors.New("servicer missing")
-	ErrTooManyTxs           = errors.New("too many transactions")
-	ErrActionNotActivated   = errors.New("action not activated")
-	ErrAuthNotActivated     = errors.New("auth not activated")
-	ErrAuthFailed           = errors.New("auth failed")
-	ErrMisalignedTime       = errors.New("misaligned time")
-	ErrInvalidActor         = errors.New("invalid actor")
-	ErrInvalidSponsor       = errors.New("invalid sponsor")
+	ErrInvalidSignature      = errors.New("invalid signature")
+	ErrDuplicateTx           = errors.New("duplicate transaction")
+	ErrInsufficientPrice     = errors.New("insufficient price")
+	ErrInvalidType           = errors.New("invalid tx type")
+	ErrInvalidID             = errors.New("invalid content ID")
+	ErrInvalidSchema         = errors.New("invalid schema")
+	ErrInvalidContent        = errors.New("invalid content")
+	ErrContentAlreadyExists  = errors.New("content already exists")
+	ErrContentMissing        = errors.New("content does not exist")
+	ErrWrongOwner            = errors.New("wrong owner")
+	ErrInsufficientTip       = errors.New("insufficient tip")
+	ErrAccountNotEmpty       = errors.New("account not empty")
+	ErrServicerMissing       = errors.New("servicer missing")
+	ErrTooManyTxs            = errors.New("too many transactions")
+	ErrActionNotActivated    = errors.New("action not activated")
+	ErrAuthNotActivated      = errors.New("auth not activated")
+	ErrAuthFailed            = errors.New("auth failed")
+	ErrMisalignedTime        = errors.New("misaligned time")
+	ErrInvalidActor          = errors.New("invalid actor")
+	ErrInvalidSponsor        = errors.New("invalid sponsor")
+	ErrTooManyActions        = errors.New("too many actions")
+	ErrMissingSponsor        = errors.New("missing sponsor")
+	ErrMissingSponsorAuth    = errors.New("missing sponsor auth")
+	ErrNonceTooLow           = errors.New("nonce too low")
+	ErrNonceTooHigh          = errors.New("nonce too high")
+	ErrTooManyAccessListKeys = errors.New("too many access list keys")
+	ErrSponsorInsolvent      = errors.New("sponsor cannot pay pending fees")
 
 	// Execution Correctness
 	ErrInvalidBalance  = errors.New("invalid balance")
@@ -65,6 +72,18 @@ var (
 	ErrEmptyWarpPayload          = errors.New("empty warp payload")
 	ErrTooManyWarpMessages       = errors.New("too many warp messages")
 	ErrWarpResultMismatch        = errors.New("warp result mismatch")
+	ErrTooManyWarpActions        = errors.New("too many warp actions")
+
+	// Scheduler
+	ErrScheduledTooEarly       = errors.New("scheduled too early")
+	ErrScheduleFull            = errors.New("schedule full")
+	ErrScheduledActionTooLarge = errors.New("scheduled action too large")
+	ErrInvalidScheduledAction  = errors.New("invalid scheduled action")
+
+	// Bundles
+	ErrBundleTooSmall    = errors.New("bundle too small")
+	ErrBundleTooLarge    = errors.New("bundle too large")
+	ErrDuplicateInBundle = errors.New("duplicate transaction in bundle")
 
 	// Misc
 	ErrNotImplemented         = errors.New("not implemented")
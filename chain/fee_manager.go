@@ -209,6 +209,23 @@ func (f *FeeManager) UnitsConsumed() Dimensions {
 	return d
 }
 
+// Fees returns the fees paid for the units consumed in each dimension
+// (excluding any [Base.PriorityFee]).
+func (f *FeeManager) Fees() (Dimensions, error) {
+	f.l.RLock()
+	defer f.l.RUnlock()
+
+	var d Dimensions
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		fee, err := math.Mul64(f.unitPrice(i), f.lastConsumed(i))
+		if err != nil {
+			return Dimensions{}, err
+		}
+		d[i] = fee
+	}
+	return d, nil
+}
+
 func computeNextPriceWindow(
 	previous window.Window,
 	previousConsumed uint64,
@@ -128,10 +128,10 @@ func (mr *MockActionMockRecorder) Size() *gomock.Call {
 }
 
 // StateKeys mocks base method.
-func (m *MockAction) StateKeys(arg0 Auth, arg1 ids.ID) []string {
+func (m *MockAction) StateKeys(arg0 Auth, arg1 ids.ID) state.Keys {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "StateKeys", arg0, arg1)
-	ret0, _ := ret[0].([]string)
+	ret0, _ := ret[0].(state.Keys)
 	return ret0
 }
 
@@ -207,7 +207,7 @@ func (mr *MockAuthMockRecorder) ValidRange(arg0 interface{}) *gomock.Call {
 }
 
 // Verify mocks base method.
-func (m *MockAuth) Verify(arg0 context.Context, arg1 Rules, arg2 state.Immutable, arg3 Action) (uint64, error) {
+func (m *MockAuth) Verify(arg0 context.Context, arg1 Rules, arg2 state.Immutable, arg3 []Action) (uint64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Verify", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(uint64)
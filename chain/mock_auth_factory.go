@@ -53,7 +53,7 @@ func (mr *MockAuthFactoryMockRecorder) MaxUnits() *gomock.Call {
 }
 
 // Sign mocks base method.
-func (m *MockAuthFactory) Sign(arg0 []byte, arg1 Action) (Auth, error) {
+func (m *MockAuthFactory) Sign(arg0 []byte, arg1 []Action) (Auth, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Sign", arg0, arg1)
 	ret0, _ := ret[0].(Auth)
@@ -66,6 +66,34 @@ func (mr *MockRulesMockRecorder) FetchCustom(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCustom", reflect.TypeOf((*MockRules)(nil).FetchCustom), arg0)
 }
 
+// GetActionActivation mocks base method.
+func (m *MockRules) GetActionActivation(arg0 byte) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActionActivation", arg0)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetActionActivation indicates an expected call of GetActionActivation.
+func (mr *MockRulesMockRecorder) GetActionActivation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionActivation", reflect.TypeOf((*MockRules)(nil).GetActionActivation), arg0)
+}
+
+// GetAuthActivation mocks base method.
+func (m *MockRules) GetAuthActivation(arg0 byte) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthActivation", arg0)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetAuthActivation indicates an expected call of GetAuthActivation.
+func (mr *MockRulesMockRecorder) GetAuthActivation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthActivation", reflect.TypeOf((*MockRules)(nil).GetAuthActivation), arg0)
+}
+
 // GetBaseComputeUnits mocks base method.
 func (m *MockRules) GetBaseComputeUnits() uint64 {
 	m.ctrl.T.Helper()
@@ -94,6 +122,20 @@ func (mr *MockRulesMockRecorder) GetBaseWarpComputeUnits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseWarpComputeUnits", reflect.TypeOf((*MockRules)(nil).GetBaseWarpComputeUnits))
 }
 
+// GetMaxActionsPerTx mocks base method.
+func (m *MockRules) GetMaxActionsPerTx() byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxActionsPerTx")
+	ret0, _ := ret[0].(byte)
+	return ret0
+}
+
+// GetMaxActionsPerTx indicates an expected call of GetMaxActionsPerTx.
+func (mr *MockRulesMockRecorder) GetMaxActionsPerTx() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxActionsPerTx", reflect.TypeOf((*MockRules)(nil).GetMaxActionsPerTx))
+}
+
 // GetMaxBlockUnits mocks base method.
 func (m *MockRules) GetMaxBlockUnits() Dimensions {
 	m.ctrl.T.Helper()
@@ -108,6 +150,34 @@ func (mr *MockRulesMockRecorder) GetMaxBlockUnits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBlockUnits", reflect.TypeOf((*MockRules)(nil).GetMaxBlockUnits))
 }
 
+// GetMaxScheduledActions mocks base method.
+func (m *MockRules) GetMaxScheduledActions() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxScheduledActions")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxScheduledActions indicates an expected call of GetMaxScheduledActions.
+func (mr *MockRulesMockRecorder) GetMaxScheduledActions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxScheduledActions", reflect.TypeOf((*MockRules)(nil).GetMaxScheduledActions))
+}
+
+// GetMaxScheduledUnits mocks base method.
+func (m *MockRules) GetMaxScheduledUnits() Dimensions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxScheduledUnits")
+	ret0, _ := ret[0].(Dimensions)
+	return ret0
+}
+
+// GetMaxScheduledUnits indicates an expected call of GetMaxScheduledUnits.
+func (mr *MockRulesMockRecorder) GetMaxScheduledUnits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxScheduledUnits", reflect.TypeOf((*MockRules)(nil).GetMaxScheduledUnits))
+}
+
 // GetMinBlockGap mocks base method.
 func (m *MockRules) GetMinBlockGap() int64 {
 	m.ctrl.T.Helper()
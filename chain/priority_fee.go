@@ -0,0 +1,50 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// payPriorityFees credits the sum of [Base.PriorityFee] paid by [txs] to the
+// recipient configured by [sm]. Like other block-level changes, this is applied
+// to [ts] after all [txs] are executed.
+func payPriorityFees(
+	ctx context.Context,
+	ts *tstate.TState,
+	im state.Immutable,
+	sm StateManager,
+	txs []*Transaction,
+) error {
+	var (
+		total uint64
+		err   error
+	)
+	for _, tx := range txs {
+		total, err = smath.Add64(total, tx.Base.PriorityFee)
+		if err != nil {
+			return err
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	// Fetch keys from [im] (any modifications made by [txs] are
+	// read from [ts])
+	tsv, err := newBlockView(ctx, ts, im, allKeys(sm.PriorityFeeStateKeys()))
+	if err != nil {
+		return err
+	}
+	if err := sm.PayPriorityFees(ctx, tsv, total); err != nil {
+		return err
+	}
+	tsv.Commit()
+	return nil
+}
@@ -31,10 +31,22 @@ func (b *StatelessBlock) Execute(
 	im state.Immutable,
 	feeManager *FeeManager,
 	r Rules,
-) ([]*Result, *tstate.TState, error) {
+) ([]*Result, []*Receipt, *tstate.TState, error) {
 	ctx, span := tracer.Start(ctx, "Processor.Execute")
 	defer span.End()
 
+	return b.execute(ctx, im, feeManager, r, b.vm.GetStoreReceipts())
+}
+
+// execute processes all transactions in [b] on top of [im]. If [storeReceipts] is
+// true, a [Receipt] is generated for each transaction.
+func (b *StatelessBlock) execute(
+	ctx context.Context,
+	im state.Immutable,
+	feeManager *FeeManager,
+	r Rules,
+	storeReceipts bool,
+) ([]*Result, []*Receipt, *tstate.TState, error) {
 	var (
 		sm        = b.vm.StateManager()
 		numTxs    = len(b.Txs)
@@ -42,10 +54,40 @@ func (b *StatelessBlock) Execute(
 		cacheLock sync.RWMutex
 		cache     = make(map[string]*fetchData, numTxs)
 
-		e       = executor.New(numTxs, b.vm.GetTransactionExecutionCores(), b.vm.GetExecutorVerifyRecorder())
 		ts      = tstate.New(numTxs * 2) // TODO: tune this heuristic
 		results = make([]*Result, numTxs)
+
+		receipts []*Receipt
 	)
+	if storeReceipts {
+		receipts = make([]*Receipt, numTxs)
+	}
+
+	// Execute scheduled actions before any transactions
+	forfeited, err := executeScheduled(ctx, b.vm, ts, im, feeManager, r, t)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: unable to execute scheduled actions", err)
+	}
+
+	// If enabled, transactions are executed speculatively (ignoring
+	// [stateKeys]) and re-executed if they observed stale state.
+	var (
+		optimistic = b.vm.GetOptimisticExecution()
+		e          *executor.Executor
+		oe         *executor.Optimistic
+	)
+	if optimistic {
+		oe = executor.NewOptimistic(numTxs, b.vm.GetTransactionExecutionCores(), b.vm.GetExecutorVerifyRecorder())
+	} else {
+		e = executor.New(numTxs, b.vm.GetTransactionExecutionCores(), b.vm.GetExecutorVerifyRecorder())
+	}
+	stop := func() {
+		if optimistic {
+			oe.Stop()
+		} else {
+			e.Stop()
+		}
+	}
 
 	// Fetch required keys and execute transactions
 	for li, ltx := range b.Txs {
@@ -54,10 +96,21 @@ func (b *StatelessBlock) Execute(
 
 		stateKeys, err := tx.StateKeys(sm)
 		if err != nil {
-			e.Stop()
-			return nil, nil, err
+			stop()
+			return nil, nil, nil, err
 		}
-		e.Run(stateKeys, func() error {
+
+		// [execute] may be called more than once if optimistic execution is
+		// enabled, so we only record its output once the transaction is committed.
+		var (
+			warpMsg, hasWarp = b.warpMessages[tx.ID()]
+			warpProcessed    bool
+			warpVerified     bool
+
+			tsv    *tstate.TStateView
+			result *Result
+		)
+		execute := func() error {
 			// Fetch keys from cache
 			var (
 				reads    = make(map[string]uint16, len(stateKeys))
@@ -102,11 +155,29 @@ func (b *StatelessBlock) Execute(
 				}
 			}
 
+			// Update key cache
+			//
+			// Values in [im] are not modified during execution, so we can safely
+			// cache them before the transaction is committed.
+			if len(toCache) > 0 {
+				cacheLock.Lock()
+				for k := range toCache {
+					cache[k] = toCache[k]
+				}
+				cacheLock.Unlock()
+			}
+
 			// Execute transaction
 			//
 			// It is critical we explicitly set the scope before each transaction is
 			// processed
-			tsv := ts.NewView(stateKeys, storage)
+			tsv = ts.NewView(stateKeys, storage)
+			if storeReceipts {
+				tsv.EnableReadTracking()
+			}
+			if optimistic {
+				tsv.EnableValidation()
+			}
 
 			// Ensure we have enough funds to pay fees
 			authCUs, err := tx.PreExecute(ctx, feeManager, sm, r, tsv, t)
@@ -115,19 +186,18 @@ func (b *StatelessBlock) Execute(
 			}
 
 			// Wait to execute transaction until we have the warp result processed.
-			var warpVerified bool
-			warpMsg, ok := b.warpMessages[tx.ID()]
-			if ok {
+			if hasWarp && !warpProcessed {
 				select {
 				case warpVerified = <-warpMsg.verifiedChan:
+					warpProcessed = true
 				case <-ctx.Done():
 					return ctx.Err()
 				}
 			}
-			result, err := tx.Execute(ctx, feeManager, authCUs, reads, sm, r, tsv, t, ok && warpVerified)
-			if err != nil {
-				return err
-			}
+			result, err = tx.Execute(ctx, feeManager, authCUs, reads, sm, r, tsv, t, hasWarp && warpVerified)
+			return err
+		}
+		commit := func() error {
 			results[i] = result
 
 			// Update block metadata with units actually consumed (if more is consumed than block allows, we will non-deterministically
@@ -136,24 +206,40 @@ func (b *StatelessBlock) Execute(
 				return fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
 			}
 
+			// Record changes before they are committed
+			if storeReceipts {
+				receipts[i] = newReceipt(ctx, tx.ID(), tsv)
+			}
+
 			// Commit results to parent [TState]
 			tsv.Commit()
-
-			// Update key cache
-			if len(toCache) > 0 {
-				cacheLock.Lock()
-				for k := range toCache {
-					cache[k] = toCache[k]
-				}
-				cacheLock.Unlock()
-			}
 			return nil
+		}
+		if optimistic {
+			oe.Run(execute, func() bool { return tsv.Validate() }, commit)
+			continue
+		}
+		e.Run(stateKeys, func() error {
+			if err := execute(); err != nil {
+				return err
+			}
+			return commit()
 		})
 	}
-	if err := e.Wait(); err != nil {
-		return nil, nil, err
+	if optimistic {
+		err = oe.Wait()
+	} else {
+		err = e.Wait()
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Distribute fees
+	if err := distributeBlockFees(ctx, b.vm, ts, im, feeManager, b.Txs, forfeited); err != nil {
+		return nil, nil, nil, err
 	}
 
 	// Return tstate that can be used to add block-level keys to state
-	return results, ts, nil
+	return results, receipts, ts, nil
 }
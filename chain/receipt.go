@@ -0,0 +1,149 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// StateDiff is the value of a key before and after a [Transaction] was executed.
+// A nil value means the key did not exist.
+type StateDiff struct {
+	Key    []byte `json:"key"`
+	Before []byte `json:"before"`
+	After  []byte `json:"after"`
+}
+
+// Receipt records the keys read and modified by a [Transaction] (and how they
+// were modified). All keys are sorted.
+//
+// Receipts are only generated if [VM.GetStoreReceipts] is true.
+type Receipt struct {
+	TxID ids.ID `json:"txId"`
+
+	Reads     [][]byte `json:"reads"`
+	Allocates [][]byte `json:"allocates"`
+	Modifies  [][]byte `json:"modifies"`
+	Removes   [][]byte `json:"removes"`
+
+	Diffs []*StateDiff `json:"diffs"`
+}
+
+// newReceipt creates a [Receipt] for [txID] from the operations performed on [tsv].
+// Read tracking must be enabled on [tsv] before the [Transaction] is executed for
+// [Receipt.Reads] to be populated.
+func newReceipt(ctx context.Context, txID ids.ID, tsv *tstate.TStateView) *Receipt {
+	var (
+		allocates, writes = tsv.KeyOperations()
+		changes           = tsv.Changes(ctx)
+		receipt           = &Receipt{
+			TxID:      txID,
+			Reads:     sortedKeys(maps.Keys(tsv.Reads())),
+			Allocates: sortedKeys(maps.Keys(allocates)),
+			Diffs:     make([]*StateDiff, 0, len(changes)),
+		}
+	)
+	for _, k := range sortedKeys(maps.Keys(writes)) {
+		if _, ok := allocates[string(k)]; ok {
+			continue
+		}
+		if change, ok := changes[string(k)]; ok && change.After == nil {
+			receipt.Removes = append(receipt.Removes, k)
+			continue
+		}
+		receipt.Modifies = append(receipt.Modifies, k)
+	}
+	for _, k := range sortedKeys(maps.Keys(changes)) {
+		change := changes[string(k)]
+		receipt.Diffs = append(receipt.Diffs, &StateDiff{
+			Key:    k,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return receipt
+}
+
+func sortedKeys(keys []string) [][]byte {
+	slices.Sort(keys)
+	sorted := make([][]byte, len(keys))
+	for i, k := range keys {
+		sorted[i] = []byte(k)
+	}
+	return sorted
+}
+
+func (r *Receipt) Size() int {
+	size := consts.IDLen
+	for _, keys := range [][][]byte{r.Reads, r.Allocates, r.Modifies, r.Removes} {
+		size += consts.IntLen
+		for _, k := range keys {
+			size += codec.BytesLen(k)
+		}
+	}
+	size += consts.IntLen
+	for _, diff := range r.Diffs {
+		size += codec.BytesLen(diff.Key) + codec.BytesLen(diff.Before) + codec.BytesLen(diff.After)
+	}
+	return size
+}
+
+func (r *Receipt) Marshal(p *codec.Packer) {
+	p.PackID(r.TxID)
+	for _, keys := range [][][]byte{r.Reads, r.Allocates, r.Modifies, r.Removes} {
+		p.PackInt(len(keys))
+		for _, k := range keys {
+			p.PackBytes(k)
+		}
+	}
+	p.PackInt(len(r.Diffs))
+	for _, diff := range r.Diffs {
+		p.PackBytes(diff.Key)
+		p.PackBytes(diff.Before)
+		p.PackBytes(diff.After)
+	}
+}
+
+func UnmarshalReceipt(raw []byte) (*Receipt, error) {
+	var (
+		p       = codec.NewReader(raw, consts.MaxInt) // could be much larger than [NetworkSizeLimit]
+		receipt Receipt
+	)
+	p.UnpackID(true, &receipt.TxID)
+	for _, keys := range []*[][]byte{&receipt.Reads, &receipt.Allocates, &receipt.Modifies, &receipt.Removes} {
+		count := p.UnpackInt(false)
+		for i := 0; i < count; i++ {
+			var k []byte
+			p.UnpackBytes(consts.MaxInt, true, &k)
+			*keys = append(*keys, k)
+		}
+	}
+	count := p.UnpackInt(false)
+	receipt.Diffs = make([]*StateDiff, 0, count)
+	for i := 0; i < count; i++ {
+		diff := &StateDiff{}
+		p.UnpackBytes(consts.MaxInt, true, &diff.Key)
+		p.UnpackBytes(consts.MaxInt, false, &diff.Before)
+		p.UnpackBytes(consts.MaxInt, false, &diff.After)
+		if len(diff.Before) == 0 {
+			diff.Before = nil
+		}
+		if len(diff.After) == 0 {
+			diff.After = nil
+		}
+		receipt.Diffs = append(receipt.Diffs, diff)
+	}
+	if !p.Empty() {
+		return nil, ErrInvalidObject
+	}
+	return &receipt, p.Err()
+}
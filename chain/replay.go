@@ -0,0 +1,220 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// ReplayedTransaction is the outcome of re-executing a [Transaction].
+type ReplayedTransaction struct {
+	TxID ids.ID `json:"txId"`
+
+	Result  *Result  `json:"result"`
+	Receipt *Receipt `json:"receipt"`
+
+	// Recorded is the [Receipt] stored when the [Transaction] was accepted (nil
+	// if it is no longer stored or receipts were not enabled).
+	Recorded *Receipt `json:"recorded"`
+	// Mismatch is true if [Recorded] is populated and does not equal [Receipt].
+	Mismatch bool `json:"mismatch"`
+}
+
+// ReplayMismatch is a key whose value after replay differs from the value
+// recorded when the block was accepted. A nil value means the key did not exist.
+type ReplayMismatch struct {
+	Key      []byte `json:"key"`
+	Recorded []byte `json:"recorded"`
+	Replayed []byte `json:"replayed"`
+
+	// TxIDs are the transactions that modified [Key] during replay.
+	TxIDs []ids.ID `json:"txIds"`
+}
+
+// ReplayResult is the outcome of re-executing a [StatelessBlock].
+type ReplayResult struct {
+	Height  uint64 `json:"height"`
+	BlockID ids.ID `json:"blockId"`
+
+	Txs []*ReplayedTransaction `json:"txs"`
+
+	// Mismatches are sorted by key.
+	Mismatches []*ReplayMismatch `json:"mismatches"`
+}
+
+// Diverged returns true if re-executing the block produced a different outcome
+// than was recorded.
+func (r *ReplayResult) Diverged() bool {
+	if len(r.Mismatches) > 0 {
+		return true
+	}
+	for _, tx := range r.Txs {
+		if tx.Mismatch {
+			return true
+		}
+	}
+	return false
+}
+
+// Replay re-executes [b] on top of [parent] (the state [b] was originally
+// executed on) and compares the resulting changes with [recorded] (the changes
+// persisted when [b] was accepted). A key that was removed should be mapped
+// to [maybe.Nothing]. If provided, [recordedReceipts] are compared with the
+// receipts generated during replay.
+//
+// Warp messages are not re-verified (the results recorded in [b] are used
+// instead), so [Replay] can be used on blocks of any age.
+func (b *StatelessBlock) Replay(
+	ctx context.Context,
+	parent state.Immutable,
+	recorded map[string]maybe.Maybe[[]byte],
+	recordedReceipts map[ids.ID]*Receipt,
+) (*ReplayResult, error) {
+	ctx, span := b.vm.Tracer().Start(ctx, "StatelessBlock.Replay")
+	defer span.End()
+
+	var (
+		sm = b.vm.StateManager()
+		r  = b.vm.Rules(b.Tmstmp)
+	)
+
+	// Load rules that depend on parent state
+	r, err := LoadRules(ctx, r, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch parent metadata
+	parentHeightRaw, err := parent.GetValue(ctx, HeightKey(sm.HeightKey()))
+	if err != nil {
+		return nil, err
+	}
+	if b.Hght != binary.BigEndian.Uint64(parentHeightRaw)+1 {
+		return nil, ErrInvalidBlockHeight
+	}
+	parentTimestampRaw, err := parent.GetValue(ctx, TimestampKey(sm.TimestampKey()))
+	if err != nil {
+		return nil, err
+	}
+	parentTimestamp := int64(binary.BigEndian.Uint64(parentTimestampRaw))
+	feeRaw, err := parent.GetValue(ctx, FeeKey(sm.FeeKey()))
+	if err != nil {
+		return nil, err
+	}
+	parentFeeManager := NewFeeManager(feeRaw)
+	feeManager, err := parentFeeManager.ComputeNext(parentTimestamp, b.Tmstmp, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the warp results recorded in the block
+	for _, msg := range b.warpMessages {
+		verified := b.WarpResults.Contains(uint(msg.warpNum))
+		msg.verifiedChan <- verified
+		msg.verified = verified
+	}
+
+	// Re-execute block (always generating receipts, so we can attribute
+	// changes to transactions)
+	results, receipts, ts, err := b.execute(ctx, parent, feeManager, r, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.commitMetadata(ctx, ts, parentHeightRaw, parentTimestampRaw, parentFeeManager, feeManager); err != nil {
+		return nil, err
+	}
+
+	// Compare transaction outcomes
+	result := &ReplayResult{
+		Height:  b.Hght,
+		BlockID: b.ID(),
+		Txs:     make([]*ReplayedTransaction, len(b.Txs)),
+	}
+	modifiedBy := make(map[string][]ids.ID)
+	for i, tx := range b.Txs {
+		rtx := &ReplayedTransaction{
+			TxID:     tx.ID(),
+			Result:   results[i],
+			Receipt:  receipts[i],
+			Recorded: recordedReceipts[tx.ID()],
+		}
+		if rtx.Recorded != nil {
+			rtx.Mismatch = !equalReceipts(rtx.Receipt, rtx.Recorded)
+		}
+		result.Txs[i] = rtx
+		for _, diff := range receipts[i].Diffs {
+			modifiedBy[string(diff.Key)] = append(modifiedBy[string(diff.Key)], tx.ID())
+		}
+	}
+
+	// Compare state changes
+	var (
+		replayed = ts.ChangedKeys()
+		changed  = maps.Keys(replayed)
+	)
+	for k := range recorded {
+		if _, ok := replayed[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	for _, k := range sortedKeys(changed) {
+		replayedValue, err := changedValue(ctx, parent, replayed, k)
+		if err != nil {
+			return nil, err
+		}
+		recordedValue, err := changedValue(ctx, parent, recorded, k)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(replayedValue, recordedValue) {
+			continue
+		}
+		result.Mismatches = append(result.Mismatches, &ReplayMismatch{
+			Key:      k,
+			Recorded: recordedValue,
+			Replayed: replayedValue,
+			TxIDs:    modifiedBy[string(k)],
+		})
+	}
+	return result, nil
+}
+
+// changedValue returns the value of [key] in [changes] or, if it was not
+// changed, in [parent] (nil if it does not exist).
+func changedValue(
+	ctx context.Context,
+	parent state.Immutable,
+	changes map[string]maybe.Maybe[[]byte],
+	key []byte,
+) ([]byte, error) {
+	if v, ok := changes[string(key)]; ok {
+		return v.Value(), nil
+	}
+	v, err := parent.GetValue(ctx, key)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	return v, err
+}
+
+// equalReceipts returns true if [a] and [b] have the same encoding.
+func equalReceipts(a *Receipt, b *Receipt) bool {
+	pa := codec.NewWriter(a.Size(), consts.MaxInt)
+	a.Marshal(pa)
+	pb := codec.NewWriter(b.Size(), consts.MaxInt)
+	b.Marshal(pb)
+	return bytes.Equal(pa.Bytes(), pb.Bytes())
+}
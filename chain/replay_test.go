@@ -0,0 +1,320 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/executor"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	htrace "github.com/ava-labs/hypersdk/trace"
+)
+
+var (
+	_ VM             = (*replayVM)(nil)
+	_ StateManager   = (*replayStateManager)(nil)
+	_ FeeDistributor = (*replayFeeDistributor)(nil)
+)
+
+// replayVM implements the parts of [VM] used by [StatelessBlock.Replay].
+type replayVM struct {
+	VM
+
+	tracer trace.Tracer
+	rules  Rules
+}
+
+func (vm *replayVM) Tracer() trace.Tracer                     { return vm.tracer }
+func (vm *replayVM) Rules(int64) Rules                        { return vm.rules }
+func (*replayVM) Registry() (ActionRegistry, AuthRegistry)    { return nil, nil }
+func (*replayVM) StateManager() StateManager                  { return &replayStateManager{} }
+func (*replayVM) FeeDistributor() FeeDistributor              { return &replayFeeDistributor{} }
+func (*replayVM) GetOptimisticExecution() bool                { return false }
+func (*replayVM) GetTransactionExecutionCores() int           { return 1 }
+func (*replayVM) GetExecutorVerifyRecorder() executor.Metrics { return nil }
+func (*replayVM) GetStoreReceipts() bool                      { return false }
+
+type replayStateManager struct{}
+
+func (*replayStateManager) HeightKey() []byte                           { return []byte{0} }
+func (*replayStateManager) TimestampKey() []byte                        { return []byte{1} }
+func (*replayStateManager) FeeKey() []byte                              { return []byte{2} }
+func (*replayStateManager) NonceKey(addr codec.Address) []byte          { return append([]byte{3}, addr[:]...) }
+func (*replayStateManager) SchedulerPrefix() []byte                     { return []byte{4} }
+func (*replayStateManager) PriorityFeeStateKeys() []string              { return nil }
+func (*replayStateManager) IncomingWarpKeyPrefix(ids.ID, ids.ID) []byte { return []byte{5} }
+func (*replayStateManager) OutgoingWarpKeyPrefix(ids.ID) []byte         { return []byte{6} }
+
+func (*replayStateManager) PayPriorityFees(context.Context, state.Mutable, uint64) error {
+	return nil
+}
+
+type replayFeeDistributor struct{}
+
+func (*replayFeeDistributor) StateKeys() []string { return nil }
+
+func (*replayFeeDistributor) Distribute(context.Context, Dimensions, uint64, state.Mutable) error {
+	return nil
+}
+
+// replayState is a [state.Immutable] backed by a map.
+type replayState map[string][]byte
+
+func (s replayState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func newReplayRules(ctrl *gomock.Controller) Rules {
+	var (
+		r          = NewMockRules(ctrl)
+		ones       = Dimensions{1, 1, 1, 1, 1}
+		maxUnits   = Dimensions{1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000}
+		changeDeno = Dimensions{48, 48, 48, 48, 48}
+	)
+	r.EXPECT().ChainID().Return(ids.Empty).AnyTimes()
+	r.EXPECT().GetValidityWindow().Return(int64(60_000)).AnyTimes()
+	r.EXPECT().GetMaxActionsPerTx().Return(uint8(1)).AnyTimes()
+	r.EXPECT().GetMinUnitPrice().Return(ones).AnyTimes()
+	r.EXPECT().GetUnitPriceChangeDenominator().Return(changeDeno).AnyTimes()
+	r.EXPECT().GetWindowTargetUnits().Return(maxUnits).AnyTimes()
+	r.EXPECT().GetMaxBlockUnits().Return(maxUnits).AnyTimes()
+	r.EXPECT().GetBaseComputeUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageKeyReadUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageValueReadUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageKeyAllocateUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageValueAllocateUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageKeyWriteUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetStorageValueWriteUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetMaxScheduledActions().Return(0).AnyTimes()
+	r.EXPECT().GetMaxScheduledUnits().Return(Dimensions{}).AnyTimes()
+	r.EXPECT().GetActionActivation(gomock.Any()).Return(int64(-1)).AnyTimes()
+	r.EXPECT().GetAuthActivation(gomock.Any()).Return(int64(-1)).AnyTimes()
+	return r
+}
+
+// newReplayAuth returns an [Auth] that pays fees from the balance stored at [balanceKey].
+func newReplayAuth(ctrl *gomock.Controller, balanceKey []byte) Auth {
+	update := func(ctx context.Context, mu state.Mutable, f func(uint64) uint64) error {
+		v, err := mu.GetValue(ctx, balanceKey)
+		if err != nil {
+			return err
+		}
+		return mu.Insert(ctx, balanceKey, binary.BigEndian.AppendUint64(nil, f(binary.BigEndian.Uint64(v))))
+	}
+	auth := NewMockAuth(ctrl)
+	auth.EXPECT().GetTypeID().Return(uint8(0)).AnyTimes()
+	auth.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+	auth.EXPECT().MaxComputeUnits(gomock.Any()).Return(uint64(1)).AnyTimes()
+	auth.EXPECT().StateKeys().Return([]string{string(balanceKey)}).AnyTimes()
+	auth.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(1), nil).AnyTimes()
+	auth.EXPECT().Sponsor().Return(codec.EmptyAddress).AnyTimes()
+	auth.EXPECT().CanDeduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	auth.EXPECT().Deduct(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, mu state.Mutable, amount uint64) error {
+			return update(ctx, mu, func(balance uint64) uint64 { return balance - amount })
+		},
+	).AnyTimes()
+	auth.EXPECT().Refund(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, mu state.Mutable, amount uint64) error {
+			return update(ctx, mu, func(balance uint64) uint64 { return balance + amount })
+		},
+	).AnyTimes()
+	return auth
+}
+
+// newReplayAction returns an [Action] that stores [value] at [key].
+func newReplayAction(ctrl *gomock.Controller, key []byte, value []byte) Action {
+	action := NewMockAction(ctrl)
+	action.EXPECT().GetTypeID().Return(uint8(0)).AnyTimes()
+	action.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+	action.EXPECT().MaxComputeUnits(gomock.Any()).Return(uint64(1)).AnyTimes()
+	action.EXPECT().OutputsWarpMessage().Return(false).AnyTimes()
+	action.EXPECT().StateKeys(gomock.Any(), gomock.Any()).Return(state.Keys{string(key): state.All}).AnyTimes()
+	action.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ Rules, mu state.Mutable, _ int64, _ Auth, _ ids.ID, _ bool) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+			return true, 1, nil, nil, mu.Insert(ctx, key, value)
+		},
+	).AnyTimes()
+	return action
+}
+
+func TestReplayReportsCorruptedRecord(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	var (
+		vm = &replayVM{tracer: tracer, rules: newReplayRules(ctrl)}
+		sm = vm.StateManager()
+
+		balanceKey = keys.EncodeChunks([]byte("balance"), 1)
+		valueKeys  = [][]byte{
+			keys.EncodeChunks([]byte("value0"), 1),
+			keys.EncodeChunks([]byte("value1"), 1),
+		}
+		parent = replayState{
+			string(HeightKey(sm.HeightKey())):       binary.BigEndian.AppendUint64(nil, 9),
+			string(TimestampKey(sm.TimestampKey())): binary.BigEndian.AppendUint64(nil, 9_000),
+			string(FeeKey(sm.FeeKey())):             NewFeeManager(nil).Bytes(),
+			string(balanceKey):                      binary.BigEndian.AppendUint64(nil, 1_000_000),
+		}
+		auth = newReplayAuth(ctrl, balanceKey)
+		txs  = make([]*Transaction, len(valueKeys))
+	)
+	for i, k := range valueKeys {
+		txs[i] = &Transaction{
+			Base: &Base{
+				Timestamp: 20_000,
+				ChainID:   ids.Empty,
+				MaxFee:    10_000,
+			},
+			Actions: []Action{newReplayAction(ctrl, k, []byte{byte(i + 1)})},
+			Auth:    auth,
+			size:    100,
+			id:      ids.GenerateTestID(),
+		}
+	}
+	blk := &StatelessBlock{
+		StatefulBlock: &StatefulBlock{
+			Prnt:   ids.GenerateTestID(),
+			Tmstmp: 10_000,
+			Hght:   10,
+			Txs:    txs,
+		},
+		id: ids.GenerateTestID(),
+		vm: vm,
+	}
+
+	// Replaying against nothing recorded reports every change
+	honest, err := blk.Replay(ctx, parent, nil, nil)
+	require.NoError(err)
+	require.Len(honest.Txs, len(txs))
+	recorded := make(map[string]maybe.Maybe[[]byte], len(honest.Mismatches))
+	for _, m := range honest.Mismatches {
+		require.NotNil(m.Replayed)
+		recorded[string(m.Key)] = maybe.Some(m.Replayed)
+	}
+	require.Contains(recorded, string(balanceKey))
+	receipts := make(map[ids.ID]*Receipt, len(txs))
+	for _, rtx := range honest.Txs {
+		require.True(rtx.Result.Success)
+		receipts[rtx.TxID] = rtx.Receipt
+	}
+
+	// Replaying against the honest record does not diverge
+	result, err := blk.Replay(ctx, parent, recorded, receipts)
+	require.NoError(err)
+	require.False(result.Diverged())
+	require.Empty(result.Mismatches)
+	for _, rtx := range result.Txs {
+		require.NotNil(rtx.Recorded)
+		require.False(rtx.Mismatch)
+	}
+
+	// Corrupt the value written by the second transaction (and its receipt)
+	const bad = 0xff
+	recorded[string(valueKeys[1])] = maybe.Some([]byte{bad})
+	corrupted := *receipts[txs[1].ID()]
+	corrupted.Diffs = make([]*StateDiff, len(receipts[txs[1].ID()].Diffs))
+	for i, diff := range receipts[txs[1].ID()].Diffs {
+		d := *diff
+		if string(d.Key) == string(valueKeys[1]) {
+			d.After = []byte{bad}
+		}
+		corrupted.Diffs[i] = &d
+	}
+	receipts[txs[1].ID()] = &corrupted
+
+	result, err = blk.Replay(ctx, parent, recorded, receipts)
+	require.NoError(err)
+	require.True(result.Diverged())
+	require.False(result.Txs[0].Mismatch)
+	require.True(result.Txs[1].Mismatch)
+	require.Equal(txs[1].ID(), result.Txs[1].TxID)
+	require.Len(result.Mismatches, 1)
+	mismatch := result.Mismatches[0]
+	require.Equal(valueKeys[1], mismatch.Key)
+	require.Equal([]byte{bad}, mismatch.Recorded)
+	require.Equal([]byte{2}, mismatch.Replayed)
+	require.Equal([]ids.ID{txs[1].ID()}, mismatch.TxIDs)
+}
+
+func TestReplayReportsMissingRemoval(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	var (
+		vm = &replayVM{tracer: tracer, rules: newReplayRules(ctrl)}
+		sm = vm.StateManager()
+
+		balanceKey = keys.EncodeChunks([]byte("balance"), 1)
+		valueKey   = keys.EncodeChunks([]byte("value"), 1)
+		parent     = replayState{
+			string(HeightKey(sm.HeightKey())):       binary.BigEndian.AppendUint64(nil, 9),
+			string(TimestampKey(sm.TimestampKey())): binary.BigEndian.AppendUint64(nil, 9_000),
+			string(FeeKey(sm.FeeKey())):             NewFeeManager(nil).Bytes(),
+			string(balanceKey):                      binary.BigEndian.AppendUint64(nil, 1_000_000),
+		}
+		tx = &Transaction{
+			Base: &Base{
+				Timestamp: 20_000,
+				ChainID:   ids.Empty,
+				MaxFee:    10_000,
+			},
+			Actions: []Action{newReplayAction(ctrl, valueKey, []byte{1})},
+			Auth:    newReplayAuth(ctrl, balanceKey),
+			size:    100,
+			id:      ids.GenerateTestID(),
+		}
+		blk = &StatelessBlock{
+			StatefulBlock: &StatefulBlock{
+				Prnt:   ids.GenerateTestID(),
+				Tmstmp: 10_000,
+				Hght:   10,
+				Txs:    []*Transaction{tx},
+			},
+			id: ids.GenerateTestID(),
+			vm: vm,
+		}
+	)
+
+	// The record claims [valueKey] was never written
+	result, err := blk.Replay(ctx, parent, map[string]maybe.Maybe[[]byte]{
+		string(valueKey): maybe.Nothing[[]byte](),
+	}, nil)
+	require.NoError(err)
+	require.True(result.Diverged())
+	var found bool
+	for _, m := range result.Mismatches {
+		if string(m.Key) != string(valueKey) {
+			continue
+		}
+		found = true
+		require.Nil(m.Recorded)
+		require.Equal([]byte{1}, m.Replayed)
+		require.Equal([]ids.ID{tx.ID()}, m.TxIDs)
+	}
+	require.True(found)
+}
@@ -11,7 +11,9 @@ import (
 
 type Result struct {
 	Success bool
-	Output  []byte
+	// Outputs contains the output of each [Action] that was executed (in order). If
+	// the [Transaction] failed, the last item is the output of the [Action] that failed.
+	Outputs [][]byte
 
 	Consumed Dimensions
 	Fee      uint64
@@ -20,7 +22,10 @@ type Result struct {
 }
 
 func (r *Result) Size() int {
-	size := consts.BoolLen + codec.BytesLen(r.Output) + DimensionsLen + consts.Uint64Len
+	size := consts.BoolLen + consts.ByteLen + DimensionsLen + consts.Uint64Len
+	for _, output := range r.Outputs {
+		size += codec.BytesLen(output)
+	}
 	if r.WarpMessage != nil {
 		size += codec.BytesLen(r.WarpMessage.Bytes())
 	} else {
@@ -31,7 +36,10 @@ func (r *Result) Size() int {
 
 func (r *Result) Marshal(p *codec.Packer) error {
 	p.PackBool(r.Success)
-	p.PackBytes(r.Output)
+	p.PackByte(uint8(len(r.Outputs)))
+	for _, output := range r.Outputs {
+		p.PackBytes(output)
+	}
 	p.PackFixedBytes(r.Consumed.Bytes())
 	p.PackUint64(r.Fee)
 	var warpBytes []byte
@@ -58,10 +66,16 @@ func UnmarshalResult(p *codec.Packer) (*Result, error) {
 	result := &Result{
 		Success: p.UnpackBool(),
 	}
-	p.UnpackBytes(consts.MaxInt, false, &result.Output)
-	if len(result.Output) == 0 {
-		// Enforce object standardization
-		result.Output = nil
+	numOutputs := p.UnpackByte()
+	result.Outputs = make([][]byte, 0, numOutputs)
+	for i := uint8(0); i < numOutputs; i++ {
+		var output []byte
+		p.UnpackBytes(consts.MaxInt, false, &output)
+		if len(output) == 0 {
+			// Enforce object standardization
+			output = nil
+		}
+		result.Outputs = append(result.Outputs, output)
 	}
 	consumedRaw := make([]byte, DimensionsLen)
 	p.UnpackFixedBytes(DimensionsLen, &consumedRaw)
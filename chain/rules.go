@@ -0,0 +1,20 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// LoadRules returns the [Rules] to use on top of [im]. If [r] is [StateRules],
+// this is the result of [StateRules.Load]. Otherwise, it is [r].
+func LoadRules(ctx context.Context, r Rules, im state.Immutable) (Rules, error) {
+	sr, ok := r.(StateRules)
+	if !ok {
+		return r, nil
+	}
+	return sr.Load(ctx, im)
+}
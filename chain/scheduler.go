@@ -0,0 +1,594 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/math"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+const (
+	schedulerKeyType       = 0x0
+	scheduleKeyType        = 0x1
+	scheduledActionKeyType = 0x2
+)
+
+// SchedulerKey stores the next second (in ms) that has scheduled actions that
+// have not been executed.
+func SchedulerKey(prefix []byte) []byte {
+	k := make([]byte, 0, len(prefix)+consts.ByteLen+consts.Uint16Len)
+	k = append(k, prefix...)
+	k = append(k, schedulerKeyType)
+	return keys.EncodeChunks(k, SchedulerKeyChunks)
+}
+
+// ScheduleKey stores the IDs of all actions scheduled to execute at [timestamp]
+// (in the order they were scheduled).
+func ScheduleKey(prefix []byte, timestamp int64) []byte {
+	k := make([]byte, 0, len(prefix)+consts.ByteLen+consts.Uint64Len+consts.Uint16Len)
+	k = append(k, prefix...)
+	k = append(k, scheduleKeyType)
+	k = binary.BigEndian.AppendUint64(k, uint64(timestamp))
+	return keys.EncodeChunks(k, ScheduleKeyChunks)
+}
+
+// ScheduledActionKey stores the [ScheduledAction] with [actionID].
+func ScheduledActionKey(prefix []byte, actionID ids.ID) []byte {
+	k := make([]byte, 0, len(prefix)+consts.ByteLen+consts.IDLen+consts.Uint16Len)
+	k = append(k, prefix...)
+	k = append(k, scheduledActionKeyType)
+	k = append(k, actionID[:]...)
+	return keys.EncodeChunks(k, ScheduledActionChunks)
+}
+
+// ScheduleStateKeys are the keys modified by [Schedule]. These must be included in
+// the [Action.StateKeys] of any [Action] that calls [Schedule] (in addition to the
+// keys modified by [Auth.Deduct]).
+func ScheduleStateKeys(prefix []byte, timestamp int64, actionID ids.ID) state.Keys {
+	return state.Keys{
+		string(ScheduleKey(prefix, timestamp)):       state.All,
+		string(ScheduledActionKey(prefix, actionID)): state.All,
+	}
+}
+
+// ScheduleStateKeysMaxChunks are the max chunks of each key returned by
+// [ScheduleStateKeys].
+func ScheduleStateKeysMaxChunks() []uint16 {
+	return []uint16{ScheduleKeyChunks, ScheduledActionChunks}
+}
+
+// ScheduledAction is an [Action] that is executed at the start of the first block
+// with a timestamp greater than or equal to [Timestamp].
+//
+// Scheduled actions are executed with [Auth] (which is not verified again) and must
+// prepay [Fee] when they are scheduled. If [Fee] is less than the max fee of the
+// [ScheduledAction] when it is executed (or the [Action] is no longer valid), the
+// [ScheduledAction] is dropped and [Fee] is refunded. Otherwise, any unused
+// portion of [Fee] is refunded after execution.
+type ScheduledAction struct {
+	// Timestamp must be aligned to the second.
+	Timestamp int64  `json:"timestamp"`
+	Fee       uint64 `json:"fee"`
+
+	Auth   Auth   `json:"auth"`
+	Action Action `json:"action"`
+}
+
+func (s *ScheduledAction) StateKeys(prefix []byte, actionID ids.ID) (state.Keys, error) {
+	authKeys := s.Auth.StateKeys()
+	actionKeys := s.Action.StateKeys(s.Auth, actionID)
+	stateKeys := make(state.Keys, len(authKeys)+len(actionKeys)+1)
+	for _, k := range authKeys {
+		if !keys.Valid(k) {
+			return nil, ErrInvalidKeyValue
+		}
+		stateKeys.Add(k, state.All)
+	}
+	for k, permissions := range actionKeys {
+		if !keys.Valid(k) {
+			return nil, ErrInvalidKeyValue
+		}
+		stateKeys.Add(k, permissions)
+	}
+	stateKeys.Add(string(ScheduledActionKey(prefix, actionID)), state.All)
+	return stateKeys, nil
+}
+
+// MaxUnits is the max amount of units that could be consumed by executing [s]. Because
+// [s] is already included on-chain, no bandwidth is consumed during execution.
+func (s *ScheduledAction) MaxUnits(r Rules, prefix []byte, actionID ids.ID) (Dimensions, error) {
+	maxComputeUnitsOp := math.NewUint64Operator(r.GetBaseComputeUnits())
+	maxComputeUnitsOp.Add(s.Action.MaxComputeUnits(r))
+	maxComputeUnitsOp.Add(s.Auth.MaxComputeUnits(r))
+	maxComputeUnits, err := maxComputeUnitsOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	stateKeys, err := s.StateKeys(prefix, actionID)
+	if err != nil {
+		return Dimensions{}, err
+	}
+	reads, allocates, writes, err := maxStorageUnits(r, stateKeys)
+	if err != nil {
+		return Dimensions{}, err
+	}
+	return Dimensions{0, maxComputeUnits, reads, allocates, writes}, nil
+}
+
+func (s *ScheduledAction) Size() int {
+	return consts.Int64Len + consts.Uint64Len + consts.ByteLen + s.Auth.Size() + consts.ByteLen + s.Action.Size()
+}
+
+func (s *ScheduledAction) Marshal(p *codec.Packer) {
+	p.PackInt64(s.Timestamp)
+	p.PackUint64(s.Fee)
+	p.PackByte(s.Auth.GetTypeID())
+	s.Auth.Marshal(p)
+	p.PackByte(s.Action.GetTypeID())
+	s.Action.Marshal(p)
+}
+
+func UnmarshalScheduledAction(
+	raw []byte,
+	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
+	authRegistry *codec.TypeParser[Auth, *warp.Message, bool],
+) (*ScheduledAction, error) {
+	p := codec.NewReader(raw, len(raw))
+	timestamp := p.UnpackInt64(true)
+	fee := p.UnpackUint64(false)
+	auth, _, err := unmarshalAuth(p, authRegistry, nil)
+	if err != nil {
+		return nil, err
+	}
+	actionType := p.UnpackByte()
+	unmarshalAction, requiresWarp, ok := actionRegistry.LookupIndex(actionType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
+	}
+	if requiresWarp {
+		return nil, fmt.Errorf("%w: action %d requires warp", ErrInvalidScheduledAction, actionType)
+	}
+	action, err := unmarshalAction(p, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not unmarshal action", err)
+	}
+	if !p.Empty() {
+		return nil, fmt.Errorf("%w: remaining=%d", ErrInvalidObject, len(raw)-p.Offset())
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return &ScheduledAction{
+		Timestamp: timestamp,
+		Fee:       fee,
+		Auth:      auth,
+		Action:    action,
+	}, nil
+}
+
+// Schedule enqueues [sa] to be executed at [ScheduledAction.Timestamp] and deducts
+// [ScheduledAction.Fee] from [ScheduledAction.Auth]. It is intended to be called
+// from [Action.Execute] (with [now] set to the timestamp of the block).
+//
+// [actionID] must be unique (it is typically the [actionID] of the calling [Action]).
+func Schedule(
+	ctx context.Context,
+	r Rules,
+	mu state.Mutable,
+	prefix []byte,
+	now int64,
+	actionID ids.ID,
+	sa *ScheduledAction,
+) error {
+	if sa.Timestamp%consts.MillisecondsPerSecond != 0 {
+		return fmt.Errorf("%w: timestamp=%d", ErrMisalignedTime, sa.Timestamp)
+	}
+	if sa.Timestamp <= now {
+		return fmt.Errorf("%w: timestamp=%d now=%d", ErrScheduledTooEarly, sa.Timestamp, now)
+	}
+	if sa.Action.OutputsWarpMessage() {
+		return fmt.Errorf("%w: action %d outputs warp message", ErrInvalidScheduledAction, sa.Action.GetTypeID())
+	}
+
+	// Ensure [sa] could ever be executed
+	maxUnits, err := sa.MaxUnits(r, prefix, actionID)
+	if err != nil {
+		return err
+	}
+	if !(Dimensions{}).CanAdd(maxUnits, r.GetMaxScheduledUnits()) {
+		return fmt.Errorf("%w: max units exceeds limit", ErrInvalidScheduledAction)
+	}
+	p := codec.NewWriter(sa.Size(), consts.NetworkSizeLimit)
+	sa.Marshal(p)
+	if err := p.Err(); err != nil {
+		return err
+	}
+	v := p.Bytes()
+	if numChunks, ok := keys.NumChunks(v); !ok || numChunks > ScheduledActionChunks {
+		return fmt.Errorf("%w: size=%d", ErrScheduledActionTooLarge, len(v))
+	}
+
+	// Prepay fee
+	if sa.Fee > 0 {
+		if err := sa.Auth.Deduct(ctx, mu, sa.Fee); err != nil {
+			return err
+		}
+	}
+
+	// Append to schedule
+	sk := ScheduleKey(prefix, sa.Timestamp)
+	schedule, err := mu.GetValue(ctx, sk)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	if len(schedule)/consts.IDLen >= MaxScheduledActionsPerSecond {
+		return fmt.Errorf("%w: timestamp=%d", ErrScheduleFull, sa.Timestamp)
+	}
+	schedule = append(schedule[:len(schedule):len(schedule)], actionID[:]...)
+	if err := mu.Insert(ctx, sk, schedule); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ScheduledActionKey(prefix, actionID), v)
+}
+
+// scheduler executes scheduled actions at the start of a block.
+type scheduler struct {
+	ts         *tstate.TState
+	im         state.Immutable
+	feeManager *FeeManager
+	r          Rules
+	timestamp  int64
+
+	prefix         []byte
+	actionRegistry ActionRegistry
+	authRegistry   AuthRegistry
+
+	executed  int
+	consumed  Dimensions
+	forfeited uint64
+}
+
+// executeScheduled executes all actions scheduled at or before [timestamp] (in the
+// order they were scheduled) until [Rules.GetMaxScheduledActions] or
+// [Rules.GetMaxScheduledUnits] is reached. Any remaining actions are executed in
+// subsequent blocks (before any actions scheduled after them).
+//
+// Units consumed by scheduled actions count towards the units consumed by the block.
+// It returns the prepaid fees of scheduled actions that could not be refunded (which
+// must be provided to the [FeeDistributor]).
+func executeScheduled(
+	ctx context.Context,
+	vm VM,
+	ts *tstate.TState,
+	im state.Immutable,
+	feeManager *FeeManager,
+	r Rules,
+	timestamp int64,
+) (uint64, error) {
+	actionRegistry, authRegistry := vm.Registry()
+	s := &scheduler{
+		ts:             ts,
+		im:             im,
+		feeManager:     feeManager,
+		r:              r,
+		timestamp:      timestamp,
+		prefix:         vm.StateManager().SchedulerPrefix(),
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+	}
+
+	// Fetch the next second to check
+	k := SchedulerKey(s.prefix)
+	tsv, err := newBlockView(ctx, ts, im, state.Keys{string(k): state.All})
+	if err != nil {
+		return 0, err
+	}
+	last := timestamp - timestamp%consts.MillisecondsPerSecond
+	next := last
+	v, err := tsv.GetValue(ctx, k)
+	switch {
+	case err == nil:
+		next = int64(binary.BigEndian.Uint64(v))
+	case errors.Is(err, database.ErrNotFound):
+	default:
+		return 0, err
+	}
+	start := next
+
+	// Execute scheduled actions
+	for i := 0; i < MaxScheduleScan && next <= last; i++ {
+		done, err := s.executeSecond(ctx, next)
+		if err != nil {
+			return 0, err
+		}
+		if !done {
+			break
+		}
+		next += consts.MillisecondsPerSecond
+	}
+	if next == start && v != nil {
+		return s.forfeited, nil
+	}
+	if err := tsv.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, uint64(next))); err != nil {
+		return 0, err
+	}
+	tsv.Commit()
+	return s.forfeited, nil
+}
+
+// executeSecond executes the actions scheduled at [second]. It returns true if all of
+// them were executed.
+func (s *scheduler) executeSecond(ctx context.Context, second int64) (bool, error) {
+	k := ScheduleKey(s.prefix, second)
+	tsv, err := newBlockView(ctx, s.ts, s.im, state.Keys{string(k): state.All})
+	if err != nil {
+		return false, err
+	}
+	schedule, err := tsv.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	if len(schedule)%consts.IDLen != 0 {
+		return false, ErrInvalidKeyValue
+	}
+	processed := 0
+	for ; processed < len(schedule); processed += consts.IDLen {
+		executed, err := s.execute(ctx, ids.ID(schedule[processed:processed+consts.IDLen]))
+		if err != nil {
+			return false, err
+		}
+		if !executed {
+			break
+		}
+	}
+	if processed == len(schedule) {
+		err = tsv.Remove(ctx, k)
+	} else {
+		err = tsv.Insert(ctx, k, schedule[processed:])
+	}
+	if err != nil {
+		return false, err
+	}
+	tsv.Commit()
+	return processed == len(schedule), nil
+}
+
+// execute executes (or drops) the [ScheduledAction] with [actionID]. It returns false
+// if the block cannot execute any more scheduled actions.
+func (s *scheduler) execute(ctx context.Context, actionID ids.ID) (bool, error) {
+	k := ScheduledActionKey(s.prefix, actionID)
+	etsv, err := newBlockView(ctx, s.ts, s.im, state.Keys{string(k): state.All})
+	if err != nil {
+		return false, err
+	}
+	raw, err := etsv.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		// Should never happen
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	sa, err := UnmarshalScheduledAction(raw, s.actionRegistry, s.authRegistry)
+	if err != nil {
+		// [ScheduledAction] can no longer be parsed (refund the prepaid fee if
+		// we can still parse the [Auth] that paid it)
+		auth, fee := s.unmarshalPrepaid(raw)
+		return true, s.drop(ctx, k, auth, fee)
+	}
+	stateKeys, err := sa.StateKeys(s.prefix, actionID)
+	if err != nil {
+		return true, s.drop(ctx, k, sa.Auth, sa.Fee)
+	}
+	maxUnits, err := sa.MaxUnits(s.r, s.prefix, actionID)
+	if err != nil {
+		return true, s.drop(ctx, k, sa.Auth, sa.Fee)
+	}
+	maxFee, err := s.feeManager.MaxFee(maxUnits)
+	if err != nil {
+		return false, err
+	}
+
+	// Drop [sa] (and refund the prepaid fee) if it can no longer be executed
+	if maxFee > sa.Fee || !s.valid(sa) || !(Dimensions{}).CanAdd(maxUnits, s.r.GetMaxScheduledUnits()) {
+		return true, s.drop(ctx, k, sa.Auth, sa.Fee)
+	}
+
+	// Ensure block can execute [sa]
+	if s.executed >= s.r.GetMaxScheduledActions() ||
+		!s.consumed.CanAdd(maxUnits, s.r.GetMaxScheduledUnits()) ||
+		!s.feeManager.UnitsConsumed().CanAdd(maxUnits, s.r.GetMaxBlockUnits()) {
+		return false, nil
+	}
+
+	// Execute [sa] (reverting any changes if unsuccessful)
+	tsv, err := newBlockView(ctx, s.ts, s.im, stateKeys)
+	if err != nil {
+		return false, err
+	}
+	tsv.EnableReadTracking()
+	actionStart := tsv.OpIndex()
+	success, actionCUs, _, _, err := sa.Action.Execute(ctx, s.r, tsv, s.timestamp, sa.Auth, actionID, false)
+	if err != nil || !success {
+		tsv.Rollback(ctx, actionStart)
+	}
+	if err := tsv.Remove(ctx, k); err != nil {
+		return false, err
+	}
+
+	// Charge for the units consumed (refunding the rest of the prepaid fee)
+	used, err := s.used(sa, k, raw, actionCUs, tsv)
+	if err != nil {
+		return false, err
+	}
+	if !maxUnits.Greater(used) {
+		return false, fmt.Errorf("%w: max=%+v consumed=%+v", ErrInvalidUnitsConsumed, maxUnits, used)
+	}
+	fee, err := s.feeManager.MaxFee(used)
+	if err != nil {
+		return false, err
+	}
+	if ok, d := s.feeManager.Consume(used, s.r.GetMaxBlockUnits()); !ok {
+		return false, fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
+	}
+	s.executed++
+	s.consumed, err = Add(s.consumed, used)
+	if err != nil {
+		return false, err
+	}
+	if err := refund(ctx, tsv, sa.Auth, sa.Fee-fee); err != nil {
+		return false, err
+	}
+	tsv.Commit()
+	return true, nil
+}
+
+// used returns the units consumed by executing [sa] (stored as [raw] at [k]) in [tsv].
+//
+// Like [Transaction.Execute], we only charge for the chunks read and we
+// pessimistically charge for the keys [Auth.Refund] could modify.
+func (s *scheduler) used(sa *ScheduledAction, k []byte, raw []byte, actionCUs uint64, tsv *tstate.TStateView) (Dimensions, error) {
+	computeUnitsOp := math.NewUint64Operator(s.r.GetBaseComputeUnits())
+	computeUnitsOp.Add(actionCUs)
+	computeUnits, err := computeUnitsOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	rawChunks, _ := keys.NumChunks(raw) // not possible to fail
+	readsOp := math.NewUint64Operator(s.r.GetStorageKeyReadUnits())
+	readsOp.MulAdd(uint64(rawChunks), s.r.GetStorageValueReadUnits())
+	for _, chunksRead := range tsv.Reads() {
+		readsOp.Add(s.r.GetStorageKeyReadUnits())
+		readsOp.MulAdd(uint64(chunksRead), s.r.GetStorageValueReadUnits())
+	}
+	readUnits, err := readsOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	allocates, writes := tsv.KeyOperations()
+	refundWrites := make(map[string]uint16, len(writes))
+	for key, chunksModified := range writes {
+		refundWrites[key] = chunksModified
+	}
+	for _, key := range sa.Auth.StateKeys() {
+		maxChunks, ok := keys.MaxChunks([]byte(key))
+		if !ok {
+			return Dimensions{}, ErrInvalidKeyValue
+		}
+		refundWrites[key] = maxChunks
+	}
+	allocatesOp := math.NewUint64Operator(0)
+	for _, chunksStored := range allocates {
+		allocatesOp.Add(s.r.GetStorageKeyAllocateUnits())
+		allocatesOp.MulAdd(uint64(chunksStored), s.r.GetStorageValueAllocateUnits())
+	}
+	allocateUnits, err := allocatesOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	writesOp := math.NewUint64Operator(0)
+	for _, chunksModified := range refundWrites {
+		writesOp.Add(s.r.GetStorageKeyWriteUnits())
+		writesOp.MulAdd(uint64(chunksModified), s.r.GetStorageValueWriteUnits())
+	}
+	writeUnits, err := writesOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	return Dimensions{0, computeUnits, readUnits, allocateUnits, writeUnits}, nil
+}
+
+// valid returns true if [sa] can be executed at the current timestamp.
+func (s *scheduler) valid(sa *ScheduledAction) bool {
+	if activation := s.r.GetAuthActivation(sa.Auth.GetTypeID()); activation >= 0 && s.timestamp < activation {
+		return false
+	}
+	if activation := s.r.GetActionActivation(sa.Action.GetTypeID()); activation >= 0 && s.timestamp < activation {
+		return false
+	}
+	start, end := sa.Auth.ValidRange(s.r)
+	if (start >= 0 && s.timestamp < start) || (end >= 0 && s.timestamp > end) {
+		return false
+	}
+	start, end = sa.Action.ValidRange(s.r)
+	if (start >= 0 && s.timestamp < start) || (end >= 0 && s.timestamp > end) {
+		return false
+	}
+	return true
+}
+
+// unmarshalPrepaid returns the [Auth] and fee of a [ScheduledAction] that could not
+// be parsed. If the [Auth] cannot be parsed either, it returns nil.
+func (s *scheduler) unmarshalPrepaid(raw []byte) (Auth, uint64) {
+	p := codec.NewReader(raw, len(raw))
+	p.UnpackInt64(false)
+	fee := p.UnpackUint64(false)
+	if p.Err() != nil {
+		// Should never happen ([Schedule] always stores the fee)
+		return nil, 0
+	}
+	auth, _, err := unmarshalAuth(p, s.authRegistry, nil)
+	if err != nil || p.Err() != nil {
+		return nil, fee
+	}
+	return auth, fee
+}
+
+// drop deletes the [ScheduledAction] stored at [k] without executing it and refunds
+// its prepaid [fee] to [auth]. If [auth] is nil, [fee] is forfeited instead.
+func (s *scheduler) drop(ctx context.Context, k []byte, auth Auth, fee uint64) error {
+	stateKeys := state.Keys{string(k): state.All}
+	if auth != nil {
+		for _, ak := range auth.StateKeys() {
+			if !keys.Valid(ak) {
+				// We can't refund [auth] if it modifies invalid keys
+				auth = nil
+				break
+			}
+			stateKeys.Add(ak, state.All)
+		}
+	}
+	tsv, err := newBlockView(ctx, s.ts, s.im, stateKeys)
+	if err != nil {
+		return err
+	}
+	if err := tsv.Remove(ctx, k); err != nil {
+		return err
+	}
+	if auth == nil {
+		s.forfeited, err = smath.Add64(s.forfeited, fee)
+		if err != nil {
+			return err
+		}
+	} else if err := refund(ctx, tsv, auth, fee); err != nil {
+		return err
+	}
+	tsv.Commit()
+	return nil
+}
+
+// refund returns [amount] to [auth].
+func refund(ctx context.Context, tsv *tstate.TStateView, auth Auth, amount uint64) error {
+	if amount == 0 {
+		return nil
+	}
+	tsv.DisableAllocation()
+	defer tsv.EnableAllocation()
+	return auth.Refund(ctx, tsv, amount)
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// SimulationResult is the outcome of executing a [Transaction] against some
+// state without persisting any of its changes.
+type SimulationResult struct {
+	*Result
+
+	// Reads are the keys read during execution and the number of chunks read
+	// (0 if the key did not exist).
+	Reads map[string]uint16
+	// Allocates are the keys created during execution and the number of chunks
+	// allocated.
+	Allocates map[string]uint16
+	// Writes are the keys modified during execution and the number of chunks
+	// written (0 if the key was removed).
+	Writes map[string]uint16
+
+	// AccessList contains the keys accessed during execution that were not
+	// specified by the [Transaction] (sorted). It is only populated in
+	// discovery mode.
+	AccessList [][]byte
+}
+
+// Simulate executes [tx] on top of [im] at [timestamp] in a throwaway [tstate.TStateView].
+//
+// Signatures (including any warp message signatures) are not verified, so
+// callers should only rely on the result if they intend to properly sign [tx].
+// If [tx] could not be included in a block at [timestamp], the error from [PreExecute]
+// is returned.
+//
+// If [discover] is set, [tx] is allowed to access keys it did not specify. These
+// keys are returned in [SimulationResult.AccessList] and should be attached to [tx]
+// (in [Transaction.AccessList]) before it is issued. Otherwise, [tx] is executed exactly
+// as it would be in a block.
+//
+// In discovery mode, [tx] is executed again with the keys it discovered attached
+// until it doesn't access any new keys, so the result (including [Result.Consumed]
+// and [Result.Fee]) is that of [tx] with [SimulationResult.AccessList] attached.
+func Simulate(
+	ctx context.Context,
+	tx *Transaction,
+	feeManager *FeeManager,
+	sm StateManager,
+	r Rules,
+	im state.Immutable,
+	timestamp int64,
+	discover bool,
+) (*SimulationResult, error) {
+	var (
+		candidate  = tx
+		accessList = [][]byte{}
+	)
+	for {
+		simulation, discovered, err := simulate(ctx, candidate, feeManager, sm, r, im, timestamp, discover)
+		if err != nil {
+			return nil, err
+		}
+		if len(discovered) == 0 {
+			if discover {
+				slices.SortFunc(accessList, func(a, b []byte) bool { return string(a) < string(b) })
+				simulation.AccessList = accessList
+			}
+			return simulation, nil
+		}
+		for _, k := range discovered {
+			accessList = append(accessList, []byte(k))
+		}
+		if len(tx.AccessList)+len(accessList) > MaxAccessListKeys {
+			return nil, ErrTooManyAccessListKeys
+		}
+		candidate = tx.withAccessList(append(slices.Clone(tx.AccessList), accessList...))
+	}
+}
+
+// simulate executes [tx] once (see [Simulate]) and returns the keys it accessed
+// that it did not specify (if [discover] is set).
+//
+// If [tx] accessed keys it did not specify, it may consume more units than it
+// can pay for. The result of execution is discarded in this case (only the
+// discovered keys are returned).
+func simulate(
+	ctx context.Context,
+	tx *Transaction,
+	feeManager *FeeManager,
+	sm StateManager,
+	r Rules,
+	im state.Immutable,
+	timestamp int64,
+	discover bool,
+) (*SimulationResult, []string, error) {
+	stateKeys, err := tx.StateKeys(sm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Fetch keys from [im]
+	var (
+		reads   = make(map[string]uint16, len(stateKeys))
+		storage = make(map[string][]byte, len(stateKeys))
+	)
+	for k := range stateKeys {
+		v, err := im.GetValue(ctx, []byte(k))
+		if errors.Is(err, database.ErrNotFound) {
+			reads[k] = 0
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		numChunks, ok := keys.NumChunks(v)
+		if !ok {
+			return nil, nil, ErrInvalidKeyValue
+		}
+		reads[k] = numChunks
+		storage[k] = v
+	}
+
+	// Execute transaction
+	var tsv *tstate.TStateView
+	if discover {
+		tsv = tstate.New(len(stateKeys)).NewDiscoveryView(im)
+	} else {
+		tsv = tstate.New(len(stateKeys)).NewView(stateKeys, storage)
+	}
+	tsv.EnableReadTracking()
+	authCUs, err := tx.PreExecute(ctx, feeManager, sm, r, tsv, timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := tx.Execute(ctx, feeManager, authCUs, reads, sm, r, tsv, timestamp, tx.WarpMessage != nil)
+
+	// Collect keys that were not specified
+	discovered := []string{}
+	if discover {
+		for k := range tsv.Scope() {
+			if _, ok := stateKeys[k]; ok {
+				continue
+			}
+			if !keys.Valid(k) {
+				return nil, nil, ErrInvalidKeyValue
+			}
+			discovered = append(discovered, k)
+		}
+	}
+	if err != nil {
+		if len(discovered) > 0 && errors.Is(err, ErrInvalidUnitsConsumed) {
+			return nil, discovered, nil
+		}
+		return nil, nil, err
+	}
+	allocates, writes := tsv.KeyOperations()
+	return &SimulationResult{
+		Result:    result,
+		Reads:     tsv.Reads(),
+		Allocates: allocates,
+		Writes:    writes,
+	}, discovered, nil
+}
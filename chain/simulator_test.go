@@ -0,0 +1,97 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// newDiscoveryAction returns an [Action] that copies the value stored at [from]
+// to [to] without specifying either key.
+func newDiscoveryAction(ctrl *gomock.Controller, from []byte, to []byte) Action {
+	action := NewMockAction(ctrl)
+	action.EXPECT().GetTypeID().Return(uint8(0)).AnyTimes()
+	action.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+	action.EXPECT().MaxComputeUnits(gomock.Any()).Return(uint64(1)).AnyTimes()
+	action.EXPECT().OutputsWarpMessage().Return(false).AnyTimes()
+	action.EXPECT().StateKeys(gomock.Any(), gomock.Any()).Return(state.Keys{}).AnyTimes()
+	action.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ Rules, mu state.Mutable, _ int64, _ Auth, _ ids.ID, _ bool) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+			v, err := mu.GetValue(ctx, from)
+			if err != nil {
+				return false, 1, nil, nil, err
+			}
+			return true, 1, nil, nil, mu.Insert(ctx, to, v)
+		},
+	).AnyTimes()
+	return action
+}
+
+func TestSimulateDiscover(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	var (
+		r  = newReplayRules(ctrl)
+		sm = &replayStateManager{}
+		fm = NewFeeManager(nil)
+
+		balanceKey = keys.EncodeChunks([]byte("balance"), 1)
+		fromKey    = keys.EncodeChunks([]byte("from"), 4)
+		toKey      = keys.EncodeChunks([]byte("to"), 4)
+		im         = replayState{
+			string(balanceKey): binary.BigEndian.AppendUint64(nil, 1_000_000),
+			string(fromKey):    make([]byte, 150), // 3 chunks
+		}
+		tx = &Transaction{
+			Base: &Base{
+				Timestamp: 20_000,
+				ChainID:   ids.Empty,
+				MaxFee:    10_000,
+			},
+			Actions: []Action{newDiscoveryAction(ctrl, fromKey, toKey)},
+			Auth:    newReplayAuth(ctrl, balanceKey),
+			size:    100,
+			id:      ids.GenerateTestID(),
+		}
+	)
+
+	// Undeclared keys can't be accessed outside of discovery mode
+	result, err := Simulate(ctx, tx, fm, sm, r, im, 10_000, false)
+	require.NoError(err)
+	require.False(result.Success)
+	require.Nil(result.AccessList)
+
+	// Undeclared keys that are read and written are discovered (and charged
+	// for)
+	discovered, err := Simulate(ctx, tx, fm, sm, r, im, 10_000, true)
+	require.NoError(err)
+	require.True(discovered.Success)
+	require.Equal([][]byte{fromKey, toKey}, discovered.AccessList)
+	require.Equal(uint16(3), discovered.Reads[string(fromKey)])
+	require.Equal(uint16(4), discovered.Allocates[string(toKey)]) // max chunks of [toKey]
+	require.Equal(uint16(3), discovered.Writes[string(toKey)])
+
+	// The result is the same as executing the transaction with the access
+	// list attached
+	attached := tx.withAccessList(discovered.AccessList)
+	expected, err := Simulate(ctx, attached, fm, sm, r, im, 10_000, false)
+	require.NoError(err)
+	require.True(expected.Success)
+	require.Equal(expected.Consumed, discovered.Consumed)
+	require.Equal(expected.Fee, discovered.Fee)
+	require.Greater(discovered.Consumed[Bandwidth], uint64(tx.Size()))
+	require.Empty(tx.AccessList)
+}
@@ -5,11 +5,13 @@ package chain
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 
@@ -32,8 +34,26 @@ var (
 type Transaction struct {
 	Base        *Base         `json:"base"`
 	WarpMessage *warp.Message `json:"warpMessage"`
-	Action      Action        `json:"action"`
-	Auth        Auth          `json:"auth"`
+	Actions     []Action      `json:"actions"`
+	// SponsorAddr is the address of the account that pays the fees of a
+	// sponsored [Transaction] (it is empty if [Auth] pays its own fees).
+	//
+	// SponsorAddr is included in the digest, so [Auth] can't be reused
+	// to have some other account pay for the [Transaction].
+	SponsorAddr codec.Address `json:"sponsorAddr"`
+	// AccessList contains state keys that may be accessed by [Actions] in
+	// addition to those returned by [Action.StateKeys]. This allows
+	// [Actions] to access keys that depend on state (which can be
+	// found with [Simulate] in discovery mode).
+	//
+	// Keys in [AccessList] are treated exactly like keys returned by
+	// [Action.StateKeys] (they are charged for and the [Transaction] can't
+	// access any key that is not specified).
+	AccessList [][]byte `json:"accessList"`
+	Auth       Auth     `json:"auth"`
+	// SponsorAuth authorizes [SponsorAddr] to pay the fees of a sponsored
+	// [Transaction]. It signs the same digest as [Auth].
+	SponsorAuth Auth `json:"sponsorAuth,omitempty"`
 
 	digest         []byte
 	bytes          []byte
@@ -45,7 +65,7 @@ type Transaction struct {
 	// prevents duplicates (like txID). We will not allow 2 instances of the same
 	// warpID from the same sourceChainID to be accepted.
 	warpID    ids.ID
-	stateKeys set.Set[string]
+	stateKeys state.Keys
 }
 
 type WarpResult struct {
@@ -53,31 +73,65 @@ type WarpResult struct {
 	VerifyErr error
 }
 
-func NewTx(base *Base, wm *warp.Message, act Action) *Transaction {
+func NewTx(base *Base, wm *warp.Message, actions []Action) *Transaction {
+	return &Transaction{
+		Base:        base,
+		WarpMessage: wm,
+		Actions:     actions,
+	}
+}
+
+// NewSponsoredTx creates a [Transaction] whose fees are paid by [sponsor]
+// instead of the actor. It must be signed with [SignSponsored].
+func NewSponsoredTx(base *Base, wm *warp.Message, actions []Action, sponsor codec.Address) *Transaction {
 	return &Transaction{
 		Base:        base,
 		WarpMessage: wm,
-		Action:      act,
+		Actions:     actions,
+		SponsorAddr: sponsor,
 	}
 }
 
+// NewCancelTx creates a [Transaction] without any [Action] that removes [txID] (a
+// pending transaction from the same sponsor) from the mempool. [base] must pay a
+// higher fee than [txID] (see [Base.Replaces]).
+func NewCancelTx(base *Base, txID ids.ID) *Transaction {
+	base.Replaces = txID
+	return &Transaction{Base: base}
+}
+
+// CreateActionID returns the ID provided to the [Action] at index [idx] of a
+// [Transaction].
+//
+// The first [Action] is provided the ID of the [Transaction] itself, so that
+// transactions with a single [Action] continue to produce the same identifiers
+// (i.e. for objects created during execution) as they always have.
+func CreateActionID(txID ids.ID, idx uint8) ids.ID {
+	if idx == 0 {
+		return txID
+	}
+	return txID.Prefix(uint64(idx))
+}
+
 func (t *Transaction) Digest() ([]byte, error) {
 	if len(t.digest) > 0 {
 		return t.digest, nil
 	}
-	actionID := t.Action.GetTypeID()
 	var warpBytes []byte
 	if t.WarpMessage != nil {
 		warpBytes = t.WarpMessage.Bytes()
 	}
 	size := t.Base.Size() +
 		codec.BytesLen(warpBytes) +
-		consts.ByteLen + t.Action.Size()
+		actionsSize(t.Actions) +
+		sponsorSize(t.Sponsored()) +
+		accessListSize(t.AccessList)
 	p := codec.NewWriter(size, consts.NetworkSizeLimit)
 	t.Base.Marshal(p)
 	p.PackBytes(warpBytes)
-	p.PackByte(actionID)
-	t.Action.Marshal(p)
+	marshalActions(p, t.Actions)
+	t.marshalSponsor(p)
+	marshalAccessList(p, t.AccessList)
 	return p.Bytes(), p.Err()
 }
 
@@ -91,15 +145,57 @@ func (t *Transaction) Sign(
 	if err != nil {
 		return nil, err
 	}
-	auth, err := factory.Sign(msg, t.Action)
+	if t.Sponsored() {
+		return nil, ErrMissingSponsorAuth
+	}
+	auth, err := factory.Sign(msg, t.Actions)
+	if err != nil {
+		return nil, err
+	}
+	t.Auth = auth
+	return t.reload(msg, actionRegistry, authRegistry)
+}
+
+// SignSponsored signs a sponsored [Transaction] with both the actor's [AuthFactory]
+// and the sponsor's [AuthFactory]. Both factories sign the same digest.
+func (t *Transaction) SignSponsored(
+	factory AuthFactory,
+	sponsorFactory AuthFactory,
+	actionRegistry ActionRegistry,
+	authRegistry AuthRegistry,
+) (*Transaction, error) {
+	// Generate auths
+	msg, err := t.Digest()
+	if err != nil {
+		return nil, err
+	}
+	if !t.Sponsored() {
+		return nil, ErrMissingSponsor
+	}
+	auth, err := factory.Sign(msg, t.Actions)
+	if err != nil {
+		return nil, err
+	}
+	sponsorAuth, err := sponsorFactory.Sign(msg, t.Actions)
 	if err != nil {
 		return nil, err
 	}
 	t.Auth = auth
+	t.SponsorAuth = sponsorAuth
+	return t.reload(msg, actionRegistry, authRegistry)
+}
 
-	// Ensure transaction is fully initialized and correct by reloading it from
-	// bytes
+// reload ensures the transaction is fully initialized and correct by reloading it from
+// bytes
+func (t *Transaction) reload(
+	msg []byte,
+	actionRegistry ActionRegistry,
+	authRegistry AuthRegistry,
+) (*Transaction, error) {
 	size := len(msg) + consts.ByteLen + t.Auth.Size()
+	if t.SponsorAuth != nil {
+		size += consts.ByteLen + t.SponsorAuth.Size()
+	}
 	p := codec.NewWriter(size, consts.NetworkSizeLimit)
 	if err := t.Marshal(p); err != nil {
 		return nil, err
@@ -116,12 +212,69 @@ func (t *Transaction) AuthAsyncVerify() func() error {
 		// It is up to [t.Auth] to limit the computational
 		// complexity of [t.Auth.AsyncVerify] and [t.Auth.Verify] to prevent
 		// a DoS (invalid Auth will not charge [t.Auth.Sponsor()].
-		return t.Auth.AsyncVerify(t.digest)
+		if err := t.Auth.AsyncVerify(t.digest); err != nil {
+			return err
+		}
+		if t.SponsorAuth == nil {
+			return nil
+		}
+		return t.SponsorAuth.AsyncVerify(t.digest)
+	}
+}
+
+// verifyAuth calls [DigestAuth.VerifyDigest] if [auth] implements
+// [DigestAuth] and [Auth.Verify] otherwise.
+func verifyAuth(
+	ctx context.Context,
+	auth Auth,
+	r Rules,
+	im state.Immutable,
+	digest []byte,
+	actions []Action,
+) (uint64, error) {
+	if da, ok := auth.(DigestAuth); ok {
+		return da.VerifyDigest(ctx, r, im, digest, actions)
+	}
+	return auth.Verify(ctx, r, im, actions)
+}
+
+// Sponsored returns true if the fees of the [Transaction] are paid by
+// [SponsorAddr] instead of the actor.
+func (t *Transaction) Sponsored() bool {
+	return t.SponsorAddr != codec.EmptyAddress
+}
+
+// Auths returns all [Auth] that must be verified for the [Transaction] to be
+// valid.
+func (t *Transaction) Auths() []Auth {
+	if t.SponsorAuth == nil {
+		return []Auth{t.Auth}
+	}
+	return []Auth{t.Auth, t.SponsorAuth}
+}
+
+// FeeAuth returns the [Auth] that pays the fees of the [Transaction].
+func (t *Transaction) FeeAuth() Auth {
+	if t.SponsorAuth != nil {
+		return t.SponsorAuth
 	}
+	return t.Auth
 }
 
 func (t *Transaction) Bytes() []byte { return t.bytes }
 
+// withAccessList returns a copy of [t] with [accessList] attached. The copy is
+// only suitable for simulation (it is not signed and keeps the ID of [t]).
+func (t *Transaction) withAccessList(accessList [][]byte) *Transaction {
+	c := *t
+	c.AccessList = accessList
+	c.size = t.size - accessListSize(t.AccessList) + accessListSize(accessList)
+	c.digest = nil
+	c.bytes = nil
+	c.stateKeys = nil
+	return &c
+}
+
 func (t *Transaction) Size() int { return t.size }
 
 func (t *Transaction) ID() ids.ID { return t.id }
@@ -130,34 +283,60 @@ func (t *Transaction) Expiry() int64 { return t.Base.Timestamp }
 
 func (t *Transaction) MaxFee() uint64 { return t.Base.MaxFee }
 
-func (t *Transaction) StateKeys(stateMapping StateManager) (set.Set[string], error) {
+func (t *Transaction) Nonce() uint64 { return t.Base.Nonce }
+
+func (t *Transaction) PriorityFee() uint64 { return t.Base.PriorityFee }
+
+// Cancels returns true if [Transaction] only cancels the pending transaction it
+// replaces (it doesn't have any [Action]).
+func (t *Transaction) Cancels() bool { return len(t.Actions) == 0 && t.Base.Replaces != ids.Empty }
+
+func (t *Transaction) StateKeys(stateMapping StateManager) (state.Keys, error) {
 	if t.stateKeys != nil {
 		return t.stateKeys, nil
 	}
 
 	// Verify the formatting of state keys passed by the controller
-	actionKeys := t.Action.StateKeys(t.Auth, t.ID())
-	authKeys := t.Auth.StateKeys()
-	stateKeys := set.NewSet[string](len(actionKeys) + len(authKeys))
-	for _, arr := range [][]string{actionKeys, authKeys} {
-		for _, k := range arr {
+	stateKeys := make(state.Keys, len(t.Auth.StateKeys()))
+	for _, auth := range t.Auths() {
+		for _, k := range auth.StateKeys() {
+			if !keys.Valid(k) {
+				return nil, ErrInvalidKeyValue
+			}
+			stateKeys.Add(k, state.All)
+		}
+	}
+	// Add key used to track the nonce of the sponsor
+	if t.Base.Nonce > 0 {
+		stateKeys.Add(string(NonceKey(stateMapping.NonceKey(t.Sponsor()))), state.All)
+	}
+	for i, action := range t.Actions {
+		for k, permissions := range action.StateKeys(t.Auth, CreateActionID(t.id, uint8(i))) {
 			if !keys.Valid(k) {
 				return nil, ErrInvalidKeyValue
 			}
-			stateKeys.Add(k)
+			stateKeys.Add(k, permissions)
+		}
+	}
+	// Access list keys were discovered by execution, so we don't know
+	// how they will be accessed.
+	for _, k := range t.AccessList {
+		if !keys.Valid(string(k)) {
+			return nil, ErrInvalidKeyValue
 		}
+		stateKeys.Add(string(k), state.All)
 	}
 
 	// Add keys used to manage warp operations
 	if t.WarpMessage != nil {
 		p := stateMapping.IncomingWarpKeyPrefix(t.WarpMessage.SourceChainID, t.warpID)
 		k := keys.EncodeChunks(p, MaxIncomingWarpChunks)
-		stateKeys.Add(string(k))
+		stateKeys.Add(string(k), state.All)
 	}
-	if t.Action.OutputsWarpMessage() {
+	if t.outputsWarpMessage() {
 		p := stateMapping.OutgoingWarpKeyPrefix(t.id)
 		k := keys.EncodeChunks(p, MaxOutgoingWarpChunks)
-		stateKeys.Add(string(k))
+		stateKeys.Add(string(k), state.All)
 	}
 
 	// Cache keys if called again
@@ -170,13 +349,17 @@ func (t *Transaction) StateKeys(stateMapping StateManager) (set.Set[string], err
 func (t *Transaction) MaxUnits(sm StateManager, r Rules) (Dimensions, error) {
 	// Cacluate max compute costs
 	maxComputeUnitsOp := math.NewUint64Operator(r.GetBaseComputeUnits())
-	maxComputeUnitsOp.Add(t.Action.MaxComputeUnits(r))
-	maxComputeUnitsOp.Add(t.Auth.MaxComputeUnits(r))
+	for _, action := range t.Actions {
+		maxComputeUnitsOp.Add(action.MaxComputeUnits(r))
+	}
+	for _, auth := range t.Auths() {
+		maxComputeUnitsOp.Add(auth.MaxComputeUnits(r))
+	}
 	if t.WarpMessage != nil {
 		maxComputeUnitsOp.Add(r.GetBaseWarpComputeUnits())
 		maxComputeUnitsOp.MulAdd(uint64(t.numWarpSigners), r.GetWarpComputeUnitsPerSigner())
 	}
-	if t.Action.OutputsWarpMessage() {
+	if t.outputsWarpMessage() {
 		// Chunks later accounted for by call to [StateKeys]
 		maxComputeUnitsOp.Add(r.GetOutgoingWarpComputeUnits())
 	}
@@ -193,53 +376,87 @@ func (t *Transaction) MaxUnits(sm StateManager, r Rules) (Dimensions, error) {
 	if err != nil {
 		return Dimensions{}, err
 	}
+	reads, allocates, writes, err := maxStorageUnits(r, stateKeys)
+	if err != nil {
+		return Dimensions{}, err
+	}
+	return Dimensions{uint64(t.Size()), maxComputeUnits, reads, allocates, writes}, nil
+}
+
+// maxStorageUnits returns the max storage cost that could be incurred by processing
+// all [stateKeys]. Keys that are only read can't be allocated or written.
+func maxStorageUnits(r Rules, stateKeys state.Keys) (uint64, uint64, uint64, error) {
 	readsOp := math.NewUint64Operator(0)
 	allocatesOp := math.NewUint64Operator(0)
 	writesOp := math.NewUint64Operator(0)
-	for k := range stateKeys {
-		// Compute key costs
-		readsOp.Add(r.GetStorageKeyReadUnits())
-		allocatesOp.Add(r.GetStorageKeyAllocateUnits())
-		writesOp.Add(r.GetStorageKeyWriteUnits())
-
-		// Compute value costs
+	for k, permissions := range stateKeys {
 		maxChunks, ok := keys.MaxChunks([]byte(k))
 		if !ok {
-			return Dimensions{}, ErrInvalidKeyValue
+			return 0, 0, 0, ErrInvalidKeyValue
 		}
+
+		// Compute key and value costs
+		readsOp.Add(r.GetStorageKeyReadUnits())
 		readsOp.MulAdd(uint64(maxChunks), r.GetStorageValueReadUnits())
+		if !permissions.Has(state.Write) {
+			continue
+		}
+		allocatesOp.Add(r.GetStorageKeyAllocateUnits())
 		allocatesOp.MulAdd(uint64(maxChunks), r.GetStorageValueAllocateUnits())
+		writesOp.Add(r.GetStorageKeyWriteUnits())
 		writesOp.MulAdd(uint64(maxChunks), r.GetStorageValueWriteUnits())
 	}
 	reads, err := readsOp.Value()
 	if err != nil {
-		return Dimensions{}, err
+		return 0, 0, 0, err
 	}
 	allocates, err := allocatesOp.Value()
 	if err != nil {
-		return Dimensions{}, err
+		return 0, 0, 0, err
 	}
 	writes, err := writesOp.Value()
 	if err != nil {
-		return Dimensions{}, err
+		return 0, 0, 0, err
 	}
-	return Dimensions{uint64(t.Size()), maxComputeUnits, reads, allocates, writes}, nil
+	return reads, allocates, writes, nil
 }
 
 // EstimateMaxUnits provides a pessimistic estimate of the cost to execute a transaction. This is
 // typically used during transaction construction.
-func EstimateMaxUnits(r Rules, action Action, authFactory AuthFactory, warpMessage *warp.Message) (Dimensions, error) {
+func EstimateMaxUnits(r Rules, actions []Action, authFactory AuthFactory, warpMessage *warp.Message) (Dimensions, error) {
+	return EstimateSponsoredMaxUnits(r, actions, authFactory, nil, warpMessage)
+}
+
+// EstimateSponsoredMaxUnits provides a pessimistic estimate of the cost to execute a transaction
+// whose fees are paid by [sponsorFactory]. If [sponsorFactory] is nil, the transaction is assumed
+// to not be sponsored.
+func EstimateSponsoredMaxUnits(
+	r Rules,
+	actions []Action,
+	authFactory AuthFactory,
+	sponsorFactory AuthFactory,
+	warpMessage *warp.Message,
+) (Dimensions, error) {
 	authBandwidth, authCompute, authStateKeysMaxChunks := authFactory.MaxUnits()
-	bandwidth := BaseSize + consts.ByteLen + uint64(action.Size()) + consts.ByteLen + authBandwidth
-	actionStateKeysMaxChunks := action.StateKeysMaxChunks()
-	stateKeysMaxChunks := make([]uint16, 0, len(authStateKeysMaxChunks)+len(actionStateKeysMaxChunks))
+	bandwidth := BaseSize + uint64(actionsSize(actions)) + uint64(sponsorSize(sponsorFactory != nil)) + uint64(accessListSize(nil)) + consts.ByteLen + authBandwidth
+	stateKeysMaxChunks := make([]uint16, 0, len(authStateKeysMaxChunks))
 	stateKeysMaxChunks = append(stateKeysMaxChunks, authStateKeysMaxChunks...)
-	stateKeysMaxChunks = append(stateKeysMaxChunks, actionStateKeysMaxChunks...)
 
 	// Estimate compute costs
 	computeUnitsOp := math.NewUint64Operator(r.GetBaseComputeUnits())
 	computeUnitsOp.Add(authCompute)
-	computeUnitsOp.Add(action.MaxComputeUnits(r))
+	if sponsorFactory != nil {
+		sponsorBandwidth, sponsorCompute, sponsorStateKeysMaxChunks := sponsorFactory.MaxUnits()
+		bandwidth += consts.ByteLen + sponsorBandwidth
+		stateKeysMaxChunks = append(stateKeysMaxChunks, sponsorStateKeysMaxChunks...)
+		computeUnitsOp.Add(sponsorCompute)
+	}
+	outputsWarp := false
+	for _, action := range actions {
+		stateKeysMaxChunks = append(stateKeysMaxChunks, action.StateKeysMaxChunks()...)
+		computeUnitsOp.Add(action.MaxComputeUnits(r))
+		outputsWarp = outputsWarp || action.OutputsWarpMessage()
+	}
 	if warpMessage != nil {
 		bandwidth += uint64(codec.BytesLen(warpMessage.Bytes()))
 		stateKeysMaxChunks = append(stateKeysMaxChunks, MaxIncomingWarpChunks)
@@ -250,7 +467,7 @@ func EstimateMaxUnits(r Rules, action Action, authFactory AuthFactory, warpMessa
 		}
 		computeUnitsOp.MulAdd(uint64(numSigners), r.GetWarpComputeUnitsPerSigner())
 	}
-	if action.OutputsWarpMessage() {
+	if outputsWarp {
 		stateKeysMaxChunks = append(stateKeysMaxChunks, MaxOutgoingWarpChunks)
 		computeUnitsOp.Add(r.GetOutgoingWarpComputeUnits())
 	}
@@ -291,6 +508,69 @@ func EstimateMaxUnits(r Rules, action Action, authFactory AuthFactory, warpMessa
 	return Dimensions{bandwidth, computeUnits, reads, allocates, writes}, nil
 }
 
+// EstimateAccessListUnits provides a pessimistic estimate of the additional cost of
+// attaching [accessList] to a transaction. This should be added to the estimate returned
+// by [EstimateMaxUnits] (or [EstimateSponsoredMaxUnits]).
+func EstimateAccessListUnits(r Rules, accessList [][]byte) (Dimensions, error) {
+	bandwidth := uint64(accessListSize(accessList) - accessListSize(nil))
+	readsOp := math.NewUint64Operator(0)
+	allocatesOp := math.NewUint64Operator(0)
+	writesOp := math.NewUint64Operator(0)
+	for _, k := range accessList {
+		// Compute key costs
+		readsOp.Add(r.GetStorageKeyReadUnits())
+		allocatesOp.Add(r.GetStorageKeyAllocateUnits())
+		writesOp.Add(r.GetStorageKeyWriteUnits())
+
+		// Compute value costs
+		maxChunks, ok := keys.MaxChunks(k)
+		if !ok {
+			return Dimensions{}, ErrInvalidKeyValue
+		}
+		readsOp.MulAdd(uint64(maxChunks), r.GetStorageValueReadUnits())
+		allocatesOp.MulAdd(uint64(maxChunks), r.GetStorageValueAllocateUnits())
+		writesOp.MulAdd(uint64(maxChunks), r.GetStorageValueWriteUnits())
+	}
+	reads, err := readsOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	allocates, err := allocatesOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	writes, err := writesOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	return Dimensions{bandwidth, 0, reads, allocates, writes}, nil
+}
+
+// EstimateNonceUnits provides a pessimistic estimate of the additional cost of specifying
+// a [Base.Nonce]. This should be added to the estimate returned by [EstimateMaxUnits] (or
+// [EstimateSponsoredMaxUnits]) if the transaction specifies a [Base.Nonce].
+func EstimateNonceUnits(r Rules) (Dimensions, error) {
+	readsOp := math.NewUint64Operator(r.GetStorageKeyReadUnits())
+	readsOp.MulAdd(NonceKeyChunks, r.GetStorageValueReadUnits())
+	reads, err := readsOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	allocatesOp := math.NewUint64Operator(r.GetStorageKeyAllocateUnits())
+	allocatesOp.MulAdd(NonceKeyChunks, r.GetStorageValueAllocateUnits())
+	allocates, err := allocatesOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	writesOp := math.NewUint64Operator(r.GetStorageKeyWriteUnits())
+	writesOp.MulAdd(NonceKeyChunks, r.GetStorageValueWriteUnits())
+	writes, err := writesOp.Value()
+	if err != nil {
+		return Dimensions{}, err
+	}
+	return Dimensions{0, 0, reads, allocates, writes}, nil
+}
+
 func (t *Transaction) PreExecute(
 	ctx context.Context,
 	feeManager *FeeManager,
@@ -302,26 +582,45 @@ func (t *Transaction) PreExecute(
 	if err := t.Base.Execute(r.ChainID(), r, timestamp); err != nil {
 		return 0, err
 	}
-	start, end := t.Action.ValidRange(r)
-	if start >= 0 && timestamp < start {
-		return 0, ErrActionNotActivated
-	}
-	if end >= 0 && timestamp > end {
-		return 0, ErrActionNotActivated
+	if len(t.Actions) > int(r.GetMaxActionsPerTx()) {
+		return 0, ErrTooManyActions
 	}
-	start, end = t.Auth.ValidRange(r)
-	if start >= 0 && timestamp < start {
-		return 0, ErrAuthNotActivated
+	for _, action := range t.Actions {
+		if activation := r.GetActionActivation(action.GetTypeID()); activation >= 0 && timestamp < activation {
+			return 0, ErrActionNotActivated
+		}
+		start, end := action.ValidRange(r)
+		if start >= 0 && timestamp < start {
+			return 0, ErrActionNotActivated
+		}
+		if end >= 0 && timestamp > end {
+			return 0, ErrActionNotActivated
+		}
 	}
-	if end >= 0 && timestamp > end {
-		return 0, ErrAuthNotActivated
+	authCUsOp := math.NewUint64Operator(0)
+	for _, auth := range t.Auths() {
+		if activation := r.GetAuthActivation(auth.GetTypeID()); activation >= 0 && timestamp < activation {
+			return 0, ErrAuthNotActivated
+		}
+		start, end := auth.ValidRange(r)
+		if start >= 0 && timestamp < start {
+			return 0, ErrAuthNotActivated
+		}
+		if end >= 0 && timestamp > end {
+			return 0, ErrAuthNotActivated
+		}
+		// It is up to [auth] to limit the computational
+		// complexity of [auth.AsyncVerify] and [auth.Verify] to prevent
+		// a DoS (invalid Auth will not charge [auth.Sponsor()].
+		authCUs, err := verifyAuth(ctx, auth, r, im, t.digest, t.Actions)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrAuthFailed, err) //nolint:errorlint
+		}
+		authCUsOp.Add(authCUs)
 	}
-	// It is up to [t.Auth] to limit the computational
-	// complexity of [t.Auth.AsyncVerify] and [t.Auth.Verify] to prevent
-	// a DoS (invalid Auth will not charge [t.Auth.Sponsor()].
-	authCUs, err := t.Auth.Verify(ctx, r, im, t.Action)
+	authCUs, err := authCUsOp.Value()
 	if err != nil {
-		return 0, fmt.Errorf("%w: %v", ErrAuthFailed, err) //nolint:errorlint
+		return 0, err
 	}
 	maxUnits, err := t.MaxUnits(s, r)
 	if err != nil {
@@ -331,9 +630,28 @@ func (t *Transaction) PreExecute(
 	if err != nil {
 		return 0, err
 	}
-	if err := t.Auth.CanDeduct(ctx, im, maxFee); err != nil {
+	totalFee, err := smath.Add64(maxFee, t.Base.PriorityFee)
+	if err != nil {
+		return 0, err
+	}
+	if err := t.FeeAuth().CanDeduct(ctx, im, totalFee); err != nil {
 		return 0, err
 	}
+
+	// We check the nonce last so that a transaction that fails with [ErrNonceTooHigh]
+	// is otherwise valid (and can be held until the gap is filled).
+	if t.Base.Nonce > 0 {
+		nonce, err := GetNonce(ctx, im, s, t.Sponsor())
+		if err != nil {
+			return 0, err
+		}
+		if t.Base.Nonce <= nonce {
+			return 0, fmt.Errorf("%w: expected=%d found=%d", ErrNonceTooLow, nonce+1, t.Base.Nonce)
+		}
+		if t.Base.Nonce > nonce+1 {
+			return 0, fmt.Errorf("%w: expected=%d found=%d", ErrNonceTooHigh, nonce+1, t.Base.Nonce)
+		}
+	}
 	return authCUs, nil
 }
 
@@ -366,12 +684,34 @@ func (t *Transaction) Execute(
 		// Should never happen
 		return nil, err
 	}
-	if err := t.Auth.Deduct(ctx, ts, maxFee); err != nil {
+	totalFee, err := smath.Add64(maxFee, t.Base.PriorityFee)
+	if err != nil {
+		// Should never happen
+		return nil, err
+	}
+	if err := t.FeeAuth().Deduct(ctx, ts, totalFee); err != nil {
 		// This should never fail for low balance (as we check [CanDeductFee]
 		// immediately before).
 		return nil, err
 	}
 
+	// Consume the nonce (like the fee, this happens regardless of whether the
+	// actions succeed)
+	if t.Base.Nonce > 0 {
+		k := NonceKey(s.NonceKey(t.Sponsor()))
+		if err := ts.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, t.Base.Nonce)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Record the actions authorized by [Auth] (this is checked by
+	// [Auth.Verify] during [PreExecute], so it should never fail)
+	if ra, ok := t.Auth.(RecordingAuth); ok {
+		if err := ra.RecordActions(ctx, ts); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check warp message is not duplicate
 	if t.WarpMessage != nil {
 		p := s.IncomingWarpKeyPrefix(t.WarpMessage.SourceChainID, t.warpID)
@@ -386,38 +726,73 @@ func (t *Transaction) Execute(
 		case err != nil:
 			// An error here can indicate there is an issue with the database or that
 			// the key was not properly specified.
-			return &Result{false, utils.ErrBytes(err), maxUnits, maxFee, nil}, nil
+			return &Result{false, [][]byte{utils.ErrBytes(err)}, maxUnits, totalFee, nil}, nil
 		}
 	}
 
 	// We create a temp state checkpoint to ensure we don't commit failed actions to state.
+	//
+	// All actions share a single checkpoint, so if any of them fail, the changes made by
+	// all of them are reverted.
 	actionStart := ts.OpIndex()
 	handleRevert := func(rerr error) (*Result, error) {
 		// Be warned that the variables captured in this function
 		// are set when this function is defined. If any of them are
 		// modified later, they will not be used here.
 		ts.Rollback(ctx, actionStart)
-		return &Result{false, utils.ErrBytes(rerr), maxUnits, maxFee, nil}, nil
+		return &Result{false, [][]byte{utils.ErrBytes(rerr)}, maxUnits, totalFee, nil}, nil
+	}
+	var (
+		success     = true
+		actionCUsOp = math.NewUint64Operator(0)
+		outputs     = make([][]byte, 0, len(t.Actions))
+		warpMessage *warp.UnsignedMessage
+	)
+	for i, action := range t.Actions {
+		actionSuccess, actionCUs, output, actionWarpMessage, err := action.Execute(
+			ctx,
+			r,
+			ts,
+			timestamp,
+			t.Auth,
+			CreateActionID(t.id, uint8(i)),
+			warpVerified,
+		)
+		if err != nil {
+			return handleRevert(err)
+		}
+		if len(output) == 0 && output != nil {
+			// Enforce object standardization (this is a VM bug and we should fail
+			// fast)
+			return handleRevert(ErrInvalidObject)
+		}
+		outputs = append(outputs, output)
+		actionCUsOp.Add(actionCUs)
+		if !actionSuccess {
+			// We don't execute any actions after the first failure (the output
+			// of the failed action is always the last item in [outputs]).
+			success = false
+			break
+		}
+
+		// Ensure constraints hold if successful
+		outputsWarp := action.OutputsWarpMessage()
+		if (actionWarpMessage == nil && outputsWarp) || (actionWarpMessage != nil && !outputsWarp) {
+			return handleRevert(ErrInvalidObject)
+		}
+		if actionWarpMessage != nil {
+			warpMessage = actionWarpMessage
+		}
 	}
-	success, actionCUs, output, warpMessage, err := t.Action.Execute(ctx, r, ts, timestamp, t.Auth, t.id, warpVerified)
+	actionCUs, err := actionCUsOp.Value()
 	if err != nil {
 		return handleRevert(err)
 	}
-	if len(output) == 0 && output != nil {
-		// Enforce object standardization (this is a VM bug and we should fail
-		// fast)
-		return handleRevert(ErrInvalidObject)
-	}
-	outputsWarp := t.Action.OutputsWarpMessage()
+	outputsWarp := t.outputsWarpMessage()
 	if !success {
 		ts.Rollback(ctx, actionStart)
 		warpMessage = nil // warp messages can only be emitted on success
 	} else {
-		// Ensure constraints hold if successful
-		if (warpMessage == nil && outputsWarp) || (warpMessage != nil && !outputsWarp) {
-			return handleRevert(ErrInvalidObject)
-		}
-
 		// Store incoming warp messages in state by their ID to prevent replays
 		if t.WarpMessage != nil {
 			p := s.IncomingWarpKeyPrefix(t.WarpMessage.SourceChainID, t.warpID)
@@ -469,7 +844,7 @@ func (t *Transaction) Execute(
 
 	// Because we compute the fee before [Auth.Refund] is called, we need
 	// to pessimistically precompute the storage it will change.
-	for _, key := range t.Auth.StateKeys() {
+	for _, key := range t.FeeAuth().StateKeys() {
 		// maxChunks will be greater than the chunks read in any of these keys,
 		// so we don't need to check for pre-existing values.
 		maxChunks, ok := keys.MaxChunks([]byte(key))
@@ -518,7 +893,7 @@ func (t *Transaction) Execute(
 		return nil, fmt.Errorf("%w: max=%+v consumed=%+v", ErrInvalidUnitsConsumed, maxUnits, used)
 	}
 
-	// Return any funds from unused units
+	// Return any funds from unused units (the [Base.PriorityFee] is never refunded)
 	//
 	// To avoid storage abuse of [Auth.Refund], we precharge for possible usage.
 	feeRequired, err := feeManager.MaxFee(used)
@@ -529,23 +904,81 @@ func (t *Transaction) Execute(
 	if refund > 0 {
 		ts.DisableAllocation()
 		defer ts.EnableAllocation()
-		if err := t.Auth.Refund(ctx, ts, refund); err != nil {
+		if err := t.FeeAuth().Refund(ctx, ts, refund); err != nil {
 			return handleRevert(err)
 		}
 	}
 	return &Result{
 		Success: success,
-		Output:  output,
+		Outputs: outputs,
 
 		Consumed: used,
-		Fee:      feeRequired,
+		Fee:      feeRequired + t.Base.PriorityFee,
 
 		WarpMessage: warpMessage,
 	}, nil
 }
 
+// Sponsor returns the address that pays the fees of the [Transaction].
 func (t *Transaction) Sponsor() codec.Address {
-	return t.Auth.Sponsor()
+	return t.FeeAuth().Sponsor()
+}
+
+// outputsWarpMessage returns true if any [Action] in the [Transaction] will
+// produce a warp message.
+func (t *Transaction) outputsWarpMessage() bool {
+	for _, action := range t.Actions {
+		if action.OutputsWarpMessage() {
+			return true
+		}
+	}
+	return false
+}
+
+func actionsSize(actions []Action) int {
+	size := consts.ByteLen
+	for _, action := range actions {
+		size += consts.ByteLen + action.Size()
+	}
+	return size
+}
+
+func marshalActions(p *codec.Packer, actions []Action) {
+	p.PackByte(uint8(len(actions)))
+	for _, action := range actions {
+		p.PackByte(action.GetTypeID())
+		action.Marshal(p)
+	}
+}
+
+func sponsorSize(sponsored bool) int {
+	if !sponsored {
+		return consts.BoolLen
+	}
+	return consts.BoolLen + codec.AddressLen
+}
+
+func (t *Transaction) marshalSponsor(p *codec.Packer) {
+	sponsored := t.Sponsored()
+	p.PackBool(sponsored)
+	if sponsored {
+		p.PackAddress(t.SponsorAddr)
+	}
+}
+
+func accessListSize(accessList [][]byte) int {
+	size := consts.ByteLen
+	for _, k := range accessList {
+		size += codec.BytesLen(k)
+	}
+	return size
+}
+
+func marshalAccessList(p *codec.Packer, accessList [][]byte) {
+	p.PackByte(uint8(len(accessList)))
+	for _, k := range accessList {
+		p.PackBytes(k)
+	}
 }
 
 func (t *Transaction) Marshal(p *codec.Packer) error {
@@ -554,7 +987,12 @@ func (t *Transaction) Marshal(p *codec.Packer) error {
 		return p.Err()
 	}
 
-	actionID := t.Action.GetTypeID()
+	if t.Sponsored() != (t.SponsorAuth != nil) {
+		return ErrMissingSponsorAuth
+	}
+	if len(t.AccessList) > MaxAccessListKeys {
+		return ErrTooManyAccessListKeys
+	}
 	authID := t.Auth.GetTypeID()
 	t.Base.Marshal(p)
 	var warpBytes []byte
@@ -565,10 +1003,15 @@ func (t *Transaction) Marshal(p *codec.Packer) error {
 		}
 	}
 	p.PackBytes(warpBytes)
-	p.PackByte(actionID)
-	t.Action.Marshal(p)
+	marshalActions(p, t.Actions)
+	t.marshalSponsor(p)
+	marshalAccessList(p, t.AccessList)
 	p.PackByte(authID)
 	t.Auth.Marshal(p)
+	if t.SponsorAuth != nil {
+		p.PackByte(t.SponsorAuth.GetTypeID())
+		t.SponsorAuth.Marshal(p)
+	}
 	return p.Err()
 }
 
@@ -603,7 +1046,9 @@ func UnmarshalTxs(
 			return nil, nil, err
 		}
 		txs = append(txs, tx)
-		authCounts[tx.Auth.GetTypeID()]++
+		for _, auth := range tx.Auths() {
+			authCounts[auth.GetTypeID()]++
+		}
 	}
 	if !p.Empty() {
 		// Ensure no leftover bytes
@@ -641,36 +1086,87 @@ func UnmarshalTx(
 		}
 		numWarpSigners = numSigners
 	}
-	actionType := p.UnpackByte()
-	unmarshalAction, actionWarp, ok := actionRegistry.LookupIndex(actionType)
-	if !ok {
-		return nil, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
-	}
-	if actionWarp && warpMessage == nil {
-		return nil, fmt.Errorf("%w: action %d", ErrExpectedWarpMessage, actionType)
+	numActions := p.UnpackByte()
+	if numActions == 0 && base.Replaces == ids.Empty {
+		// Only transactions that cancel a pending transaction can omit actions
+		return nil, fmt.Errorf("%w: no actions", ErrInvalidObject)
+	}
+	var (
+		actions                       = make([]Action, 0, numActions)
+		actionWarp, actionOutputsWarp bool
+	)
+	for i := uint8(0); i < numActions; i++ {
+		actionType := p.UnpackByte()
+		unmarshalAction, requiresWarp, ok := actionRegistry.LookupIndex(actionType)
+		if !ok {
+			return nil, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
+		}
+		if requiresWarp {
+			if warpMessage == nil {
+				return nil, fmt.Errorf("%w: action %d", ErrExpectedWarpMessage, actionType)
+			}
+			// A warp message can only be consumed by a single action
+			if actionWarp {
+				return nil, fmt.Errorf("%w: action %d", ErrTooManyWarpActions, actionType)
+			}
+			actionWarp = true
+		}
+		action, err := unmarshalAction(p, warpMessage)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not unmarshal action", err)
+		}
+		if action.OutputsWarpMessage() {
+			// Outgoing warp messages are stored by txID, so only a single
+			// action can produce one
+			if actionOutputsWarp {
+				return nil, fmt.Errorf("%w: action %d", ErrTooManyWarpActions, actionType)
+			}
+			actionOutputsWarp = true
+		}
+		actions = append(actions, action)
+	}
+	var sponsorAddr codec.Address
+	sponsored := p.UnpackBool()
+	if sponsored {
+		p.UnpackAddress(&sponsorAddr)
+		if sponsorAddr == codec.EmptyAddress {
+			return nil, fmt.Errorf("%w: empty sponsor", ErrInvalidSponsor)
+		}
 	}
-	action, err := unmarshalAction(p, warpMessage)
-	if err != nil {
-		return nil, fmt.Errorf("%w: could not unmarshal action", err)
+	numAccessListKeys := p.UnpackByte()
+	if numAccessListKeys > MaxAccessListKeys {
+		return nil, ErrTooManyAccessListKeys
+	}
+	var accessList [][]byte
+	if numAccessListKeys > 0 {
+		accessList = make([][]byte, 0, numAccessListKeys)
+		seen := set.NewSet[string](int(numAccessListKeys))
+		for i := uint8(0); i < numAccessListKeys; i++ {
+			var k []byte
+			p.UnpackBytes(MaxAccessListKeySize, true, &k)
+			if !keys.Valid(string(k)) || seen.Contains(string(k)) {
+				return nil, fmt.Errorf("%w: invalid access list key", ErrInvalidObject)
+			}
+			seen.Add(string(k))
+			accessList = append(accessList, k)
+		}
 	}
 	digest := p.Offset()
-	authType := p.UnpackByte()
-	unmarshalAuth, authWarp, ok := authRegistry.LookupIndex(authType)
-	if !ok {
-		return nil, fmt.Errorf("%w: %d is unknown auth type", ErrInvalidObject, authType)
-	}
-	if authWarp && warpMessage == nil {
-		return nil, fmt.Errorf("%w: auth %d", ErrExpectedWarpMessage, authType)
-	}
-	auth, err := unmarshalAuth(p, warpMessage)
+	auth, authWarp, err := unmarshalAuth(p, authRegistry, warpMessage)
 	if err != nil {
-		return nil, fmt.Errorf("%w: could not unmarshal auth", err)
-	}
-	if actorType := auth.Actor()[0]; actorType != authType {
-		return nil, fmt.Errorf("%w: actorType (%d) did not match authType (%d)", ErrInvalidActor, actorType, authType)
+		return nil, err
 	}
-	if sponsorType := auth.Sponsor()[0]; sponsorType != authType {
-		return nil, fmt.Errorf("%w: sponsorType (%d) did not match authType (%d)", ErrInvalidSponsor, sponsorType, authType)
+	var sponsorAuth Auth
+	if sponsored {
+		var sponsorWarp bool
+		sponsorAuth, sponsorWarp, err = unmarshalAuth(p, authRegistry, warpMessage)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not unmarshal sponsor auth", err)
+		}
+		if sponsorAuth.Sponsor() != sponsorAddr {
+			return nil, fmt.Errorf("%w: sponsor auth did not match sponsor address", ErrInvalidSponsor)
+		}
+		authWarp = authWarp || sponsorWarp
 	}
 	warpExpected := actionWarp || authWarp
 	if !warpExpected && warpMessage != nil {
@@ -679,9 +1175,12 @@ func UnmarshalTx(
 
 	var tx Transaction
 	tx.Base = base
-	tx.Action = action
+	tx.Actions = actions
 	tx.WarpMessage = warpMessage
+	tx.SponsorAddr = sponsorAddr
+	tx.AccessList = accessList
 	tx.Auth = auth
+	tx.SponsorAuth = sponsorAuth
 	if err := p.Err(); err != nil {
 		return nil, p.Err()
 	}
@@ -696,3 +1195,29 @@ func UnmarshalTx(
 	}
 	return &tx, nil
 }
+
+func unmarshalAuth(
+	p *codec.Packer,
+	authRegistry *codec.TypeParser[Auth, *warp.Message, bool],
+	warpMessage *warp.Message,
+) (Auth, bool, error) {
+	authType := p.UnpackByte()
+	unmarshal, authWarp, ok := authRegistry.LookupIndex(authType)
+	if !ok {
+		return nil, false, fmt.Errorf("%w: %d is unknown auth type", ErrInvalidObject, authType)
+	}
+	if authWarp && warpMessage == nil {
+		return nil, false, fmt.Errorf("%w: auth %d", ErrExpectedWarpMessage, authType)
+	}
+	auth, err := unmarshal(p, warpMessage)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: could not unmarshal auth", err)
+	}
+	if actorType := auth.Actor()[0]; actorType != authType {
+		return nil, false, fmt.Errorf("%w: actorType (%d) did not match authType (%d)", ErrInvalidActor, actorType, authType)
+	}
+	if sponsorType := auth.Sponsor()[0]; sponsorType != authType {
+		return nil, false, fmt.Errorf("%w: sponsorType (%d) did not match authType (%d)", ErrInvalidSponsor, sponsorType, authType)
+	}
+	return auth, authWarp, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPreExecuteActivation(t *testing.T) {
+	const (
+		actionTypeID uint8 = 1
+		authTypeID   uint8 = 2
+
+		timestamp int64 = 10_000
+	)
+	tests := []struct {
+		name             string
+		actionActivation int64
+		authActivation   int64
+		err              error
+	}{
+		{
+			name:             "action before activation",
+			actionActivation: timestamp + 1,
+			authActivation:   -1,
+			err:              ErrActionNotActivated,
+		},
+		{
+			name:             "auth before activation",
+			actionActivation: -1,
+			authActivation:   timestamp + 1,
+			err:              ErrAuthNotActivated,
+		},
+		{
+			// The action is active at its activation time, so we fail on the auth
+			name:             "action at activation",
+			actionActivation: timestamp,
+			authActivation:   timestamp + 1,
+			err:              ErrAuthNotActivated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			chainID := ids.GenerateTestID()
+			rules := NewMockRules(ctrl)
+			rules.EXPECT().ChainID().Return(chainID).AnyTimes()
+			rules.EXPECT().GetValidityWindow().Return(int64(60_000)).AnyTimes()
+			rules.EXPECT().GetMaxActionsPerTx().Return(uint8(1)).AnyTimes()
+			rules.EXPECT().GetActionActivation(actionTypeID).Return(tt.actionActivation).AnyTimes()
+			rules.EXPECT().GetAuthActivation(authTypeID).Return(tt.authActivation).AnyTimes()
+
+			action := NewMockAction(ctrl)
+			action.EXPECT().GetTypeID().Return(actionTypeID).AnyTimes()
+			action.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+			auth := NewMockAuth(ctrl)
+			auth.EXPECT().GetTypeID().Return(authTypeID).AnyTimes()
+			auth.EXPECT().ValidRange(gomock.Any()).Return(int64(-1), int64(-1)).AnyTimes()
+
+			tx := &Transaction{
+				Base: &Base{
+					Timestamp: timestamp,
+					ChainID:   chainID,
+				},
+				Actions: []Action{action},
+				Auth:    auth,
+			}
+			_, err := tx.PreExecute(context.Background(), nil, nil, rules, nil, timestamp)
+			require.ErrorIs(err, tt.err)
+		})
+	}
+}
@@ -286,3 +286,56 @@ func (h *Handler) WatchChain(hideTxs bool, getParser func(string, uint32, ids.ID
 	}
 	return nil
 }
+
+// ReplayBlock replays the accepted block at [height] using the admin API of the
+// selected chain (authenticated with [adminToken]).
+func (h *Handler) ReplayBlock(height uint64, adminToken string) error {
+	_, uris, err := h.PromptChain("select chainID", nil)
+	if err != nil {
+		return err
+	}
+	cli := rpc.NewAdminJSONRPCClient(uris[0], adminToken)
+	result, err := cli.ReplayBlock(context.Background(), height)
+	if err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{yellow}}height:{{/}}%d {{yellow}}id:{{/}}%s {{yellow}}txs:{{/}}%d\n",
+		result.Height,
+		result.BlockID,
+		len(result.Txs),
+	)
+	for _, tx := range result.Txs {
+		status := "{{green}}match{{/}}"
+		switch {
+		case tx.Recorded == nil:
+			status = "{{yellow}}no receipt{{/}}"
+		case tx.Mismatch:
+			status = "{{red}}mismatch{{/}}"
+		}
+		utils.Outf(
+			"%s {{yellow}}success:{{/}}%t {{yellow}}fee:{{/}}%d {{yellow}}consumed:{{/}} [%s] {{yellow}}diffs:{{/}}%d %s\n",
+			tx.TxID,
+			tx.Result.Success,
+			tx.Result.Fee,
+			ParseDimensions(tx.Result.Consumed),
+			len(tx.Receipt.Diffs),
+			status,
+		)
+	}
+	for _, m := range result.Mismatches {
+		utils.Outf(
+			"{{red}}key:{{/}}%x {{red}}recorded:{{/}}%x {{red}}replayed:{{/}}%x {{red}}modified by:{{/}}%v\n",
+			m.Key,
+			m.Recorded,
+			m.Replayed,
+			m.TxIDs,
+		)
+	}
+	if result.Diverged() {
+		utils.Outf("{{red}}replay diverged from recorded execution{{/}}\n")
+		return nil
+	}
+	utils.Outf("{{green}}replay matched recorded execution{{/}}\n")
+	return nil
+}
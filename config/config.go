@@ -20,9 +20,13 @@ func (c *Config) GetLogLevel() logging.Level                { return logging.Inf
 func (c *Config) GetSignatureVerificationCores() int        { return 1 }
 func (c *Config) GetRootGenerationCores() int               { return 1 }
 func (c *Config) GetTransactionExecutionCores() int         { return 1 }
+func (c *Config) GetOptimisticExecution() bool              { return false }
 func (c *Config) GetMempoolSize() int                       { return 2_048 }
 func (c *Config) GetMempoolSponsorSize() int                { return 32 }
 func (c *Config) GetMempoolExemptSponsors() []codec.Address { return nil }
+func (c *Config) GetMempoolFeePriority() bool               { return false }
+func (c *Config) GetMempoolPersistFrequency() time.Duration { return 0 }  // persistence is disabled by default
+func (c *Config) GetMempoolAdminToken() string              { return "" } // admin API is disabled by default
 func (c *Config) GetStreamingBacklogSize() int              { return 1024 }
 func (c *Config) GetStateEvictionBatchSize() int            { return 4 * units.MiB }
 func (c *Config) GetIntermediateNodeCacheSize() int         { return 4 * units.GiB }
@@ -37,6 +41,7 @@ func (c *Config) GetAcceptedBlockWindowCache() int { return 128 }    // 256MB at
 func (c *Config) GetAcceptedBlockWindow() int      { return 50_000 } // ~3.5hr with 250ms block time (100GB at 2MB)
 func (c *Config) GetStateSyncMinBlocks() uint64    { return 768 }    // set to max int for archive nodes to ensure no skips
 func (c *Config) GetAcceptorSize() int             { return 64 }
+func (c *Config) GetReceiptWindow() int            { return 0 } // receipts are disabled by default
 
 func (c *Config) GetContinuousProfilerConfig() *profiler.Config {
 	return &profiler.Config{Enabled: false}
@@ -0,0 +1,90 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"github.com/ava-labs/avalanchego/utils/math"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+// AuthEngine batch verifies [Multisig] (it should be registered as the
+// vm.AuthEngine for the [Multisig] TypeID).
+type AuthEngine struct{}
+
+func (*AuthEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
+	batchSize := math.Max(count/cores, ed25519.MinBatchSize)
+	return &Batch{batchSize: batchSize}
+}
+
+func (*AuthEngine) Cache(chain.Auth) {}
+
+// Batch verifies all ed25519 signatures in a set of [Multisig] in batches.
+// secp256r1 signatures are verified individually.
+type Batch struct {
+	batchSize int
+
+	counter int
+	batch   *ed25519.Batch
+}
+
+func (b *Batch) Add(msg []byte, rauth chain.Auth) func() error {
+	auth := rauth.(*Multisig)
+	var (
+		jobs    []func() error
+		pending []Signature
+	)
+	for _, sig := range auth.Signatures {
+		k := auth.Keys[sig.Index]
+		if k.Type != ED25519Key {
+			pending = append(pending, sig)
+			continue
+		}
+		if b.batch == nil {
+			b.batch = ed25519.NewBatch()
+		}
+		b.batch.Add(msg, ed25519.PublicKey(k.Key), ed25519.Signature(sig.Signature))
+		b.counter++
+		if b.counter == b.batchSize {
+			// May finish parts of batch early
+			jobs = append(jobs, b.batch.VerifyAsync())
+			b.batch = nil
+			b.counter = 0
+		}
+	}
+	if len(pending) > 0 {
+		jobs = append(jobs, func() error {
+			for _, sig := range pending {
+				if !auth.Keys[sig.Index].verify(msg, sig.Signature) {
+					return crypto.ErrInvalidSignature
+				}
+			}
+			return nil
+		})
+	}
+	switch len(jobs) {
+	case 0:
+		return nil
+	case 1:
+		return jobs[0]
+	default:
+		return func() error {
+			for _, job := range jobs {
+				if err := job(); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+}
+
+func (b *Batch) Done() []func() error {
+	if b.batch == nil {
+		return nil
+	}
+	return []func() error{b.batch.VerifyAsync()}
+}
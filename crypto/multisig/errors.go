@@ -0,0 +1,17 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import "errors"
+
+var (
+	ErrInvalidThreshold       = errors.New("invalid threshold")
+	ErrTooManyKeys            = errors.New("too many keys")
+	ErrUnsortedKeys           = errors.New("keys not sorted and unique")
+	ErrInvalidKeyType         = errors.New("invalid key type")
+	ErrInvalidKeyIndex        = errors.New("invalid key index")
+	ErrUnsortedSignatures     = errors.New("signatures not sorted and unique")
+	ErrInsufficientSignatures = errors.New("insufficient signatures")
+	ErrUnknownKey             = errors.New("unknown key")
+)
@@ -0,0 +1,142 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+)
+
+var _ chain.AuthFactory = (*Factory)(nil)
+
+// Factory collects signatures from the keys that control a multisig account
+// and produces a [Multisig] once [threshold] of them have signed.
+//
+// Key holders can sign the digest of a [chain.Transaction] offline and provide
+// their signature with [AddSignature]. Alternatively, private keys can be added
+// to the [Factory] to sign when [Sign] is called.
+type Factory struct {
+	module    *Module
+	threshold uint8
+	keys      []PublicKey
+	addr      codec.Address
+
+	ed25519Keys   map[uint8]ed25519.PrivateKey
+	secp256r1Keys map[uint8]secp256r1.PrivateKey
+	signatures    map[uint8][]byte
+}
+
+func (m *Module) NewFactory(threshold uint8, keys []PublicKey) (*Factory, error) {
+	sorted := make([]PublicKey, len(keys))
+	copy(sorted, keys)
+	SortKeys(sorted)
+	addr, err := m.Address(threshold, sorted)
+	if err != nil {
+		return nil, err
+	}
+	return &Factory{
+		module:        m,
+		threshold:     threshold,
+		keys:          sorted,
+		addr:          addr,
+		ed25519Keys:   map[uint8]ed25519.PrivateKey{},
+		secp256r1Keys: map[uint8]secp256r1.PrivateKey{},
+		signatures:    map[uint8][]byte{},
+	}, nil
+}
+
+// Address is the address of the account controlled by the [Factory].
+func (f *Factory) Address() codec.Address {
+	return f.addr
+}
+
+func (f *Factory) index(key PublicKey) (uint8, error) {
+	for i, k := range f.keys {
+		if compareKeys(k, key) == 0 {
+			return uint8(i), nil
+		}
+	}
+	return 0, ErrUnknownKey
+}
+
+// AddED25519PrivateKey allows the [Factory] to sign with [priv].
+func (f *Factory) AddED25519PrivateKey(priv ed25519.PrivateKey) error {
+	idx, err := f.index(NewED25519PublicKey(priv.PublicKey()))
+	if err != nil {
+		return err
+	}
+	f.ed25519Keys[idx] = priv
+	return nil
+}
+
+// AddSECP256R1PrivateKey allows the [Factory] to sign with [priv].
+func (f *Factory) AddSECP256R1PrivateKey(priv secp256r1.PrivateKey) error {
+	idx, err := f.index(NewSECP256R1PublicKey(priv.PublicKey()))
+	if err != nil {
+		return err
+	}
+	f.secp256r1Keys[idx] = priv
+	return nil
+}
+
+// AddSignature adds a signature produced by [key] offline. The signature must
+// be over the digest that will later be passed to [Sign].
+func (f *Factory) AddSignature(key PublicKey, sig []byte) error {
+	idx, err := f.index(key)
+	if err != nil {
+		return err
+	}
+	if len(sig) != signatureLen(key.Type) {
+		return crypto.ErrInvalidSignature
+	}
+	f.signatures[idx] = sig
+	return nil
+}
+
+// Sign produces a [Multisig] using the first [threshold] keys (in canonical
+// order) that have a valid signature for [msg].
+//
+// Signatures added with [AddSignature] that are not valid for [msg] are ignored.
+func (f *Factory) Sign(msg []byte, _ []chain.Action) (chain.Auth, error) {
+	sigs := make([]Signature, 0, f.threshold)
+	for i, k := range f.keys {
+		if len(sigs) == int(f.threshold) {
+			break
+		}
+		idx := uint8(i)
+		if sig, ok := f.signatures[idx]; ok && k.verify(msg, sig) {
+			sigs = append(sigs, Signature{idx, sig})
+			continue
+		}
+		if priv, ok := f.ed25519Keys[idx]; ok {
+			sig := ed25519.Sign(msg, priv)
+			sigs = append(sigs, Signature{idx, sig[:]})
+			continue
+		}
+		if priv, ok := f.secp256r1Keys[idx]; ok {
+			sig, err := secp256r1.Sign(msg, priv)
+			if err != nil {
+				return nil, err
+			}
+			sigs = append(sigs, Signature{idx, sig[:]})
+		}
+	}
+	if len(sigs) < int(f.threshold) {
+		return nil, ErrInsufficientSignatures
+	}
+	return &Multisig{
+		Threshold:  f.threshold,
+		Keys:       f.keys,
+		Signatures: sigs,
+		module:     f.module,
+		addr:       f.addr,
+	}, nil
+}
+
+func (f *Factory) MaxUnits() (uint64, uint64, []uint16) {
+	return f.module.MaxUnits(f.threshold, f.keys)
+}
@@ -0,0 +1,386 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Auth = (*Multisig)(nil)
+
+// Supported key types
+const (
+	ED25519Key   uint8 = 0
+	SECP256R1Key uint8 = 1
+)
+
+const (
+	// MaxKeys is the maximum number of keys that can control a single
+	// account. This bounds the cost of verifying a [Multisig].
+	MaxKeys = 16
+
+	BaseComputeUnits      = 1
+	ED25519ComputeUnits   = 5
+	SECP256R1ComputeUnits = 10 // can't be batched like ed25519
+)
+
+// BalanceHandler is implemented by each VM that registers [Multisig] to pay
+// fees from the balance of a multisig account.
+type BalanceHandler interface {
+	// StateKeys are the keys touched by [CanDeduct], [Deduct], and [Refund].
+	StateKeys(addr codec.Address) []string
+	// StateKeysMaxChunks are the max chunks of each key in [StateKeys].
+	StateKeysMaxChunks() []uint16
+
+	CanDeduct(ctx context.Context, addr codec.Address, im state.Immutable, amount uint64) error
+	Deduct(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+	Refund(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+}
+
+// PublicKey is a single key that can sign on behalf of a multisig account.
+type PublicKey struct {
+	Type uint8  `json:"type"`
+	Key  []byte `json:"key"`
+}
+
+func NewED25519PublicKey(pk ed25519.PublicKey) PublicKey {
+	return PublicKey{Type: ED25519Key, Key: pk[:]}
+}
+
+func NewSECP256R1PublicKey(pk secp256r1.PublicKey) PublicKey {
+	return PublicKey{Type: SECP256R1Key, Key: pk[:]}
+}
+
+func (k PublicKey) size() int {
+	return consts.ByteLen + keyLen(k.Type)
+}
+
+func (k PublicKey) verify(msg []byte, sig []byte) bool {
+	switch k.Type {
+	case ED25519Key:
+		return ed25519.Verify(msg, ed25519.PublicKey(k.Key), ed25519.Signature(sig))
+	case SECP256R1Key:
+		return secp256r1.Verify(msg, secp256r1.PublicKey(k.Key), secp256r1.Signature(sig))
+	default:
+		return false
+	}
+}
+
+func keyLen(keyType uint8) int {
+	switch keyType {
+	case ED25519Key:
+		return ed25519.PublicKeyLen
+	case SECP256R1Key:
+		return secp256r1.PublicKeyLen
+	default:
+		return 0
+	}
+}
+
+func signatureLen(keyType uint8) int {
+	switch keyType {
+	case ED25519Key:
+		return ed25519.SignatureLen
+	case SECP256R1Key:
+		return secp256r1.SignatureLen
+	default:
+		return 0
+	}
+}
+
+func keyComputeUnits(keyType uint8) uint64 {
+	if keyType == SECP256R1Key {
+		return SECP256R1ComputeUnits
+	}
+	return ED25519ComputeUnits
+}
+
+// SortKeys sorts [keys] in the canonical order expected by [Multisig].
+func SortKeys(keys []PublicKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		return compareKeys(keys[i], keys[j]) < 0
+	})
+}
+
+func compareKeys(a, b PublicKey) int {
+	if a.Type != b.Type {
+		if a.Type < b.Type {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(a.Key, b.Key)
+}
+
+func verifyKeys(threshold uint8, keys []PublicKey) error {
+	if len(keys) > MaxKeys {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyKeys, len(keys), MaxKeys)
+	}
+	if threshold == 0 || int(threshold) > len(keys) {
+		return fmt.Errorf("%w: %d of %d", ErrInvalidThreshold, threshold, len(keys))
+	}
+	for i, k := range keys {
+		if len(k.Key) == 0 || len(k.Key) != keyLen(k.Type) {
+			return fmt.Errorf("%w: %d", ErrInvalidKeyType, k.Type)
+		}
+		if i > 0 && compareKeys(keys[i-1], k) >= 0 {
+			return ErrUnsortedKeys
+		}
+	}
+	return nil
+}
+
+func marshalKeys(p *codec.Packer, threshold uint8, keys []PublicKey) {
+	p.PackByte(threshold)
+	p.PackByte(uint8(len(keys)))
+	for _, k := range keys {
+		p.PackByte(k.Type)
+		p.PackFixedBytes(k.Key)
+	}
+}
+
+func keysSize(keys []PublicKey) int {
+	size := consts.ByteLen * 2 // threshold + number of keys
+	for _, k := range keys {
+		size += k.size()
+	}
+	return size
+}
+
+// NewAddress deterministically derives the address of the account controlled
+// by [threshold] of [keys] (regardless of the order of [keys]).
+func NewAddress(typeID uint8, threshold uint8, keys []PublicKey) (codec.Address, error) {
+	sorted := make([]PublicKey, len(keys))
+	copy(sorted, keys)
+	SortKeys(sorted)
+	if err := verifyKeys(threshold, sorted); err != nil {
+		return codec.EmptyAddress, err
+	}
+	p := codec.NewWriter(keysSize(sorted), consts.MaxInt)
+	marshalKeys(p, threshold, sorted)
+	if err := p.Err(); err != nil {
+		return codec.EmptyAddress, err
+	}
+	return codec.CreateAddress(typeID, utils.ToID(p.Bytes())), nil
+}
+
+// Signature is a signature produced by the key at [Index] of [Multisig.Keys].
+type Signature struct {
+	Index     uint8  `json:"index"`
+	Signature []byte `json:"signature"`
+}
+
+// Multisig is a [chain.Auth] that is valid if it includes [Threshold] valid signatures
+// from the keys in [Keys]. [Keys] may contain any mix of supported key types.
+type Multisig struct {
+	Threshold  uint8       `json:"threshold"`
+	Keys       []PublicKey `json:"keys"`
+	Signatures []Signature `json:"signatures"`
+
+	module *Module
+	addr   codec.Address
+}
+
+func (m *Multisig) address() codec.Address {
+	if m.addr == codec.EmptyAddress {
+		// [Keys] are verified to be sorted during unmarshal
+		p := codec.NewWriter(keysSize(m.Keys), consts.MaxInt)
+		marshalKeys(p, m.Threshold, m.Keys)
+		m.addr = codec.CreateAddress(m.module.typeID, utils.ToID(p.Bytes()))
+	}
+	return m.addr
+}
+
+func (m *Multisig) GetTypeID() uint8 {
+	return m.module.typeID
+}
+
+func (m *Multisig) MaxComputeUnits(chain.Rules) uint64 {
+	units := uint64(BaseComputeUnits)
+	for _, sig := range m.Signatures {
+		units += keyComputeUnits(m.Keys[sig.Index].Type)
+	}
+	return units
+}
+
+func (*Multisig) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (m *Multisig) StateKeys() []string {
+	return m.module.handler.StateKeys(m.address())
+}
+
+func (m *Multisig) AsyncVerify(msg []byte) error {
+	for _, sig := range m.Signatures {
+		if !m.Keys[sig.Index].verify(msg, sig.Signature) {
+			return crypto.ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func (m *Multisig) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ state.Immutable,
+	_ []chain.Action,
+) (uint64, error) {
+	// The key set is committed to in the address, so there is no additional
+	// state to check to authorize the signers.
+	return m.MaxComputeUnits(r), nil
+}
+
+func (m *Multisig) Actor() codec.Address {
+	return m.address()
+}
+
+func (m *Multisig) Sponsor() codec.Address {
+	return m.address()
+}
+
+func (m *Multisig) Size() int {
+	size := keysSize(m.Keys)
+	for _, sig := range m.Signatures {
+		size += consts.ByteLen + len(sig.Signature)
+	}
+	return size
+}
+
+func (m *Multisig) Marshal(p *codec.Packer) {
+	marshalKeys(p, m.Threshold, m.Keys)
+	for _, sig := range m.Signatures {
+		p.PackByte(sig.Index)
+		p.PackFixedBytes(sig.Signature)
+	}
+}
+
+func (m *Multisig) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	return m.module.handler.CanDeduct(ctx, m.address(), im, amount)
+}
+
+func (m *Multisig) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return m.module.handler.Deduct(ctx, m.address(), mu, amount)
+}
+
+func (m *Multisig) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return m.module.handler.Refund(ctx, m.address(), mu, amount)
+}
+
+// Module binds [Multisig] to the type ID and [BalanceHandler] used by a
+// specific VM.
+type Module struct {
+	typeID  uint8
+	handler BalanceHandler
+}
+
+func NewModule(typeID uint8, handler BalanceHandler) *Module {
+	return &Module{typeID, handler}
+}
+
+func (m *Module) TypeID() uint8 {
+	return m.typeID
+}
+
+// Address returns the address of the account controlled by [threshold] of [keys].
+func (m *Module) Address(threshold uint8, keys []PublicKey) (codec.Address, error) {
+	return NewAddress(m.typeID, threshold, keys)
+}
+
+// Unmarshal should be registered in the [chain.AuthRegistry] of a VM.
+func (m *Module) Unmarshal(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	ms := Multisig{module: m}
+	ms.Threshold = p.UnpackByte()
+	numKeys := p.UnpackByte()
+	if int(numKeys) > MaxKeys {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyKeys, numKeys, MaxKeys)
+	}
+	ms.Keys = make([]PublicKey, numKeys)
+	for i := range ms.Keys {
+		keyType := p.UnpackByte()
+		l := keyLen(keyType)
+		if l == 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidKeyType, keyType)
+		}
+		ms.Keys[i].Type = keyType
+		ms.Keys[i].Key = make([]byte, l)
+		p.UnpackFixedBytes(l, &ms.Keys[i].Key)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if err := verifyKeys(ms.Threshold, ms.Keys); err != nil {
+		return nil, err
+	}
+
+	// Exactly [Threshold] signatures must be provided (this bounds the compute
+	// used by [AsyncVerify] to what is paid for in [MaxComputeUnits]).
+	ms.Signatures = make([]Signature, ms.Threshold)
+	for i := range ms.Signatures {
+		idx := p.UnpackByte()
+		if int(idx) >= len(ms.Keys) {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidKeyIndex, idx)
+		}
+		if i > 0 && ms.Signatures[i-1].Index >= idx {
+			return nil, ErrUnsortedSignatures
+		}
+		l := signatureLen(ms.Keys[idx].Type)
+		ms.Signatures[i].Index = idx
+		ms.Signatures[i].Signature = make([]byte, l)
+		p.UnpackFixedBytes(l, &ms.Signatures[i].Signature)
+	}
+	return &ms, p.Err()
+}
+
+// MaxUnits returns the max units a [Multisig] controlled by [threshold] of [keys]
+// could use.
+func (m *Module) MaxUnits(threshold uint8, keys []PublicKey) (uint64, uint64, []uint16) {
+	// Pessimistically assume the most expensive keys will sign
+	signers := make([]PublicKey, len(keys))
+	copy(signers, keys)
+	sort.SliceStable(signers, func(i, j int) bool {
+		return keyComputeUnits(signers[i].Type) > keyComputeUnits(signers[j].Type)
+	})
+	if int(threshold) < len(signers) {
+		signers = signers[:threshold]
+	}
+	bandwidth := keysSize(keys)
+	compute := uint64(BaseComputeUnits)
+	for _, k := range signers {
+		bandwidth += consts.ByteLen + signatureLen(k.Type)
+		compute += keyComputeUnits(k.Type)
+	}
+	return uint64(bandwidth), compute, m.handler.StateKeysMaxChunks()
+}
+
+// Engine returns the [AuthEngine] used to batch verify [Multisig].
+func (*Module) Engine() *AuthEngine {
+	return &AuthEngine{}
+}
@@ -0,0 +1,302 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const testTypeID uint8 = 5
+
+var _ BalanceHandler = (*testHandler)(nil)
+
+type testHandler struct{}
+
+func (*testHandler) StateKeys(addr codec.Address) []string {
+	return []string{string(addr[:])}
+}
+
+func (*testHandler) StateKeysMaxChunks() []uint16 {
+	return []uint16{1}
+}
+
+func (*testHandler) CanDeduct(context.Context, codec.Address, state.Immutable, uint64) error {
+	return nil
+}
+
+func (*testHandler) Deduct(context.Context, codec.Address, state.Mutable, uint64) error {
+	return nil
+}
+
+func (*testHandler) Refund(context.Context, codec.Address, state.Mutable, uint64) error {
+	return nil
+}
+
+type testKeys struct {
+	ed25519   []ed25519.PrivateKey
+	secp256r1 []secp256r1.PrivateKey
+	public    []PublicKey
+}
+
+func generateKeys(t *testing.T, numED25519 int, numSECP256R1 int) *testKeys {
+	require := require.New(t)
+	keys := &testKeys{}
+	for i := 0; i < numED25519; i++ {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		keys.ed25519 = append(keys.ed25519, priv)
+		keys.public = append(keys.public, NewED25519PublicKey(priv.PublicKey()))
+	}
+	for i := 0; i < numSECP256R1; i++ {
+		priv, err := secp256r1.GeneratePrivateKey()
+		require.NoError(err)
+		keys.secp256r1 = append(keys.secp256r1, priv)
+		keys.public = append(keys.public, NewSECP256R1PublicKey(priv.PublicKey()))
+	}
+	return keys
+}
+
+func marshal(t *testing.T, auth *Multisig) []byte {
+	p := codec.NewWriter(auth.Size(), consts.NetworkSizeLimit)
+	auth.Marshal(p)
+	require.NoError(t, p.Err())
+	return p.Bytes()
+}
+
+func TestAddressDeterministic(t *testing.T) {
+	require := require.New(t)
+	keys := generateKeys(t, 2, 2)
+
+	addr, err := NewAddress(testTypeID, 2, keys.public)
+	require.NoError(err)
+	require.Equal(testTypeID, addr[0])
+
+	// Order of keys should not matter
+	reversed := make([]PublicKey, len(keys.public))
+	for i, k := range keys.public {
+		reversed[len(keys.public)-1-i] = k
+	}
+	addr2, err := NewAddress(testTypeID, 2, reversed)
+	require.NoError(err)
+	require.Equal(addr, addr2)
+
+	// Threshold should matter
+	addr3, err := NewAddress(testTypeID, 3, keys.public)
+	require.NoError(err)
+	require.NotEqual(addr, addr3)
+
+	// Keys should matter
+	addr4, err := NewAddress(testTypeID, 2, keys.public[1:])
+	require.NoError(err)
+	require.NotEqual(addr, addr4)
+}
+
+func TestAddressInvalid(t *testing.T) {
+	require := require.New(t)
+	keys := generateKeys(t, 2, 1)
+
+	_, err := NewAddress(testTypeID, 0, keys.public)
+	require.ErrorIs(err, ErrInvalidThreshold)
+	_, err = NewAddress(testTypeID, 4, keys.public)
+	require.ErrorIs(err, ErrInvalidThreshold)
+	_, err = NewAddress(testTypeID, 1, append(keys.public, keys.public[0]))
+	require.ErrorIs(err, ErrUnsortedKeys)
+	_, err = NewAddress(testTypeID, 1, []PublicKey{{Type: 10, Key: []byte{1}}})
+	require.ErrorIs(err, ErrInvalidKeyType)
+	_, err = NewAddress(testTypeID, 1, generateKeys(t, MaxKeys+1, 0).public)
+	require.ErrorIs(err, ErrTooManyKeys)
+}
+
+func TestSignMixedKeys(t *testing.T) {
+	require := require.New(t)
+	module := NewModule(testTypeID, &testHandler{})
+	keys := generateKeys(t, 2, 2)
+	msg := []byte("hello")
+
+	factory, err := module.NewFactory(3, keys.public)
+	require.NoError(err)
+	require.NoError(factory.AddED25519PrivateKey(keys.ed25519[0]))
+	require.NoError(factory.AddSECP256R1PrivateKey(keys.secp256r1[1]))
+
+	// Not enough signers
+	_, err = factory.Sign(msg, nil)
+	require.ErrorIs(err, ErrInsufficientSignatures)
+
+	require.NoError(factory.AddSECP256R1PrivateKey(keys.secp256r1[0]))
+	rauth, err := factory.Sign(msg, nil)
+	require.NoError(err)
+	auth := rauth.(*Multisig)
+	require.Len(auth.Signatures, 3)
+	require.NoError(auth.AsyncVerify(msg))
+	require.ErrorIs(auth.AsyncVerify([]byte("world")), crypto.ErrInvalidSignature)
+	require.Equal(factory.Address(), auth.Actor())
+	require.Equal(factory.Address(), auth.Sponsor())
+	require.Equal(testTypeID, auth.GetTypeID())
+
+	// Ensure auth is the same after serialization
+	b := marshal(t, auth)
+	require.Len(b, auth.Size())
+	unmarshaled, err := module.Unmarshal(codec.NewReader(b, len(b)), nil)
+	require.NoError(err)
+	require.NoError(unmarshaled.AsyncVerify(msg))
+	require.Equal(auth.Actor(), unmarshaled.Actor())
+	require.Equal(auth.MaxComputeUnits(nil), unmarshaled.MaxComputeUnits(nil))
+	require.Equal(uint64(BaseComputeUnits+ED25519ComputeUnits+2*SECP256R1ComputeUnits), auth.MaxComputeUnits(nil))
+
+	// Estimates should be pessimistic
+	bandwidth, compute, stateKeys := factory.MaxUnits()
+	require.GreaterOrEqual(bandwidth, uint64(auth.Size()))
+	require.GreaterOrEqual(compute, auth.MaxComputeUnits(nil))
+	require.Len(stateKeys, len(auth.StateKeys()))
+}
+
+func TestSignOffline(t *testing.T) {
+	require := require.New(t)
+	module := NewModule(testTypeID, &testHandler{})
+	keys := generateKeys(t, 2, 1)
+	msg := []byte("hello")
+
+	factory, err := module.NewFactory(2, keys.public)
+	require.NoError(err)
+
+	// Signatures collected from each key holder
+	sig := ed25519.Sign(msg, keys.ed25519[1])
+	require.NoError(factory.AddSignature(keys.public[1], sig[:]))
+	staleSig := ed25519.Sign([]byte("stale"), keys.ed25519[0])
+	require.NoError(factory.AddSignature(keys.public[0], staleSig[:]))
+	require.ErrorIs(factory.AddSignature(keys.public[2], sig[:33]), crypto.ErrInvalidSignature)
+	other := generateKeys(t, 1, 0)
+	require.ErrorIs(factory.AddSignature(other.public[0], sig[:]), ErrUnknownKey)
+
+	// Stale signature should be ignored
+	_, err = factory.Sign(msg, nil)
+	require.ErrorIs(err, ErrInsufficientSignatures)
+
+	secpSig, err := secp256r1.Sign(msg, keys.secp256r1[0])
+	require.NoError(err)
+	require.NoError(factory.AddSignature(keys.public[2], secpSig[:]))
+	auth, err := factory.Sign(msg, nil)
+	require.NoError(err)
+	require.NoError(auth.AsyncVerify(msg))
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	require := require.New(t)
+	module := NewModule(testTypeID, &testHandler{})
+	keys := generateKeys(t, 3, 0)
+	msg := []byte("hello")
+
+	factory, err := module.NewFactory(2, keys.public)
+	require.NoError(err)
+	for _, priv := range keys.ed25519 {
+		require.NoError(factory.AddED25519PrivateKey(priv))
+	}
+	rauth, err := factory.Sign(msg, nil)
+	require.NoError(err)
+	auth := rauth.(*Multisig)
+
+	tests := []struct {
+		name   string
+		modify func(*Multisig) *Multisig
+		err    error
+	}{
+		{
+			name: "duplicate signature",
+			modify: func(m *Multisig) *Multisig {
+				return &Multisig{m.Threshold, m.Keys, []Signature{m.Signatures[0], m.Signatures[0]}, m.module, codec.EmptyAddress}
+			},
+			err: ErrUnsortedSignatures,
+		},
+		{
+			name: "unsorted keys",
+			modify: func(m *Multisig) *Multisig {
+				keys := []PublicKey{m.Keys[1], m.Keys[0], m.Keys[2]}
+				return &Multisig{m.Threshold, keys, m.Signatures, m.module, codec.EmptyAddress}
+			},
+			err: ErrUnsortedKeys,
+		},
+		{
+			name: "invalid key index",
+			modify: func(m *Multisig) *Multisig {
+				sigs := []Signature{m.Signatures[0], {Index: 3, Signature: m.Signatures[1].Signature}}
+				return &Multisig{m.Threshold, m.Keys, sigs, m.module, codec.EmptyAddress}
+			},
+			err: ErrInvalidKeyIndex,
+		},
+		{
+			name: "zero threshold",
+			modify: func(m *Multisig) *Multisig {
+				return &Multisig{0, m.Keys, nil, m.module, codec.EmptyAddress}
+			},
+			err: ErrInvalidThreshold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := marshal(t, tt.modify(auth))
+			_, err := module.Unmarshal(codec.NewReader(b, len(b)), nil)
+			require.ErrorIs(err, tt.err)
+		})
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	require := require.New(t)
+	module := NewModule(testTypeID, &testHandler{})
+	msg := []byte("hello")
+
+	var auths []*Multisig
+	for i := 0; i < 5; i++ {
+		keys := generateKeys(t, 2, 1)
+		factory, err := module.NewFactory(3, keys.public)
+		require.NoError(err)
+		for _, priv := range keys.ed25519 {
+			require.NoError(factory.AddED25519PrivateKey(priv))
+		}
+		require.NoError(factory.AddSECP256R1PrivateKey(keys.secp256r1[0]))
+		auth, err := factory.Sign(msg, nil)
+		require.NoError(err)
+		auths = append(auths, auth.(*Multisig))
+	}
+
+	verify := func(auths []*Multisig) error {
+		bv := module.Engine().GetBatchVerifier(2, len(auths))
+		jobs := []func() error{}
+		for _, auth := range auths {
+			if job := bv.Add(msg, auth); job != nil {
+				jobs = append(jobs, job)
+			}
+		}
+		jobs = append(jobs, bv.Done()...)
+		for _, job := range jobs {
+			if err := job(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	require.NoError(verify(auths))
+
+	// Invalidate a single ed25519 signature
+	sig := auths[3].Signatures[0].Signature
+	sig[0]++
+	require.ErrorIs(verify(auths), crypto.ErrInvalidSignature)
+	sig[0]--
+	require.NoError(verify(auths))
+
+	// Invalidate a single secp256r1 signature
+	sig = auths[4].Signatures[2].Signature
+	sig[0]++
+	require.ErrorIs(verify(auths), crypto.ErrInvalidSignature)
+}
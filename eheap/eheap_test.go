@@ -191,6 +191,19 @@ func TestHas(t *testing.T) {
 	require.True(eheap.Has(item.ID()), "Did not find item.")
 }
 
+func TestGet(t *testing.T) {
+	require := require.New(t)
+
+	eheap := New[*TestItem](0)
+	item := GenerateTestItem(testSponsor, 1)
+	_, ok := eheap.Get(item.ID())
+	require.False(ok, "Found an item that was not added.")
+	eheap.Add(item)
+	got, ok := eheap.Get(item.ID())
+	require.True(ok, "Did not find item.")
+	require.Equal(item, got, "Get value is incorrect")
+}
+
 func TestLen(t *testing.T) {
 	require := require.New(t)
 
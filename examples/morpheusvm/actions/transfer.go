@@ -31,10 +31,10 @@ func (*Transfer) GetTypeID() uint8 {
 	return mconsts.TransferID
 }
 
-func (t *Transfer) StateKeys(auth chain.Auth, _ ids.ID) []string {
-	return []string{
-		string(storage.BalanceKey(auth.Actor())),
-		string(storage.BalanceKey(t.To)),
+func (t *Transfer) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(auth.Actor())): state.All,
+		string(storage.BalanceKey(t.To)):         state.All,
 	}
 }
 
@@ -67,7 +67,7 @@ func (d *SECP256R1) Verify(
 	_ context.Context,
 	r chain.Rules,
 	_ state.Immutable,
-	_ chain.Action,
+	_ []chain.Action,
 ) (uint64, error) {
 	// We don't do anything during verify (there is no additional state to check
 	// to authorize the signer other than verifying the signature)
@@ -142,7 +142,7 @@ func NewSECP256R1Factory(priv secp256r1.PrivateKey) *SECP256R1Factory {
 	return &SECP256R1Factory{priv}
 }
 
-func (d *SECP256R1Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+func (d *SECP256R1Factory) Sign(msg []byte, _ []chain.Action) (chain.Auth, error) {
 	sig, err := secp256r1.Sign(msg, d.priv)
 	if err != nil {
 		return nil, err
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
@@ -61,6 +62,23 @@ var chainInfoCmd = &cobra.Command{
 	},
 }
 
+var replayChainCmd = &cobra.Command{
+	Use: "replay [height]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		height, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		return handler.Root().ReplayBlock(height, adminToken)
+	},
+}
+
 var watchChainCmd = &cobra.Command{
 	Use: "watch",
 	RunE: func(_ *cobra.Command, args []string) error {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
@@ -29,7 +30,7 @@ func sendAndWait(
 	if err != nil {
 		return false, ids.Empty, err
 	}
-	_, tx, _, err := cli.GenerateTransaction(ctx, parser, warpMsg, action, factory)
+	_, tx, _, err := cli.GenerateTransaction(ctx, parser, warpMsg, []chain.Action{action}, factory)
 	if err != nil {
 		return false, ids.Empty, err
 	}
@@ -58,14 +59,24 @@ func sendAndWait(
 }
 
 func handleTx(tx *chain.Transaction, result *chain.Result) {
-	summaryStr := string(result.Output)
 	actor := tx.Auth.Actor()
 	status := "❌"
-	if result.Success {
+	summaries := make([]string, 0, len(result.Outputs))
+	actionTypes := make([]string, 0, len(tx.Actions))
+	for _, act := range tx.Actions {
+		actionTypes = append(actionTypes, reflect.TypeOf(act).String())
+	}
+	if !result.Success {
+		summaries = append(summaries, string(result.Outputs[len(result.Outputs)-1]))
+	} else {
 		status = "✅"
-		switch action := tx.Action.(type) { //nolint:gocritic
-		case *actions.Transfer:
-			summaryStr = fmt.Sprintf("%s %s -> %s", utils.FormatBalance(action.Value, consts.Decimals), consts.Symbol, codec.MustAddressBech32(consts.HRP, action.To))
+		for _, act := range tx.Actions {
+			var summaryStr string
+			switch action := act.(type) { //nolint:gocritic
+			case *actions.Transfer:
+				summaryStr = fmt.Sprintf("%s %s -> %s", utils.FormatBalance(action.Value, consts.Decimals), consts.Symbol, codec.MustAddressBech32(consts.HRP, action.To))
+			}
+			summaries = append(summaries, summaryStr)
 		}
 	}
 	utils.Outf(
@@ -73,8 +84,8 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 		status,
 		tx.ID(),
 		codec.MustAddressBech32(consts.HRP, actor),
-		reflect.TypeOf(tx.Action),
-		summaryStr,
+		strings.Join(actionTypes, ", "),
+		strings.Join(summaries, " | "),
 		float64(result.Fee)/float64(tx.Base.MaxFee)*100,
 		utils.FormatBalance(result.Fee, consts.Decimals),
 		consts.Symbol,
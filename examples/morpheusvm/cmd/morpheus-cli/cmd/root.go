@@ -28,6 +28,7 @@ var (
 	windowTargetUnits     []string
 	minBlockGap           int64
 	hideTxs               bool
+	adminToken            string
 	randomRecipient       bool
 	maxTxBacklog          int
 	checkAllChains        bool
@@ -132,6 +133,12 @@ func init() {
 		false,
 		"hide txs",
 	)
+	replayChainCmd.PersistentFlags().StringVar(
+		&adminToken,
+		"admin-token",
+		"",
+		"token of the admin API",
+	)
 	chainCmd.AddCommand(
 		importChainCmd,
 		importANRChainCmd,
@@ -139,6 +146,7 @@ func init() {
 		setChainCmd,
 		chainInfoCmd,
 		watchChainCmd,
+		replayChainCmd,
 	)
 
 	// actions
@@ -33,9 +33,10 @@ type Config struct {
 	*config.Config
 
 	// Concurrency
-	SignatureVerificationCores int `json:"signatureVerificationCores"`
-	RootGenerationCores        int `json:"rootGenerationCores"`
-	TransactionExecutionCores  int `json:"transactionExecutionCores"`
+	SignatureVerificationCores int  `json:"signatureVerificationCores"`
+	RootGenerationCores        int  `json:"rootGenerationCores"`
+	TransactionExecutionCores  int  `json:"transactionExecutionCores"`
+	OptimisticExecution        bool `json:"optimisticExecution"`
 
 	// Tracing
 	TraceEnabled    bool    `json:"traceEnabled"`
@@ -48,13 +49,17 @@ type Config struct {
 	StreamingBacklogSize int `json:"streamingBacklogSize"`
 
 	// Mempool
-	MempoolSize           int      `json:"mempoolSize"`
-	MempoolSponsorSize    int      `json:"mempoolSponsorSize"`
-	MempoolExemptSponsors []string `json:"mempoolExemptSponsors"`
+	MempoolSize             int           `json:"mempoolSize"`
+	MempoolSponsorSize      int           `json:"mempoolSponsorSize"`
+	MempoolExemptSponsors   []string      `json:"mempoolExemptSponsors"`
+	MempoolFeePriority      bool          `json:"mempoolFeePriority"`
+	MempoolPersistFrequency time.Duration `json:"mempoolPersistFrequency"`
+	MempoolAdminToken       string        `json:"mempoolAdminToken"` // empty disables the admin API
 
 	// Misc
 	VerifySignatures  bool          `json:"verifySignatures"`
 	StoreTransactions bool          `json:"storeTransactions"`
+	ReceiptWindow     int           `json:"receiptWindow"`
 	TestMode          bool          `json:"testMode"` // makes gossip/building manual
 	LogLevel          logging.Level `json:"logLevel"`
 
@@ -94,12 +99,16 @@ func (c *Config) setDefault() {
 	c.SignatureVerificationCores = c.Config.GetSignatureVerificationCores()
 	c.RootGenerationCores = c.Config.GetRootGenerationCores()
 	c.TransactionExecutionCores = c.Config.GetTransactionExecutionCores()
+	c.OptimisticExecution = c.Config.GetOptimisticExecution()
 	c.MempoolSize = c.Config.GetMempoolSize()
 	c.MempoolSponsorSize = c.Config.GetMempoolSponsorSize()
+	c.MempoolFeePriority = c.Config.GetMempoolFeePriority()
+	c.MempoolPersistFrequency = c.Config.GetMempoolPersistFrequency()
 	c.StateSyncServerDelay = c.Config.GetStateSyncServerDelay()
 	c.StreamingBacklogSize = c.Config.GetStreamingBacklogSize()
 	c.VerifySignatures = c.Config.GetVerifySignatures()
 	c.StoreTransactions = defaultStoreTransactions
+	c.ReceiptWindow = c.Config.GetReceiptWindow()
 }
 
 func (c *Config) GetLogLevel() logging.Level                { return c.LogLevel }
@@ -107,9 +116,13 @@ func (c *Config) GetTestMode() bool                         { return c.TestMode
 func (c *Config) GetSignatureVerificationCores() int        { return c.SignatureVerificationCores }
 func (c *Config) GetRootGenerationCores() int               { return c.RootGenerationCores }
 func (c *Config) GetTransactionExecutionCores() int         { return c.TransactionExecutionCores }
+func (c *Config) GetOptimisticExecution() bool              { return c.OptimisticExecution }
 func (c *Config) GetMempoolSize() int                       { return c.MempoolSize }
 func (c *Config) GetMempoolSponsorSize() int                { return c.MempoolSponsorSize }
 func (c *Config) GetMempoolExemptSponsors() []codec.Address { return c.parsedExemptSponsors }
+func (c *Config) GetMempoolFeePriority() bool               { return c.MempoolFeePriority }
+func (c *Config) GetMempoolPersistFrequency() time.Duration { return c.MempoolPersistFrequency }
+func (c *Config) GetMempoolAdminToken() string              { return c.MempoolAdminToken }
 func (c *Config) GetTraceConfig() *trace.Config {
 	return &trace.Config{
 		Enabled:         c.TraceEnabled,
@@ -137,4 +150,5 @@ func (c *Config) GetContinuousProfilerConfig() *profiler.Config {
 }
 func (c *Config) GetVerifySignatures() bool  { return c.VerifySignatures }
 func (c *Config) GetStoreTransactions() bool { return c.StoreTransactions }
+func (c *Config) GetReceiptWindow() int      { return c.ReceiptWindow }
 func (c *Config) Loaded() bool               { return c.loaded }
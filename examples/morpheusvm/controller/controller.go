@@ -34,10 +34,12 @@ var _ vm.Controller = (*Controller)(nil)
 type Controller struct {
 	inner *vm.VM
 
-	snowCtx      *snow.Context
-	genesis      *genesis.Genesis
-	config       *config.Config
-	stateManager *storage.StateManager
+	snowCtx        *snow.Context
+	genesis        *genesis.Genesis
+	config         *config.Config
+	stateManager   *storage.StateManager
+	feeDistributor *storage.FeeDistributor
+	buildPolicy    *chain.DefaultBlockBuildPolicy
 
 	metrics *metrics
 
@@ -70,7 +72,6 @@ func (c *Controller) Initialize(
 ) {
 	c.inner = inner
 	c.snowCtx = snowCtx
-	c.stateManager = &storage.StateManager{}
 
 	// Instantiate metrics
 	var err error
@@ -95,6 +96,16 @@ func (c *Controller) Initialize(
 		)
 	}
 	snowCtx.Log.Info("loaded genesis", zap.Any("genesis", c.genesis))
+	priorityFeeRecipient, err := c.genesis.GetPriorityFeeRecipient()
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf(
+			"invalid priority fee recipient: %w",
+			err,
+		)
+	}
+	c.stateManager = &storage.StateManager{PriorityFeeRecipient: priorityFeeRecipient}
+	c.feeDistributor = &storage.FeeDistributor{}
+	c.buildPolicy = &chain.DefaultBlockBuildPolicy{}
 
 	// Create DBs
 	blockDB, stateDB, metaDB, err := hstorage.New(snowCtx.ChainDataDir, gatherer)
@@ -146,6 +157,14 @@ func (c *Controller) StateManager() chain.StateManager {
 	return c.stateManager
 }
 
+func (c *Controller) FeeDistributor() chain.FeeDistributor {
+	return c.feeDistributor
+}
+
+func (c *Controller) BlockBuildPolicy() chain.BlockBuildPolicy {
+	return c.buildPolicy
+}
+
 func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) error {
 	batch := c.metaDB.NewBatch()
 	defer batch.Reset()
@@ -167,8 +186,11 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				return err
 			}
 		}
-		if result.Success {
-			switch tx.Action.(type) { //nolint:gocritic
+		if !result.Success {
+			continue
+		}
+		for _, act := range tx.Actions {
+			switch act.(type) { //nolint:gocritic
 			case *actions.Transfer:
 				c.metrics.transfer.Inc()
 			}
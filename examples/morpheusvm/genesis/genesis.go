@@ -43,7 +43,8 @@ type Genesis struct {
 	MaxBlockUnits              chain.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
 
 	// Tx Parameters
-	ValidityWindow int64 `json:"validityWindow"` // ms
+	ValidityWindow  int64 `json:"validityWindow"` // ms
+	MaxActionsPerTx uint8 `json:"maxActionsPerTx"`
 
 	// Tx Fee Parameters
 	BaseComputeUnits          uint64 `json:"baseUnits"`
@@ -57,6 +58,14 @@ type Genesis struct {
 	StorageKeyWriteUnits      uint64 `json:"storageKeyWriteUnits"`
 	StorageValueWriteUnits    uint64 `json:"storageValueWriteUnits"` // per chunk
 
+	// Scheduler Parameters
+	MaxScheduledActions int              `json:"maxScheduledActions"` // per block
+	MaxScheduledUnits   chain.Dimensions `json:"maxScheduledUnits"`   // per block
+
+	// PriorityFeeRecipient receives all priority fees (bech32 address). If empty,
+	// priority fees are burned.
+	PriorityFeeRecipient string `json:"priorityFeeRecipient"`
+
 	// Allocates
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
 }
@@ -77,7 +86,8 @@ func Default() *Genesis {
 		MaxBlockUnits:              chain.Dimensions{1_800_000, 2_000, 2_000, 2_000, 2_000},
 
 		// Tx Parameters
-		ValidityWindow: 60 * hconsts.MillisecondsPerSecond, // ms
+		ValidityWindow:  60 * hconsts.MillisecondsPerSecond, // ms
+		MaxActionsPerTx: 16,
 
 		// Tx Fee Compute Parameters
 		BaseComputeUnits:          1,
@@ -94,6 +104,10 @@ func Default() *Genesis {
 		StorageValueAllocateUnits: 5,
 		StorageKeyWriteUnits:      10,
 		StorageValueWriteUnits:    3,
+
+		// Scheduler Parameters
+		MaxScheduledActions: 16,
+		MaxScheduledUnits:   chain.Dimensions{0, 1_000, 1_000, 1_000, 1_000},
 	}
 }
 
@@ -132,6 +146,15 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutabl
 	return nil
 }
 
+// GetPriorityFeeRecipient returns the parsed [PriorityFeeRecipient] (or
+// [codec.EmptyAddress] if it is not set).
+func (g *Genesis) GetPriorityFeeRecipient() (codec.Address, error) {
+	if len(g.PriorityFeeRecipient) == 0 {
+		return codec.EmptyAddress, nil
+	}
+	return codec.ParseAddressBech32(consts.HRP, g.PriorityFeeRecipient)
+}
+
 func (g *Genesis) GetStateBranchFactor() merkledb.BranchFactor {
 	return g.StateBranchFactor
 }
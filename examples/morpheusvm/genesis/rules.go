@@ -46,6 +46,10 @@ func (r *Rules) GetValidityWindow() int64 {
 	return r.g.ValidityWindow
 }
 
+func (r *Rules) GetMaxActionsPerTx() uint8 {
+	return r.g.MaxActionsPerTx
+}
+
 func (r *Rules) GetMaxBlockUnits() chain.Dimensions {
 	return r.g.MaxBlockUnits
 }
@@ -102,6 +106,22 @@ func (r *Rules) GetWindowTargetUnits() chain.Dimensions {
 	return r.g.WindowTargetUnits
 }
 
+func (r *Rules) GetMaxScheduledActions() int {
+	return r.g.MaxScheduledActions
+}
+
+func (r *Rules) GetMaxScheduledUnits() chain.Dimensions {
+	return r.g.MaxScheduledUnits
+}
+
+func (*Rules) GetActionActivation(uint8) int64 {
+	return -1
+}
+
+func (*Rules) GetAuthActivation(uint8) int64 {
+	return -1
+}
+
 func (*Rules) FetchCustom(string) (any, bool) {
 	return nil, false
 }
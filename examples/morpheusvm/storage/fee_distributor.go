@@ -0,0 +1,24 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.FeeDistributor = (*FeeDistributor)(nil)
+
+// FeeDistributor burns all fees (they are not credited to any account).
+type FeeDistributor struct{}
+
+func (*FeeDistributor) StateKeys() []string {
+	return nil
+}
+
+func (*FeeDistributor) Distribute(context.Context, chain.Dimensions, uint64, state.Mutable) error {
+	return nil
+}
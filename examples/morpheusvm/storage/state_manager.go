@@ -4,10 +4,18 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
 )
 
-type StateManager struct{}
+type StateManager struct {
+	// PriorityFeeRecipient is credited with all priority fees (if
+	// not [codec.EmptyAddress])
+	PriorityFeeRecipient codec.Address
+}
 
 func (*StateManager) HeightKey() []byte {
 	return HeightKey()
@@ -21,6 +29,28 @@ func (*StateManager) FeeKey() []byte {
 	return FeeKey()
 }
 
+func (*StateManager) NonceKey(addr codec.Address) []byte {
+	return NonceKey(addr)
+}
+
+func (*StateManager) SchedulerPrefix() []byte {
+	return SchedulerPrefix()
+}
+
+func (s *StateManager) PriorityFeeStateKeys() []string {
+	if s.PriorityFeeRecipient == codec.EmptyAddress {
+		return nil
+	}
+	return []string{string(BalanceKey(s.PriorityFeeRecipient))}
+}
+
+func (s *StateManager) PayPriorityFees(ctx context.Context, mu state.Mutable, amount uint64) error {
+	if s.PriorityFeeRecipient == codec.EmptyAddress {
+		return nil
+	}
+	return AddBalance(ctx, mu, s.PriorityFeeRecipient, amount, true)
+}
+
 func (*StateManager) IncomingWarpKeyPrefix(sourceChainID ids.ID, msgID ids.ID) []byte {
 	return IncomingWarpKeyPrefix(sourceChainID, msgID)
 }
@@ -32,10 +32,10 @@ func (*BurnAsset) GetTypeID() uint8 {
 	return burnAssetID
 }
 
-func (b *BurnAsset) StateKeys(auth chain.Auth, _ ids.ID) []string {
-	return []string{
-		string(storage.AssetKey(b.Asset)),
-		string(storage.BalanceKey(auth.Actor(), b.Asset)),
+func (b *BurnAsset) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(b.Asset)):                 state.All,
+		string(storage.BalanceKey(auth.Actor(), b.Asset)): state.All,
 	}
 }
 
@@ -31,10 +31,10 @@ func (*CloseOrder) GetTypeID() uint8 {
 	return closeOrderID
 }
 
-func (c *CloseOrder) StateKeys(auth chain.Auth, _ ids.ID) []string {
-	return []string{
-		string(storage.OrderKey(c.Order)),
-		string(storage.BalanceKey(auth.Actor(), c.Out)),
+func (c *CloseOrder) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.OrderKey(c.Order)):               state.All,
+		string(storage.BalanceKey(auth.Actor(), c.Out)): state.All,
 	}
 }
 
@@ -14,6 +14,24 @@ const (
 	fillOrderID   uint8 = 6
 	mintAssetID   uint8 = 7
 	transferID    uint8 = 8
+
+	scheduleTransferID uint8 = 9
+
+	proposeID         uint8 = 10
+	voteID            uint8 = 11
+	executeProposalID uint8 = 12
+	reclaimVoteID     uint8 = 13
+
+	stakeID    uint8 = 14
+	delegateID uint8 = 15
+	unstakeID  uint8 = 16
+	claimID    uint8 = 17
+)
+
+// Action IDs of modules registered outside of this package (see auth.Session)
+const (
+	RegisterSessionID uint8 = 18
+	RevokeSessionID   uint8 = 19
 )
 
 const (
@@ -29,6 +47,16 @@ const (
 	MintAssetComputeUnits   = 2
 	TransferComputeUnits    = 1
 
+	ScheduleTransferComputeUnits = 5
+
+	ProposeComputeUnits         = 5
+	VoteComputeUnits            = 5
+	ExecuteProposalComputeUnits = 5
+	ReclaimVoteComputeUnits     = 5
+
+	StakingEpochDuration = 60 * 1000 // ms
+	MinValidatorStake    = 10_000
+
 	MaxSymbolSize   = 8
 	MaxMemoSize     = 256
 	MaxMetadataSize = 256
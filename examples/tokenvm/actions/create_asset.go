@@ -28,9 +28,9 @@ func (*CreateAsset) GetTypeID() uint8 {
 	return createAssetID
 }
 
-func (*CreateAsset) StateKeys(_ chain.Auth, txID ids.ID) []string {
-	return []string{
-		string(storage.AssetKey(txID)),
+func (*CreateAsset) StateKeys(_ chain.Auth, actionID ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(actionID)): state.All,
 	}
 }
 
@@ -48,7 +48,7 @@ func (c *CreateAsset) Execute(
 	mu state.Mutable,
 	_ int64,
 	auth chain.Auth,
-	txID ids.ID,
+	actionID ids.ID,
 	_ bool,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
 	if len(c.Symbol) == 0 {
@@ -68,7 +68,7 @@ func (c *CreateAsset) Execute(
 	}
 	// It should only be possible to overwrite an existing asset if there is
 	// a hash collision.
-	if err := storage.SetAsset(ctx, mu, txID, c.Symbol, c.Decimals, c.Metadata, 0, auth.Actor(), false); err != nil {
+	if err := storage.SetAsset(ctx, mu, actionID, c.Symbol, c.Decimals, c.Metadata, 0, auth.Actor(), false); err != nil {
 		return false, CreateAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	return true, CreateAssetComputeUnits, nil, nil, nil
@@ -51,10 +51,10 @@ func (*CreateOrder) GetTypeID() uint8 {
 	return createOrderID
 }
 
-func (c *CreateOrder) StateKeys(auth chain.Auth, txID ids.ID) []string {
-	return []string{
-		string(storage.BalanceKey(auth.Actor(), c.Out)),
-		string(storage.OrderKey(txID)),
+func (c *CreateOrder) StateKeys(auth chain.Auth, actionID ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(auth.Actor(), c.Out)): state.All,
+		string(storage.OrderKey(actionID)):              state.All,
 	}
 }
 
@@ -72,7 +72,7 @@ func (c *CreateOrder) Execute(
 	mu state.Mutable,
 	_ int64,
 	auth chain.Auth,
-	txID ids.ID,
+	actionID ids.ID,
 	_ bool,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
 	if c.In == c.Out {
@@ -93,7 +93,7 @@ func (c *CreateOrder) Execute(
 	if err := storage.SubBalance(ctx, mu, auth.Actor(), c.Out, c.Supply); err != nil {
 		return false, CreateOrderComputeUnits, utils.ErrBytes(err), nil, nil
 	}
-	if err := storage.SetOrder(ctx, mu, txID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, auth.Actor()); err != nil {
+	if err := storage.SetOrder(ctx, mu, actionID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, auth.Actor()); err != nil {
 		return false, CreateOrderComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	return true, CreateOrderComputeUnits, nil, nil, nil
@@ -0,0 +1,114 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ExecuteProposal)(nil)
+
+// ExecuteProposal applies an approved proposal once voting ends. The new
+// parameter value is used starting with the next block.
+type ExecuteProposal struct {
+	// Proposal to execute.
+	Proposal ids.ID `json:"proposal"`
+}
+
+func (*ExecuteProposal) GetTypeID() uint8 {
+	return executeProposalID
+}
+
+func (e *ExecuteProposal) StateKeys(chain.Auth, ids.ID) state.Keys {
+	// The parameter is stored in the proposal, so we must include all of them.
+	keys := state.Keys{
+		string(storage.ProposalKey(e.Proposal)): state.All,
+	}
+	for parameter := uint8(0); parameter < storage.NumParameters; parameter++ {
+		keys[string(storage.ParameterKey(parameter))] = state.All
+	}
+	return keys
+}
+
+func (*ExecuteProposal) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{storage.ProposalChunks}
+	for parameter := uint8(0); parameter < storage.NumParameters; parameter++ {
+		chunks = append(chunks, storage.ParameterChunks)
+	}
+	return chunks
+}
+
+func (*ExecuteProposal) OutputsWarpMessage() bool {
+	return false
+}
+
+func (e *ExecuteProposal) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	_ chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	gr, ok := r.(GovernanceRules)
+	if !ok {
+		return false, ExecuteProposalComputeUnits, OutputGovernanceUnsupported, nil, nil
+	}
+	exists, parameter, value, end, yes, no, executed, err := storage.GetProposal(ctx, mu, e.Proposal)
+	if err != nil {
+		return false, ExecuteProposalComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, ExecuteProposalComputeUnits, OutputProposalMissing, nil, nil
+	}
+	if executed {
+		return false, ExecuteProposalComputeUnits, OutputProposalExecuted, nil, nil
+	}
+	if timestamp < end {
+		return false, ExecuteProposalComputeUnits, OutputVotingNotEnded, nil, nil
+	}
+	if yes <= no || yes < gr.GetGovernanceQuorum() {
+		return false, ExecuteProposalComputeUnits, OutputProposalNotApproved, nil, nil
+	}
+	if err := storage.SetParameter(ctx, mu, parameter, value); err != nil {
+		return false, ExecuteProposalComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetProposal(ctx, mu, e.Proposal, parameter, value, end, yes, no, true); err != nil {
+		return false, ExecuteProposalComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ExecuteProposalComputeUnits, nil, nil, nil
+}
+
+func (*ExecuteProposal) MaxComputeUnits(chain.Rules) uint64 {
+	return ExecuteProposalComputeUnits
+}
+
+func (*ExecuteProposal) Size() int {
+	return consts.IDLen
+}
+
+func (e *ExecuteProposal) Marshal(p *codec.Packer) {
+	p.PackID(e.Proposal)
+}
+
+func UnmarshalExecuteProposal(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var execute ExecuteProposal
+	p.UnpackID(true, &execute.Proposal)
+	return &execute, p.Err()
+}
+
+func (*ExecuteProposal) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
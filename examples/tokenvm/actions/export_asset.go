@@ -37,17 +37,18 @@ func (*ExportAsset) GetTypeID() uint8 {
 	return exportAssetID
 }
 
-func (e *ExportAsset) StateKeys(auth chain.Auth, _ ids.ID) []string {
+func (e *ExportAsset) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
 	if e.Return {
-		return []string{
-			string(storage.AssetKey(e.Asset)),
-			string(storage.BalanceKey(auth.Actor(), e.Asset)),
+		return state.Keys{
+			string(storage.AssetKey(e.Asset)):                 state.All,
+			string(storage.BalanceKey(auth.Actor(), e.Asset)): state.All,
 		}
 	}
-	return []string{
-		string(storage.AssetKey(e.Asset)),
-		string(storage.LoanKey(e.Asset, e.Destination)),
-		string(storage.BalanceKey(auth.Actor(), e.Asset)),
+	// The asset is only read when it is loaned to another chain
+	return state.Keys{
+		string(storage.AssetKey(e.Asset)):                 state.Read,
+		string(storage.LoanKey(e.Asset, e.Destination)):   state.All,
+		string(storage.BalanceKey(auth.Actor(), e.Asset)): state.All,
 	}
 }
 
@@ -66,7 +67,7 @@ func (e *ExportAsset) executeReturn(
 	ctx context.Context,
 	mu state.Mutable,
 	actor codec.Address,
-	txID ids.ID,
+	actionID ids.ID,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
 	exists, symbol, decimals, metadata, supply, _, isWarp, err := storage.GetAsset(ctx, mu, e.Asset)
 	if err != nil {
@@ -126,7 +127,7 @@ func (e *ExportAsset) executeReturn(
 		AssetOut:           e.AssetOut,
 		SwapOut:            e.SwapOut,
 		SwapExpiry:         e.SwapExpiry,
-		TxID:               txID,
+		TxID:               actionID,
 		DestinationChainID: e.Destination,
 	}
 	payload, err := wt.Marshal()
@@ -144,7 +145,7 @@ func (e *ExportAsset) executeLoan(
 	ctx context.Context,
 	mu state.Mutable,
 	actor codec.Address,
-	txID ids.ID,
+	actionID ids.ID,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
 	exists, symbol, decimals, _, _, _, isWarp, err := storage.GetAsset(ctx, mu, e.Asset)
 	if err != nil {
@@ -183,7 +184,7 @@ func (e *ExportAsset) executeLoan(
 		AssetOut:           e.AssetOut,
 		SwapOut:            e.SwapOut,
 		SwapExpiry:         e.SwapExpiry,
-		TxID:               txID,
+		TxID:               actionID,
 		DestinationChainID: e.Destination,
 	}
 	payload, err := wt.Marshal()
@@ -203,7 +204,7 @@ func (e *ExportAsset) Execute(
 	mu state.Mutable,
 	_ int64,
 	auth chain.Auth,
-	txID ids.ID,
+	actionID ids.ID,
 	_ bool,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
 	if e.Value == 0 {
@@ -216,9 +217,9 @@ func (e *ExportAsset) Execute(
 	}
 	// TODO: check if destination is ourselves
 	if e.Return {
-		return e.executeReturn(ctx, mu, auth.Actor(), txID)
+		return e.executeReturn(ctx, mu, auth.Actor(), actionID)
 	}
-	return e.executeLoan(ctx, mu, auth.Actor(), txID)
+	return e.executeLoan(ctx, mu, auth.Actor(), actionID)
 }
 
 func (*ExportAsset) MaxComputeUnits(chain.Rules) uint64 {
@@ -44,12 +44,12 @@ func (*FillOrder) GetTypeID() uint8 {
 	return fillOrderID
 }
 
-func (f *FillOrder) StateKeys(auth chain.Auth, _ ids.ID) []string {
-	return []string{
-		string(storage.OrderKey(f.Order)),
-		string(storage.BalanceKey(f.Owner, f.In)),
-		string(storage.BalanceKey(auth.Actor(), f.In)),
-		string(storage.BalanceKey(auth.Actor(), f.Out)),
+func (f *FillOrder) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.OrderKey(f.Order)):               state.All,
+		string(storage.BalanceKey(f.Owner, f.In)):       state.All,
+		string(storage.BalanceKey(auth.Actor(), f.In)):  state.All,
+		string(storage.BalanceKey(auth.Actor(), f.Out)): state.All,
 	}
 }
 
@@ -0,0 +1,50 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+// GovernanceRules are the [chain.Rules] of a chain that supports governance
+// of its parameters with [Propose], [Vote], [ExecuteProposal] and
+// [ReclaimVote].
+type GovernanceRules interface {
+	chain.Rules
+
+	// GetGovernanceAsset is the asset that must be locked to vote.
+	GetGovernanceAsset() ids.ID
+	GetGovernanceVotingPeriod() int64 // ms
+	GetGovernanceQuorum() uint64
+}
+
+// validParameterValue returns true if [value] can be assigned to [parameter].
+//
+// The [storage.ValidityWindowParameter] is assigned [value[0]] (in ms) and all
+// other dimensions must be 0.
+func validParameterValue(parameter uint8, value chain.Dimensions) bool {
+	switch parameter {
+	case storage.MinUnitPriceParameter, storage.WindowTargetUnitsParameter, storage.MaxBlockUnitsParameter:
+		for _, v := range value {
+			if v == 0 {
+				return false
+			}
+		}
+		return true
+	case storage.ValidityWindowParameter:
+		if int64(value[0]) <= 0 {
+			return false
+		}
+		for _, v := range value[1:] {
+			if v != 0 {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -38,38 +38,38 @@ func (*ImportAsset) GetTypeID() uint8 {
 	return importAssetID
 }
 
-func (i *ImportAsset) StateKeys(auth chain.Auth, _ ids.ID) []string {
+func (i *ImportAsset) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
 	var (
-		keys    []string
+		keys    state.Keys
 		assetID ids.ID
 	)
 	if i.warpTransfer.Return {
 		assetID = i.warpTransfer.Asset
-		keys = []string{
-			string(storage.AssetKey(i.warpTransfer.Asset)),
-			string(storage.LoanKey(i.warpTransfer.Asset, i.warpMessage.SourceChainID)),
-			string(storage.BalanceKey(i.warpTransfer.To, i.warpTransfer.Asset)),
+		keys = state.Keys{
+			string(storage.AssetKey(i.warpTransfer.Asset)):                             state.All,
+			string(storage.LoanKey(i.warpTransfer.Asset, i.warpMessage.SourceChainID)): state.All,
+			string(storage.BalanceKey(i.warpTransfer.To, i.warpTransfer.Asset)):        state.All,
 		}
 	} else {
 		assetID = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
-		keys = []string{
-			string(storage.AssetKey(assetID)),
-			string(storage.BalanceKey(i.warpTransfer.To, assetID)),
+		keys = state.Keys{
+			string(storage.AssetKey(assetID)):                      state.All,
+			string(storage.BalanceKey(i.warpTransfer.To, assetID)): state.All,
 		}
 	}
 
 	// If the [warpTransfer] specified a reward, we add the state key to make
 	// sure it is paid.
 	if i.warpTransfer.Reward > 0 {
-		keys = append(keys, string(storage.BalanceKey(auth.Actor(), assetID)))
+		keys.Add(string(storage.BalanceKey(auth.Actor(), assetID)), state.All)
 	}
 
 	// If the [warpTransfer] requests a swap, we add the state keys to transfer
 	// the required balances.
 	if i.Fill && i.warpTransfer.SwapIn > 0 {
-		keys = append(keys, string(storage.BalanceKey(auth.Actor(), i.warpTransfer.AssetOut)))
-		keys = append(keys, string(storage.BalanceKey(auth.Actor(), assetID)))
-		keys = append(keys, string(storage.BalanceKey(i.warpTransfer.To, i.warpTransfer.AssetOut)))
+		keys.Add(string(storage.BalanceKey(auth.Actor(), i.warpTransfer.AssetOut)), state.All)
+		keys.Add(string(storage.BalanceKey(auth.Actor(), assetID)), state.All)
+		keys.Add(string(storage.BalanceKey(i.warpTransfer.To, i.warpTransfer.AssetOut)), state.All)
 	}
 	return keys
 }
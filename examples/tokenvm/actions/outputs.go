@@ -37,4 +37,15 @@ var (
 	OutputMustFill               = []byte("must fill request")
 	OutputWarpVerificationFailed = []byte("warp verification failed")
 	OutputInvalidDestination     = []byte("invalid destination")
+	OutputGovernanceUnsupported  = []byte("governance is not supported")
+	OutputParameterInvalid       = []byte("parameter is invalid")
+	OutputParameterValueInvalid  = []byte("parameter value is invalid")
+	OutputProposalMissing        = []byte("proposal is missing")
+	OutputProposalExecuted       = []byte("proposal already executed")
+	OutputProposalNotApproved    = []byte("proposal is not approved")
+	OutputVotingEnded            = []byte("voting has ended")
+	OutputVotingNotEnded         = []byte("voting has not ended")
+	OutputAlreadyVoted           = []byte("already voted")
+	OutputVoteMissing            = []byte("vote is missing")
+	OutputWrongGovernanceAsset   = []byte("wrong governance asset")
 )
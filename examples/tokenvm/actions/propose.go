@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Propose)(nil)
+
+// Propose creates a proposal (identified by the actionID) to change a
+// [chain.Rules] parameter. Voting on the proposal ends after the
+// governance voting period.
+type Propose struct {
+	// Parameter to change (see [storage.MinUnitPriceParameter]).
+	Parameter uint8 `json:"parameter"`
+
+	// Value to assign to [Parameter]. The validity window (in ms) is
+	// [Value[0]].
+	Value chain.Dimensions `json:"value"`
+}
+
+func (*Propose) GetTypeID() uint8 {
+	return proposeID
+}
+
+func (*Propose) StateKeys(_ chain.Auth, actionID ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.ProposalKey(actionID)): state.All,
+	}
+}
+
+func (*Propose) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProposalChunks}
+}
+
+func (*Propose) OutputsWarpMessage() bool {
+	return false
+}
+
+func (p *Propose) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	_ chain.Auth,
+	actionID ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	gr, ok := r.(GovernanceRules)
+	if !ok {
+		return false, ProposeComputeUnits, OutputGovernanceUnsupported, nil, nil
+	}
+	if p.Parameter >= storage.NumParameters {
+		return false, ProposeComputeUnits, OutputParameterInvalid, nil, nil
+	}
+	if !validParameterValue(p.Parameter, p.Value) {
+		return false, ProposeComputeUnits, OutputParameterValueInvalid, nil, nil
+	}
+	end := timestamp + gr.GetGovernanceVotingPeriod()
+	if err := storage.SetProposal(ctx, mu, actionID, p.Parameter, p.Value, end, 0, 0, false); err != nil {
+		return false, ProposeComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ProposeComputeUnits, nil, nil, nil
+}
+
+func (*Propose) MaxComputeUnits(chain.Rules) uint64 {
+	return ProposeComputeUnits
+}
+
+func (*Propose) Size() int {
+	return consts.ByteLen + chain.DimensionsLen
+}
+
+func (p *Propose) Marshal(pk *codec.Packer) {
+	pk.PackByte(p.Parameter)
+	pk.PackFixedBytes(p.Value.Bytes())
+}
+
+func UnmarshalPropose(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var propose Propose
+	propose.Parameter = p.UnpackByte()
+	value := make([]byte, chain.DimensionsLen)
+	p.UnpackFixedBytes(chain.DimensionsLen, &value)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	dimensions, err := chain.UnpackDimensions(value)
+	if err != nil {
+		return nil, err
+	}
+	propose.Value = dimensions
+	return &propose, nil
+}
+
+func (*Propose) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
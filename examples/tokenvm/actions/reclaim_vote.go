@@ -0,0 +1,113 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ReclaimVote)(nil)
+
+// ReclaimVote returns the amount locked by a [Vote] once voting on its
+// proposal ends.
+type ReclaimVote struct {
+	// Proposal that was voted on.
+	Proposal ids.ID `json:"proposal"`
+
+	// Asset locked by the [Vote]. We need to provide this to populate
+	// [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (*ReclaimVote) GetTypeID() uint8 {
+	return reclaimVoteID
+}
+
+func (r *ReclaimVote) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.ProposalKey(r.Proposal)):           state.Read,
+		string(storage.VoteKey(r.Proposal, auth.Actor())): state.All,
+		string(storage.BalanceKey(auth.Actor(), r.Asset)): state.All,
+	}
+}
+
+func (*ReclaimVote) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProposalChunks, storage.VoteChunks, storage.BalanceChunks}
+}
+
+func (*ReclaimVote) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *ReclaimVote) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	exists, _, _, end, _, _, _, err := storage.GetProposal(ctx, mu, r.Proposal)
+	if err != nil {
+		return false, ReclaimVoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, ReclaimVoteComputeUnits, OutputProposalMissing, nil, nil
+	}
+	if timestamp < end {
+		return false, ReclaimVoteComputeUnits, OutputVotingNotEnded, nil, nil
+	}
+	voted, _, amount, asset, err := storage.GetVote(ctx, mu, r.Proposal, auth.Actor())
+	if err != nil {
+		return false, ReclaimVoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !voted {
+		return false, ReclaimVoteComputeUnits, OutputVoteMissing, nil, nil
+	}
+	if asset != r.Asset {
+		return false, ReclaimVoteComputeUnits, OutputWrongGovernanceAsset, nil, nil
+	}
+	if err := storage.DeleteVote(ctx, mu, r.Proposal, auth.Actor()); err != nil {
+		return false, ReclaimVoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.AddBalance(ctx, mu, auth.Actor(), r.Asset, amount, true); err != nil {
+		return false, ReclaimVoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ReclaimVoteComputeUnits, nil, nil, nil
+}
+
+func (*ReclaimVote) MaxComputeUnits(chain.Rules) uint64 {
+	return ReclaimVoteComputeUnits
+}
+
+func (*ReclaimVote) Size() int {
+	return consts.IDLen * 2
+}
+
+func (r *ReclaimVote) Marshal(p *codec.Packer) {
+	p.PackID(r.Proposal)
+	p.PackID(r.Asset)
+}
+
+func UnmarshalReclaimVote(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var reclaim ReclaimVote
+	p.UnpackID(true, &reclaim.Proposal)
+	p.UnpackID(false, &reclaim.Asset) // empty ID is the native asset
+	return &reclaim, p.Err()
+}
+
+func (*ReclaimVote) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ScheduleTransfer)(nil)
+
+// ScheduleTransfer schedules a [Transfer] to be executed at [Timestamp].
+type ScheduleTransfer struct {
+	// Timestamp (in ms) to execute the [Transfer]. Must be aligned to the second.
+	Timestamp int64 `json:"timestamp"`
+
+	// Fee is prepaid to execute the [Transfer] (any unused portion is refunded).
+	Fee uint64 `json:"fee"`
+
+	// To is the recipient of the [Value].
+	To codec.Address `json:"to"`
+
+	// Asset to transfer to [To].
+	Asset ids.ID `json:"asset"`
+
+	// Amount are transferred to [To].
+	Value uint64 `json:"value"`
+
+	// Optional message to accompany transaction.
+	Memo []byte `json:"memo"`
+}
+
+func (*ScheduleTransfer) GetTypeID() uint8 {
+	return scheduleTransferID
+}
+
+func (s *ScheduleTransfer) StateKeys(_ chain.Auth, actionID ids.ID) state.Keys {
+	return chain.ScheduleStateKeys(storage.SchedulerPrefix(), s.Timestamp, actionID)
+}
+
+func (*ScheduleTransfer) StateKeysMaxChunks() []uint16 {
+	return chain.ScheduleStateKeysMaxChunks()
+}
+
+func (*ScheduleTransfer) OutputsWarpMessage() bool {
+	return false
+}
+
+func (s *ScheduleTransfer) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	actionID ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if s.Value == 0 {
+		return false, ScheduleTransferComputeUnits, OutputValueZero, nil, nil
+	}
+	if len(s.Memo) > MaxMemoSize {
+		return false, ScheduleTransferComputeUnits, OutputMemoTooLarge, nil, nil
+	}
+	sa := &chain.ScheduledAction{
+		Timestamp: s.Timestamp,
+		Fee:       s.Fee,
+		Auth:      auth,
+		Action: &Transfer{
+			To:    s.To,
+			Asset: s.Asset,
+			Value: s.Value,
+			Memo:  s.Memo,
+		},
+	}
+	if err := chain.Schedule(ctx, r, mu, storage.SchedulerPrefix(), timestamp, actionID, sa); err != nil {
+		return false, ScheduleTransferComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ScheduleTransferComputeUnits, nil, nil, nil
+}
+
+func (*ScheduleTransfer) MaxComputeUnits(chain.Rules) uint64 {
+	return ScheduleTransferComputeUnits
+}
+
+func (s *ScheduleTransfer) Size() int {
+	return consts.Int64Len + consts.Uint64Len + codec.AddressLen + consts.IDLen + consts.Uint64Len + codec.BytesLen(s.Memo)
+}
+
+func (s *ScheduleTransfer) Marshal(p *codec.Packer) {
+	p.PackInt64(s.Timestamp)
+	p.PackUint64(s.Fee)
+	p.PackAddress(s.To)
+	p.PackID(s.Asset)
+	p.PackUint64(s.Value)
+	p.PackBytes(s.Memo)
+}
+
+func UnmarshalScheduleTransfer(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var transfer ScheduleTransfer
+	transfer.Timestamp = p.UnpackInt64(true)
+	transfer.Fee = p.UnpackUint64(false)
+	p.UnpackAddress(&transfer.To)
+	p.UnpackID(false, &transfer.Asset) // empty ID is the native asset
+	transfer.Value = p.UnpackUint64(true)
+	p.UnpackBytes(MaxMemoSize, false, &transfer.Memo)
+	return &transfer, p.Err()
+}
+
+func (*ScheduleTransfer) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
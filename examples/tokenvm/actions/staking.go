@@ -0,0 +1,65 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/staking"
+)
+
+var _ staking.BalanceHandler = (*stakingBalanceHandler)(nil)
+
+// Staking allows accounts to stake (and delegate) the native asset on
+// validators to earn a share of all fees.
+var Staking *staking.Module
+
+func init() {
+	var err error
+	Staking, err = staking.NewModule(staking.Config{
+		StakeID:           stakeID,
+		DelegateID:        delegateID,
+		UnstakeID:         unstakeID,
+		ClaimID:           claimID,
+		Prefix:            storage.StakingPrefix,
+		EpochDuration:     StakingEpochDuration,
+		MinValidatorStake: MinValidatorStake,
+	}, &stakingBalanceHandler{})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// stakingBalanceHandler stakes the native asset (which is [ids.Empty])
+type stakingBalanceHandler struct{}
+
+func (*stakingBalanceHandler) StateKeys(addr codec.Address) []string {
+	return []string{string(storage.BalanceKey(addr, ids.Empty))}
+}
+
+func (*stakingBalanceHandler) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks}
+}
+
+func (*stakingBalanceHandler) Deduct(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, addr, ids.Empty, amount)
+}
+
+func (*stakingBalanceHandler) Credit(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.AddBalance(ctx, mu, addr, ids.Empty, amount, true)
+}
@@ -36,10 +36,10 @@ func (*Transfer) GetTypeID() uint8 {
 	return transferID
 }
 
-func (t *Transfer) StateKeys(auth chain.Auth, _ ids.ID) []string {
-	return []string{
-		string(storage.BalanceKey(auth.Actor(), t.Asset)),
-		string(storage.BalanceKey(t.To, t.Asset)),
+func (t *Transfer) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(auth.Actor(), t.Asset)): state.All,
+		string(storage.BalanceKey(t.To, t.Asset)):         state.All,
 	}
 }
 
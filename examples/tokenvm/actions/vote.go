@@ -0,0 +1,143 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Vote)(nil)
+
+// Vote locks [Amount] of the governance asset to vote on a proposal. Each
+// account can only vote once on each proposal. The locked amount can be
+// reclaimed with [ReclaimVote] once voting ends.
+type Vote struct {
+	// Proposal to vote on.
+	Proposal ids.ID `json:"proposal"`
+
+	// Asset is the governance asset. We need to provide this to populate
+	// [StateKeys].
+	Asset ids.ID `json:"asset"`
+
+	// Approve is true to vote yes and false to vote no.
+	Approve bool `json:"approve"`
+
+	// Amount of [Asset] to lock (the weight of the vote).
+	Amount uint64 `json:"amount"`
+}
+
+func (*Vote) GetTypeID() uint8 {
+	return voteID
+}
+
+func (v *Vote) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.ProposalKey(v.Proposal)):           state.All,
+		string(storage.VoteKey(v.Proposal, auth.Actor())): state.All,
+		string(storage.BalanceKey(auth.Actor(), v.Asset)): state.All,
+	}
+}
+
+func (*Vote) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProposalChunks, storage.VoteChunks, storage.BalanceChunks}
+}
+
+func (*Vote) OutputsWarpMessage() bool {
+	return false
+}
+
+func (v *Vote) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	gr, ok := r.(GovernanceRules)
+	if !ok {
+		return false, VoteComputeUnits, OutputGovernanceUnsupported, nil, nil
+	}
+	if v.Asset != gr.GetGovernanceAsset() {
+		return false, VoteComputeUnits, OutputWrongGovernanceAsset, nil, nil
+	}
+	if v.Amount == 0 {
+		return false, VoteComputeUnits, OutputValueZero, nil, nil
+	}
+	exists, parameter, value, end, yes, no, executed, err := storage.GetProposal(ctx, mu, v.Proposal)
+	if err != nil {
+		return false, VoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, VoteComputeUnits, OutputProposalMissing, nil, nil
+	}
+	if timestamp >= end {
+		return false, VoteComputeUnits, OutputVotingEnded, nil, nil
+	}
+	voted, _, _, _, err := storage.GetVote(ctx, mu, v.Proposal, auth.Actor())
+	if err != nil {
+		return false, VoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if voted {
+		return false, VoteComputeUnits, OutputAlreadyVoted, nil, nil
+	}
+	if v.Approve {
+		yes, err = smath.Add64(yes, v.Amount)
+	} else {
+		no, err = smath.Add64(no, v.Amount)
+	}
+	if err != nil {
+		return false, VoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SubBalance(ctx, mu, auth.Actor(), v.Asset, v.Amount); err != nil {
+		return false, VoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetVote(ctx, mu, v.Proposal, auth.Actor(), v.Approve, v.Amount, v.Asset); err != nil {
+		return false, VoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetProposal(ctx, mu, v.Proposal, parameter, value, end, yes, no, executed); err != nil {
+		return false, VoteComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, VoteComputeUnits, nil, nil, nil
+}
+
+func (*Vote) MaxComputeUnits(chain.Rules) uint64 {
+	return VoteComputeUnits
+}
+
+func (*Vote) Size() int {
+	return consts.IDLen*2 + consts.BoolLen + consts.Uint64Len
+}
+
+func (v *Vote) Marshal(p *codec.Packer) {
+	p.PackID(v.Proposal)
+	p.PackID(v.Asset)
+	p.PackBool(v.Approve)
+	p.PackUint64(v.Amount)
+}
+
+func UnmarshalVote(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var vote Vote
+	p.UnpackID(true, &vote.Proposal)
+	p.UnpackID(false, &vote.Asset) // empty ID is the native asset
+	vote.Approve = p.UnpackBool()
+	vote.Amount = p.UnpackUint64(true)
+	return &vote, p.Err()
+}
+
+func (*Vote) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
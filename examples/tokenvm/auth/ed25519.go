@@ -69,7 +69,7 @@ func (d *ED25519) Verify(
 	_ context.Context,
 	r chain.Rules,
 	_ state.Immutable,
-	_ chain.Action,
+	_ []chain.Action,
 ) (uint64, error) {
 	// We don't do anything during verify (there is no additional state to check
 	// to authorize the signer other than verifying the signature)
@@ -144,7 +144,7 @@ type ED25519Factory struct {
 	priv ed25519.PrivateKey
 }
 
-func (d *ED25519Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+func (d *ED25519Factory) Sign(msg []byte, _ []chain.Action) (chain.Auth, error) {
 	sig := ed25519.Sign(msg, d.priv)
 	return &ED25519{Signer: d.priv.PublicKey(), Signature: sig}, nil
 }
@@ -0,0 +1,66 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/multisig"
+	"github.com/ava-labs/hypersdk/state"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+var _ multisig.BalanceHandler = (*multisigBalanceHandler)(nil)
+
+// Multisig allows accounts to be controlled by k-of-n ed25519 and secp256r1 keys.
+var Multisig = multisig.NewModule(multisigID, &multisigBalanceHandler{})
+
+// multisigBalanceHandler pays fees with the native asset (which is [ids.Empty])
+type multisigBalanceHandler struct{}
+
+func (*multisigBalanceHandler) StateKeys(addr codec.Address) []string {
+	return []string{string(storage.BalanceKey(addr, ids.Empty))}
+}
+
+func (*multisigBalanceHandler) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks}
+}
+
+func (*multisigBalanceHandler) CanDeduct(
+	ctx context.Context,
+	addr codec.Address,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, im, addr, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (*multisigBalanceHandler) Deduct(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, addr, ids.Empty, amount)
+}
+
+func (*multisigBalanceHandler) Refund(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	return storage.AddBalance(ctx, mu, addr, ids.Empty, amount, false)
+}
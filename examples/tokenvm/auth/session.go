@@ -0,0 +1,124 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/session"
+	"github.com/ava-labs/hypersdk/x/staking"
+)
+
+var _ session.BalanceHandler = (*sessionBalanceHandler)(nil)
+
+// Session allows accounts controlled by an ed25519 key to register session
+// keys that can only authorize some actions (and spend up to a cap of each
+// asset, including fees) until they expire.
+var Session *session.Module
+
+func init() {
+	var err error
+	Session, err = session.NewModule(session.Config{
+		AuthID:     sessionID,
+		RegisterID: actions.RegisterSessionID,
+		RevokeID:   actions.RevokeSessionID,
+		Prefix:     storage.SessionPrefix,
+		FeeAsset:   ids.Empty,
+	}, &sessionBalanceHandler{})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// sessionBalanceHandler pays fees with the native asset (which is [ids.Empty])
+type sessionBalanceHandler struct{}
+
+func (*sessionBalanceHandler) StateKeys(addr codec.Address) []string {
+	return []string{string(storage.BalanceKey(addr, ids.Empty))}
+}
+
+func (*sessionBalanceHandler) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks}
+}
+
+func (*sessionBalanceHandler) CanDeduct(
+	ctx context.Context,
+	addr codec.Address,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, im, addr, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (*sessionBalanceHandler) Deduct(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, addr, ids.Empty, amount)
+}
+
+func (*sessionBalanceHandler) Refund(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	return storage.AddBalance(ctx, mu, addr, ids.Empty, amount, false)
+}
+
+func (*sessionBalanceHandler) Spend(action chain.Action) (map[ids.ID]uint64, error) {
+	spend := map[ids.ID]uint64{}
+	add := func(asset ids.ID, amount uint64) error {
+		total, err := smath.Add64(spend[asset], amount)
+		if err != nil {
+			return err
+		}
+		spend[asset] = total
+		return nil
+	}
+	var err error
+	switch a := action.(type) {
+	case *actions.Transfer:
+		err = add(a.Asset, a.Value)
+	case *actions.BurnAsset:
+		err = add(a.Asset, a.Value)
+	case *actions.CreateOrder:
+		err = add(a.Out, a.Supply)
+	case *actions.FillOrder:
+		err = add(a.In, a.Value)
+	case *actions.ExportAsset:
+		if err = add(a.Asset, a.Value); err == nil {
+			err = add(a.Asset, a.Reward)
+		}
+	case *actions.ScheduleTransfer:
+		// [Fee] is prepaid with the native asset when scheduled
+		if err = add(a.Asset, a.Value); err == nil {
+			err = add(ids.Empty, a.Fee)
+		}
+	case *actions.Vote:
+		err = add(a.Asset, a.Amount)
+	case *staking.Stake:
+		err = add(ids.Empty, a.Amount)
+	case *staking.Delegate:
+		err = add(ids.Empty, a.Amount)
+	}
+	return spend, err
+}
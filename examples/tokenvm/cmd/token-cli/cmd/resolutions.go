@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
@@ -29,7 +30,7 @@ func sendAndWait(
 	if err != nil {
 		return false, ids.Empty, err
 	}
-	_, tx, _, err := cli.GenerateTransaction(ctx, parser, warpMsg, action, factory)
+	_, tx, _, err := cli.GenerateTransaction(ctx, parser, warpMsg, []chain.Action{action}, factory)
 	if err != nil {
 		return false, ids.Empty, err
 	}
@@ -59,117 +60,127 @@ func sendAndWait(
 }
 
 func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result) {
-	summaryStr := string(result.Output)
 	actor := tx.Auth.Actor()
 	status := "❌"
-	if result.Success {
+	summaries := make([]string, 0, len(result.Outputs))
+	actionTypes := make([]string, 0, len(tx.Actions))
+	for _, act := range tx.Actions {
+		actionTypes = append(actionTypes, reflect.TypeOf(act).String())
+	}
+	if !result.Success {
+		summaries = append(summaries, string(result.Outputs[len(result.Outputs)-1]))
+	} else {
 		status = "✅"
-		switch action := tx.Action.(type) {
-		case *actions.CreateAsset:
-			summaryStr = fmt.Sprintf("assetID: %s symbol: %s decimals: %d metadata: %s", tx.ID(), action.Symbol, action.Decimals, action.Metadata)
-		case *actions.MintAsset:
-			_, symbol, decimals, _, _, _, _, err := c.Asset(context.TODO(), action.Asset, true)
-			if err != nil {
-				utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
-				return
-			}
-			amountStr := utils.FormatBalance(action.Value, decimals)
-			summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
-		case *actions.BurnAsset:
-			summaryStr = fmt.Sprintf("%d %s -> 🔥", action.Value, action.Asset)
-
-		case *actions.Transfer:
-			_, symbol, decimals, _, _, _, _, err := c.Asset(context.TODO(), action.Asset, true)
-			if err != nil {
-				utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
-				return
-			}
-			amountStr := utils.FormatBalance(action.Value, decimals)
-			summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
-			if len(action.Memo) > 0 {
-				summaryStr += fmt.Sprintf(" (memo: %s)", action.Memo)
-			}
+		for i, act := range tx.Actions {
+			var summaryStr string
+			switch action := act.(type) {
+			case *actions.CreateAsset:
+				summaryStr = fmt.Sprintf("assetID: %s symbol: %s decimals: %d metadata: %s", chain.CreateActionID(tx.ID(), uint8(i)), action.Symbol, action.Decimals, action.Metadata)
+			case *actions.MintAsset:
+				_, symbol, decimals, _, _, _, _, err := c.Asset(context.TODO(), action.Asset, true)
+				if err != nil {
+					utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
+					return
+				}
+				amountStr := utils.FormatBalance(action.Value, decimals)
+				summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+			case *actions.BurnAsset:
+				summaryStr = fmt.Sprintf("%d %s -> 🔥", action.Value, action.Asset)
 
-		case *actions.CreateOrder:
-			_, inSymbol, inDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.In, true)
-			if err != nil {
-				utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
-				return
-			}
-			inTickStr := utils.FormatBalance(action.InTick, inDecimals)
-			_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.Out, true)
-			if err != nil {
-				utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
-				return
-			}
-			outTickStr := utils.FormatBalance(action.OutTick, outDecimals)
-			supplyStr := utils.FormatBalance(action.Supply, outDecimals)
-			summaryStr = fmt.Sprintf("%s %s -> %s %s (supply: %s %s)", inTickStr, inSymbol, outTickStr, outSymbol, supplyStr, outSymbol)
-		case *actions.FillOrder:
-			or, _ := actions.UnmarshalOrderResult(result.Output)
-			_, inSymbol, inDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.In, true)
-			if err != nil {
-				utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
-				return
-			}
-			inAmtStr := utils.FormatBalance(or.In, inDecimals)
-			_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.Out, true)
-			if err != nil {
-				utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
-				return
-			}
-			outAmtStr := utils.FormatBalance(or.Out, outDecimals)
-			remainingStr := utils.FormatBalance(or.Remaining, outDecimals)
-			summaryStr = fmt.Sprintf(
-				"%s %s -> %s %s (remaining: %s %s)",
-				inAmtStr, inSymbol, outAmtStr, outSymbol, remainingStr, outSymbol,
-			)
-		case *actions.CloseOrder:
-			summaryStr = fmt.Sprintf("orderID: %s", action.Order)
+			case *actions.Transfer:
+				_, symbol, decimals, _, _, _, _, err := c.Asset(context.TODO(), action.Asset, true)
+				if err != nil {
+					utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
+					return
+				}
+				amountStr := utils.FormatBalance(action.Value, decimals)
+				summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+				if len(action.Memo) > 0 {
+					summaryStr += fmt.Sprintf(" (memo: %s)", action.Memo)
+				}
 
-		case *actions.ImportAsset:
-			wm := tx.WarpMessage
-			signers, _ := wm.Signature.NumSigners()
-			wt, _ := actions.UnmarshalWarpTransfer(wm.Payload)
-			summaryStr = fmt.Sprintf("source: %s signers: %d | ", wm.SourceChainID, signers)
-			if wt.Return {
-				summaryStr += fmt.Sprintf("%s %s -> %s (return: %t)", utils.FormatBalance(wt.Value, wt.Decimals), wt.Symbol, codec.MustAddressBech32(tconsts.HRP, wt.To), wt.Return)
-			} else {
-				summaryStr += fmt.Sprintf("%s %s (new: %s, original: %s) -> %s (return: %t)", utils.FormatBalance(wt.Value, wt.Decimals), wt.Symbol, actions.ImportedAssetID(wt.Asset, wm.SourceChainID), wt.Asset, codec.MustAddressBech32(tconsts.HRP, wt.To), wt.Return)
-			}
-			if wt.Reward > 0 {
-				summaryStr += fmt.Sprintf(" | reward: %s", utils.FormatBalance(wt.Reward, wt.Decimals))
-			}
-			if wt.SwapIn > 0 {
-				_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), wt.AssetOut, true)
+			case *actions.CreateOrder:
+				_, inSymbol, inDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.In, true)
 				if err != nil {
 					utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
 					return
 				}
-				summaryStr += fmt.Sprintf(" | swap in: %s %s swap out: %s %s expiry: %d fill: %t", utils.FormatBalance(wt.SwapIn, wt.Decimals), wt.Symbol, utils.FormatBalance(wt.SwapOut, outDecimals), outSymbol, wt.SwapExpiry, action.Fill)
-			}
-		case *actions.ExportAsset:
-			wt, _ := actions.UnmarshalWarpTransfer(result.WarpMessage.Payload)
-			summaryStr = fmt.Sprintf("destination: %s | ", action.Destination)
-			var outputAssetID ids.ID
-			if !action.Return {
-				outputAssetID = actions.ImportedAssetID(action.Asset, result.WarpMessage.SourceChainID)
-				summaryStr += fmt.Sprintf("%s %s (%s) -> %s (return: %t)", utils.FormatBalance(action.Value, wt.Decimals), wt.Symbol, action.Asset, codec.MustAddressBech32(tconsts.HRP, action.To), action.Return)
-			} else {
-				outputAssetID = wt.Asset
-				summaryStr += fmt.Sprintf("%s %s (current: %s, original: %s) -> %s (return: %t)", utils.FormatBalance(action.Value, wt.Decimals), wt.Symbol, action.Asset, wt.Asset, codec.MustAddressBech32(tconsts.HRP, action.To), action.Return)
-			}
-			if wt.Reward > 0 {
-				summaryStr += fmt.Sprintf(" | reward: %s", utils.FormatBalance(wt.Reward, wt.Decimals))
-			}
-			if wt.SwapIn > 0 {
-				_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), wt.AssetOut, true)
+				inTickStr := utils.FormatBalance(action.InTick, inDecimals)
+				_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.Out, true)
+				if err != nil {
+					utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
+					return
+				}
+				outTickStr := utils.FormatBalance(action.OutTick, outDecimals)
+				supplyStr := utils.FormatBalance(action.Supply, outDecimals)
+				summaryStr = fmt.Sprintf("%s %s -> %s %s (supply: %s %s)", inTickStr, inSymbol, outTickStr, outSymbol, supplyStr, outSymbol)
+			case *actions.FillOrder:
+				or, _ := actions.UnmarshalOrderResult(result.Outputs[i])
+				_, inSymbol, inDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.In, true)
 				if err != nil {
 					utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
 					return
 				}
-				summaryStr += fmt.Sprintf(" | swap in: %s %s (%s) swap out: %s %s expiry: %d", utils.FormatBalance(wt.SwapIn, wt.Decimals), wt.Symbol, outputAssetID, utils.FormatBalance(wt.SwapOut, outDecimals), outSymbol, wt.SwapExpiry)
+				inAmtStr := utils.FormatBalance(or.In, inDecimals)
+				_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), action.Out, true)
+				if err != nil {
+					utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
+					return
+				}
+				outAmtStr := utils.FormatBalance(or.Out, outDecimals)
+				remainingStr := utils.FormatBalance(or.Remaining, outDecimals)
+				summaryStr = fmt.Sprintf(
+					"%s %s -> %s %s (remaining: %s %s)",
+					inAmtStr, inSymbol, outAmtStr, outSymbol, remainingStr, outSymbol,
+				)
+			case *actions.CloseOrder:
+				summaryStr = fmt.Sprintf("orderID: %s", action.Order)
+
+			case *actions.ImportAsset:
+				wm := tx.WarpMessage
+				signers, _ := wm.Signature.NumSigners()
+				wt, _ := actions.UnmarshalWarpTransfer(wm.Payload)
+				summaryStr = fmt.Sprintf("source: %s signers: %d | ", wm.SourceChainID, signers)
+				if wt.Return {
+					summaryStr += fmt.Sprintf("%s %s -> %s (return: %t)", utils.FormatBalance(wt.Value, wt.Decimals), wt.Symbol, codec.MustAddressBech32(tconsts.HRP, wt.To), wt.Return)
+				} else {
+					summaryStr += fmt.Sprintf("%s %s (new: %s, original: %s) -> %s (return: %t)", utils.FormatBalance(wt.Value, wt.Decimals), wt.Symbol, actions.ImportedAssetID(wt.Asset, wm.SourceChainID), wt.Asset, codec.MustAddressBech32(tconsts.HRP, wt.To), wt.Return)
+				}
+				if wt.Reward > 0 {
+					summaryStr += fmt.Sprintf(" | reward: %s", utils.FormatBalance(wt.Reward, wt.Decimals))
+				}
+				if wt.SwapIn > 0 {
+					_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), wt.AssetOut, true)
+					if err != nil {
+						utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
+						return
+					}
+					summaryStr += fmt.Sprintf(" | swap in: %s %s swap out: %s %s expiry: %d fill: %t", utils.FormatBalance(wt.SwapIn, wt.Decimals), wt.Symbol, utils.FormatBalance(wt.SwapOut, outDecimals), outSymbol, wt.SwapExpiry, action.Fill)
+				}
+			case *actions.ExportAsset:
+				wt, _ := actions.UnmarshalWarpTransfer(result.WarpMessage.Payload)
+				summaryStr = fmt.Sprintf("destination: %s | ", action.Destination)
+				var outputAssetID ids.ID
+				if !action.Return {
+					outputAssetID = actions.ImportedAssetID(action.Asset, result.WarpMessage.SourceChainID)
+					summaryStr += fmt.Sprintf("%s %s (%s) -> %s (return: %t)", utils.FormatBalance(action.Value, wt.Decimals), wt.Symbol, action.Asset, codec.MustAddressBech32(tconsts.HRP, action.To), action.Return)
+				} else {
+					outputAssetID = wt.Asset
+					summaryStr += fmt.Sprintf("%s %s (current: %s, original: %s) -> %s (return: %t)", utils.FormatBalance(action.Value, wt.Decimals), wt.Symbol, action.Asset, wt.Asset, codec.MustAddressBech32(tconsts.HRP, action.To), action.Return)
+				}
+				if wt.Reward > 0 {
+					summaryStr += fmt.Sprintf(" | reward: %s", utils.FormatBalance(wt.Reward, wt.Decimals))
+				}
+				if wt.SwapIn > 0 {
+					_, outSymbol, outDecimals, _, _, _, _, err := c.Asset(context.TODO(), wt.AssetOut, true)
+					if err != nil {
+						utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
+						return
+					}
+					summaryStr += fmt.Sprintf(" | swap in: %s %s (%s) swap out: %s %s expiry: %d", utils.FormatBalance(wt.SwapIn, wt.Decimals), wt.Symbol, outputAssetID, utils.FormatBalance(wt.SwapOut, outDecimals), outSymbol, wt.SwapExpiry)
+				}
 			}
+			summaries = append(summaries, summaryStr)
 		}
 	}
 	utils.Outf(
@@ -177,8 +188,8 @@ func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result
 		status,
 		tx.ID(),
 		codec.MustAddressBech32(tconsts.HRP, actor),
-		reflect.TypeOf(tx.Action),
-		summaryStr,
+		strings.Join(actionTypes, ", "),
+		strings.Join(summaries, " | "),
 		float64(result.Fee)/float64(tx.Base.MaxFee)*100,
 		utils.FormatBalance(result.Fee, tconsts.Decimals),
 		tconsts.Symbol,
@@ -28,6 +28,7 @@ var (
 	maxBlockUnits         []string
 	windowTargetUnits     []string
 	hideTxs               bool
+	adminToken            string
 	randomRecipient       bool
 	maxTxBacklog          int
 	checkAllChains        bool
@@ -140,6 +141,12 @@ func init() {
 		false,
 		"hide txs",
 	)
+	replayChainCmd.PersistentFlags().StringVar(
+		&adminToken,
+		"admin-token",
+		"",
+		"token of the admin API",
+	)
 	chainCmd.AddCommand(
 		importChainCmd,
 		importANRChainCmd,
@@ -147,6 +154,7 @@ func init() {
 		setChainCmd,
 		chainInfoCmd,
 		watchChainCmd,
+		replayChainCmd,
 	)
 
 	// actions
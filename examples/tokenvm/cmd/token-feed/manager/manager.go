@@ -131,7 +131,11 @@ func (m *Manager) Run(ctx context.Context) error {
 
 			// Look for transactions to recipient
 			for i, tx := range blk.Txs {
-				action, ok := tx.Action.(*actions.Transfer)
+				// Only single-action transfers are posted to the feed
+				if len(tx.Actions) != 1 {
+					continue
+				}
+				action, ok := tx.Actions[0].(*actions.Transfer)
 				if !ok {
 					continue
 				}
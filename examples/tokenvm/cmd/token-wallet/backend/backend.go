@@ -220,257 +220,259 @@ func (b *Backend) collectBlocks() {
 			}
 
 			// We should exit action parsing as soon as possible
-			switch action := tx.Action.(type) {
-			case *actions.Transfer:
-				if actor != b.addr && action.To != b.addr {
-					continue
-				}
-
-				_, symbol, decimals, _, _, owner, _, err := b.tcli.Asset(b.ctx, action.Asset, true)
-				if err != nil {
-					b.fatal(err)
-					return
-				}
-				txInfo := &TransactionInfo{
-					ID:        tx.ID().String(),
-					Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
-					Success:   result.Success,
-					Timestamp: blk.Tmstmp,
-					Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
-					Type:      "Transfer",
-					Units:     hcli.ParseDimensions(result.Consumed),
-					Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
-				}
-				if result.Success {
-					txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
-					if len(action.Memo) > 0 {
-						txInfo.Summary += fmt.Sprintf(" (memo: %s)", action.Memo)
-					}
-				} else {
-					txInfo.Summary = string(result.Output)
-				}
-				if action.To == b.addr {
-					if actor != b.addr && result.Success {
-						b.txAlertLock.Lock()
-						b.transactionAlerts = append(b.transactionAlerts, &Alert{"info", fmt.Sprintf("Received %s %s from Transfer", hutils.FormatBalance(action.Value, decimals), symbol)})
-						b.txAlertLock.Unlock()
+			for j, act := range tx.Actions {
+				switch action := act.(type) {
+				case *actions.Transfer:
+					if actor != b.addr && action.To != b.addr {
+						continue
 					}
-					hasAsset, err := b.s.HasAsset(action.Asset)
+
+					_, symbol, decimals, _, _, owner, _, err := b.tcli.Asset(b.ctx, action.Asset, true)
 					if err != nil {
 						b.fatal(err)
 						return
 					}
-					if !hasAsset {
-						if err := b.s.StoreAsset(action.Asset, b.addrStr == owner); err != nil {
+					txInfo := &TransactionInfo{
+						ID:        tx.ID().String(),
+						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
+						Success:   result.Success,
+						Timestamp: blk.Tmstmp,
+						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Type:      "Transfer",
+						Units:     hcli.ParseDimensions(result.Consumed),
+						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
+					}
+					if result.Success {
+						txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+						if len(action.Memo) > 0 {
+							txInfo.Summary += fmt.Sprintf(" (memo: %s)", action.Memo)
+						}
+					} else {
+						txInfo.Summary = string(result.Outputs[len(result.Outputs)-1])
+					}
+					if action.To == b.addr {
+						if actor != b.addr && result.Success {
+							b.txAlertLock.Lock()
+							b.transactionAlerts = append(b.transactionAlerts, &Alert{"info", fmt.Sprintf("Received %s %s from Transfer", hutils.FormatBalance(action.Value, decimals), symbol)})
+							b.txAlertLock.Unlock()
+						}
+						hasAsset, err := b.s.HasAsset(action.Asset)
+						if err != nil {
+							b.fatal(err)
+							return
+						}
+						if !hasAsset {
+							if err := b.s.StoreAsset(action.Asset, b.addrStr == owner); err != nil {
+								b.fatal(err)
+								return
+							}
+						}
+						if err := b.s.StoreTransaction(txInfo); err != nil {
+							b.fatal(err)
+							return
+						}
+					} else if actor == b.addr {
+						if err := b.s.StoreTransaction(txInfo); err != nil {
 							b.fatal(err)
 							return
 						}
 					}
-					if err := b.s.StoreTransaction(txInfo); err != nil {
+				case *actions.CreateAsset:
+					if actor != b.addr {
+						continue
+					}
+
+					if err := b.s.StoreAsset(chain.CreateActionID(tx.ID(), uint8(j)), true); err != nil {
 						b.fatal(err)
 						return
 					}
-				} else if actor == b.addr {
+					txInfo := &TransactionInfo{
+						ID:        tx.ID().String(),
+						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
+						Success:   result.Success,
+						Timestamp: blk.Tmstmp,
+						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Type:      "CreateAsset",
+						Units:     hcli.ParseDimensions(result.Consumed),
+						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
+					}
+					if result.Success {
+						txInfo.Summary = fmt.Sprintf("assetID: %s symbol: %s decimals: %d metadata: %s", chain.CreateActionID(tx.ID(), uint8(j)), action.Symbol, action.Decimals, action.Metadata)
+					} else {
+						txInfo.Summary = string(result.Outputs[len(result.Outputs)-1])
+					}
 					if err := b.s.StoreTransaction(txInfo); err != nil {
 						b.fatal(err)
 						return
 					}
-				}
-			case *actions.CreateAsset:
-				if actor != b.addr {
-					continue
-				}
-
-				if err := b.s.StoreAsset(tx.ID(), true); err != nil {
-					b.fatal(err)
-					return
-				}
-				txInfo := &TransactionInfo{
-					ID:        tx.ID().String(),
-					Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
-					Success:   result.Success,
-					Timestamp: blk.Tmstmp,
-					Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
-					Type:      "CreateAsset",
-					Units:     hcli.ParseDimensions(result.Consumed),
-					Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
-				}
-				if result.Success {
-					txInfo.Summary = fmt.Sprintf("assetID: %s symbol: %s decimals: %d metadata: %s", tx.ID(), action.Symbol, action.Decimals, action.Metadata)
-				} else {
-					txInfo.Summary = string(result.Output)
-				}
-				if err := b.s.StoreTransaction(txInfo); err != nil {
-					b.fatal(err)
-					return
-				}
-			case *actions.MintAsset:
-				if actor != b.addr && action.To != b.addr {
-					continue
-				}
-
-				_, symbol, decimals, _, _, owner, _, err := b.tcli.Asset(b.ctx, action.Asset, true)
-				if err != nil {
-					b.fatal(err)
-					return
-				}
-				txInfo := &TransactionInfo{
-					ID:        tx.ID().String(),
-					Timestamp: blk.Tmstmp,
-					Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
-					Success:   result.Success,
-					Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
-					Type:      "Mint",
-					Units:     hcli.ParseDimensions(result.Consumed),
-					Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
-				}
-				if result.Success {
-					txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
-				} else {
-					txInfo.Summary = string(result.Output)
-				}
-				if action.To == b.addr {
-					if actor != b.addr && result.Success {
-						b.txAlertLock.Lock()
-						b.transactionAlerts = append(b.transactionAlerts, &Alert{"info", fmt.Sprintf("Received %s %s from Mint", hutils.FormatBalance(action.Value, decimals), symbol)})
-						b.txAlertLock.Unlock()
+				case *actions.MintAsset:
+					if actor != b.addr && action.To != b.addr {
+						continue
 					}
-					hasAsset, err := b.s.HasAsset(action.Asset)
+
+					_, symbol, decimals, _, _, owner, _, err := b.tcli.Asset(b.ctx, action.Asset, true)
 					if err != nil {
 						b.fatal(err)
 						return
 					}
-					if !hasAsset {
-						if err := b.s.StoreAsset(action.Asset, b.addrStr == owner); err != nil {
+					txInfo := &TransactionInfo{
+						ID:        tx.ID().String(),
+						Timestamp: blk.Tmstmp,
+						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
+						Success:   result.Success,
+						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Type:      "Mint",
+						Units:     hcli.ParseDimensions(result.Consumed),
+						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
+					}
+					if result.Success {
+						txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+					} else {
+						txInfo.Summary = string(result.Outputs[len(result.Outputs)-1])
+					}
+					if action.To == b.addr {
+						if actor != b.addr && result.Success {
+							b.txAlertLock.Lock()
+							b.transactionAlerts = append(b.transactionAlerts, &Alert{"info", fmt.Sprintf("Received %s %s from Mint", hutils.FormatBalance(action.Value, decimals), symbol)})
+							b.txAlertLock.Unlock()
+						}
+						hasAsset, err := b.s.HasAsset(action.Asset)
+						if err != nil {
+							b.fatal(err)
+							return
+						}
+						if !hasAsset {
+							if err := b.s.StoreAsset(action.Asset, b.addrStr == owner); err != nil {
+								b.fatal(err)
+								return
+							}
+						}
+						if err := b.s.StoreTransaction(txInfo); err != nil {
+							b.fatal(err)
+							return
+						}
+					} else if actor == b.addr {
+						if err := b.s.StoreTransaction(txInfo); err != nil {
 							b.fatal(err)
 							return
 						}
 					}
-					if err := b.s.StoreTransaction(txInfo); err != nil {
+				case *actions.CreateOrder:
+					if actor != b.addr {
+						continue
+					}
+
+					_, inSymbol, inDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.In, true)
+					if err != nil {
+						b.fatal(err)
+						return
+					}
+					_, outSymbol, outDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.Out, true)
+					if err != nil {
 						b.fatal(err)
 						return
 					}
-				} else if actor == b.addr {
+					txInfo := &TransactionInfo{
+						ID:        tx.ID().String(),
+						Timestamp: blk.Tmstmp,
+						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
+						Success:   result.Success,
+						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Type:      "CreateOrder",
+						Units:     hcli.ParseDimensions(result.Consumed),
+						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
+					}
+					if result.Success {
+						txInfo.Summary = fmt.Sprintf("%s %s -> %s %s (supply: %s %s)",
+							hutils.FormatBalance(action.InTick, inDecimals),
+							inSymbol,
+							hutils.FormatBalance(action.OutTick, outDecimals),
+							outSymbol,
+							hutils.FormatBalance(action.Supply, outDecimals),
+							outSymbol,
+						)
+					} else {
+						txInfo.Summary = string(result.Outputs[len(result.Outputs)-1])
+					}
 					if err := b.s.StoreTransaction(txInfo); err != nil {
 						b.fatal(err)
 						return
 					}
-				}
-			case *actions.CreateOrder:
-				if actor != b.addr {
-					continue
-				}
+				case *actions.FillOrder:
+					if actor != b.addr && action.Owner != b.addr {
+						continue
+					}
 
-				_, inSymbol, inDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.In, true)
-				if err != nil {
-					b.fatal(err)
-					return
-				}
-				_, outSymbol, outDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.Out, true)
-				if err != nil {
-					b.fatal(err)
-					return
-				}
-				txInfo := &TransactionInfo{
-					ID:        tx.ID().String(),
-					Timestamp: blk.Tmstmp,
-					Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
-					Success:   result.Success,
-					Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
-					Type:      "CreateOrder",
-					Units:     hcli.ParseDimensions(result.Consumed),
-					Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
-				}
-				if result.Success {
-					txInfo.Summary = fmt.Sprintf("%s %s -> %s %s (supply: %s %s)",
-						hutils.FormatBalance(action.InTick, inDecimals),
-						inSymbol,
-						hutils.FormatBalance(action.OutTick, outDecimals),
-						outSymbol,
-						hutils.FormatBalance(action.Supply, outDecimals),
-						outSymbol,
-					)
-				} else {
-					txInfo.Summary = string(result.Output)
-				}
-				if err := b.s.StoreTransaction(txInfo); err != nil {
-					b.fatal(err)
-					return
-				}
-			case *actions.FillOrder:
-				if actor != b.addr && action.Owner != b.addr {
-					continue
-				}
+					_, inSymbol, inDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.In, true)
+					if err != nil {
+						b.fatal(err)
+						return
+					}
+					_, outSymbol, outDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.Out, true)
+					if err != nil {
+						b.fatal(err)
+						return
+					}
+					txInfo := &TransactionInfo{
+						ID:        tx.ID().String(),
+						Timestamp: blk.Tmstmp,
+						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
+						Success:   result.Success,
+						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Type:      "FillOrder",
+						Units:     hcli.ParseDimensions(result.Consumed),
+						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
+					}
+					if result.Success {
+						or, _ := actions.UnmarshalOrderResult(result.Outputs[j])
+						txInfo.Summary = fmt.Sprintf("%s %s -> %s %s (remaining: %s %s)",
+							hutils.FormatBalance(or.In, inDecimals),
+							inSymbol,
+							hutils.FormatBalance(or.Out, outDecimals),
+							outSymbol,
+							hutils.FormatBalance(or.Remaining, outDecimals),
+							outSymbol,
+						)
+
+						if action.Owner == b.addr && actor != b.addr {
+							b.txAlertLock.Lock()
+							b.transactionAlerts = append(b.transactionAlerts, &Alert{"info", fmt.Sprintf("Received %s %s from FillOrder", hutils.FormatBalance(or.In, inDecimals), inSymbol)})
+							b.txAlertLock.Unlock()
+						}
+					} else {
+						txInfo.Summary = string(result.Outputs[len(result.Outputs)-1])
+					}
+					if actor == b.addr {
+						if err := b.s.StoreTransaction(txInfo); err != nil {
+							b.fatal(err)
+							return
+						}
+					}
+				case *actions.CloseOrder:
+					if actor != b.addr {
+						continue
+					}
 
-				_, inSymbol, inDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.In, true)
-				if err != nil {
-					b.fatal(err)
-					return
-				}
-				_, outSymbol, outDecimals, _, _, _, _, err := b.tcli.Asset(b.ctx, action.Out, true)
-				if err != nil {
-					b.fatal(err)
-					return
-				}
-				txInfo := &TransactionInfo{
-					ID:        tx.ID().String(),
-					Timestamp: blk.Tmstmp,
-					Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
-					Success:   result.Success,
-					Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
-					Type:      "FillOrder",
-					Units:     hcli.ParseDimensions(result.Consumed),
-					Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
-				}
-				if result.Success {
-					or, _ := actions.UnmarshalOrderResult(result.Output)
-					txInfo.Summary = fmt.Sprintf("%s %s -> %s %s (remaining: %s %s)",
-						hutils.FormatBalance(or.In, inDecimals),
-						inSymbol,
-						hutils.FormatBalance(or.Out, outDecimals),
-						outSymbol,
-						hutils.FormatBalance(or.Remaining, outDecimals),
-						outSymbol,
-					)
-
-					if action.Owner == b.addr && actor != b.addr {
-						b.txAlertLock.Lock()
-						b.transactionAlerts = append(b.transactionAlerts, &Alert{"info", fmt.Sprintf("Received %s %s from FillOrder", hutils.FormatBalance(or.In, inDecimals), inSymbol)})
-						b.txAlertLock.Unlock()
+					txInfo := &TransactionInfo{
+						ID:        tx.ID().String(),
+						Timestamp: blk.Tmstmp,
+						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
+						Success:   result.Success,
+						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Type:      "CloseOrder",
+						Units:     hcli.ParseDimensions(result.Consumed),
+						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
+					}
+					if result.Success {
+						txInfo.Summary = fmt.Sprintf("OrderID: %s", action.Order)
+					} else {
+						txInfo.Summary = string(result.Outputs[len(result.Outputs)-1])
 					}
-				} else {
-					txInfo.Summary = string(result.Output)
-				}
-				if actor == b.addr {
 					if err := b.s.StoreTransaction(txInfo); err != nil {
 						b.fatal(err)
 						return
 					}
 				}
-			case *actions.CloseOrder:
-				if actor != b.addr {
-					continue
-				}
-
-				txInfo := &TransactionInfo{
-					ID:        tx.ID().String(),
-					Timestamp: blk.Tmstmp,
-					Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
-					Success:   result.Success,
-					Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
-					Type:      "CloseOrder",
-					Units:     hcli.ParseDimensions(result.Consumed),
-					Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
-				}
-				if result.Success {
-					txInfo.Summary = fmt.Sprintf("OrderID: %s", action.Order)
-				} else {
-					txInfo.Summary = string(result.Output)
-				}
-				if err := b.s.StoreTransaction(txInfo); err != nil {
-					b.fatal(err)
-					return
-				}
 			}
 		}
 		now := time.Now()
@@ -526,7 +528,7 @@ func (b *Backend) collectBlocks() {
 		}
 		b.currentStat.Transactions += bi.Txs
 		for _, tx := range blk.Txs {
-			b.currentStat.Accounts.Add(codec.MustAddressBech32(tconsts.HRP, tx.Auth.Sponsor()))
+			b.currentStat.Accounts.Add(codec.MustAddressBech32(tconsts.HRP, tx.Sponsor()))
 		}
 		b.currentStat.Prices = prices
 		snow := time.Now().Unix()
@@ -639,11 +641,11 @@ func (b *Backend) CreateAsset(symbol string, decimals string, metadata string) e
 	if err != nil {
 		return err
 	}
-	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, &actions.CreateAsset{
+	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, []chain.Action{&actions.CreateAsset{
 		Symbol:   []byte(symbol),
 		Decimals: uint8(udecimals),
 		Metadata: []byte(metadata),
-	}, b.factory)
+	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
 	}
@@ -663,7 +665,7 @@ func (b *Backend) CreateAsset(symbol string, decimals string, metadata string) e
 		return err
 	}
 	if !result.Success {
-		return fmt.Errorf("transaction failed on-chain: %s", result.Output)
+		return fmt.Errorf("transaction failed on-chain: %s", result.Outputs)
 	}
 	return nil
 }
@@ -694,11 +696,11 @@ func (b *Backend) MintAsset(asset string, address string, amount string) error {
 	}
 
 	// Generate transaction
-	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, &actions.MintAsset{
+	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, []chain.Action{&actions.MintAsset{
 		To:    to,
 		Asset: assetID,
 		Value: value,
-	}, b.factory)
+	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
 	}
@@ -718,7 +720,7 @@ func (b *Backend) MintAsset(asset string, address string, amount string) error {
 		return err
 	}
 	if !result.Success {
-		return fmt.Errorf("transaction failed on-chain: %s", result.Output)
+		return fmt.Errorf("transaction failed on-chain: %s", result.Outputs)
 	}
 	return nil
 }
@@ -758,12 +760,12 @@ func (b *Backend) Transfer(asset string, address string, amount string, memo str
 	}
 
 	// Generate transaction
-	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, &actions.Transfer{
+	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, []chain.Action{&actions.Transfer{
 		To:    to,
 		Asset: assetID,
 		Value: value,
 		Memo:  []byte(memo),
-	}, b.factory)
+	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
 	}
@@ -789,7 +791,7 @@ func (b *Backend) Transfer(asset string, address string, amount string, memo str
 		return err
 	}
 	if !result.Success {
-		return fmt.Errorf("transaction failed on-chain: %s", result.Output)
+		return fmt.Errorf("transaction failed on-chain: %s", result.Outputs)
 	}
 	return nil
 }
@@ -1112,13 +1114,13 @@ func (b *Backend) CreateOrder(assetIn string, inTick string, assetOut string, ou
 	}
 
 	// Generate transaction
-	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, &actions.CreateOrder{
+	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, []chain.Action{&actions.CreateOrder{
 		In:      inID,
 		InTick:  iTick,
 		Out:     outID,
 		OutTick: oTick,
 		Supply:  oSupply,
-	}, b.factory)
+	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
 	}
@@ -1147,7 +1149,7 @@ func (b *Backend) CreateOrder(assetIn string, inTick string, assetOut string, ou
 		return err
 	}
 	if !result.Success {
-		return fmt.Errorf("transaction failed on-chain: %s", result.Output)
+		return fmt.Errorf("transaction failed on-chain: %s", result.Outputs)
 	}
 
 	// We rely on order checking to clear backlog
@@ -1198,13 +1200,13 @@ func (b *Backend) FillOrder(orderID string, orderOwner string, assetIn string, i
 	}
 
 	// Generate transaction
-	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, &actions.FillOrder{
+	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, []chain.Action{&actions.FillOrder{
 		Order: oID,
 		Owner: owner,
 		In:    inID,
 		Out:   outID,
 		Value: inAmount,
-	}, b.factory)
+	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
 	}
@@ -1233,7 +1235,7 @@ func (b *Backend) FillOrder(orderID string, orderOwner string, assetIn string, i
 		return err
 	}
 	if !result.Success {
-		return fmt.Errorf("transaction failed on-chain: %s", result.Output)
+		return fmt.Errorf("transaction failed on-chain: %s", result.Outputs)
 	}
 	return nil
 }
@@ -1255,10 +1257,10 @@ func (b *Backend) CloseOrder(orderID string, assetOut string) error {
 	}
 
 	// Generate transaction
-	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, &actions.CloseOrder{
+	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, []chain.Action{&actions.CloseOrder{
 		Order: oID,
 		Out:   outID,
-	}, b.factory)
+	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
 	}
@@ -1278,7 +1280,7 @@ func (b *Backend) CloseOrder(orderID string, assetOut string) error {
 		return err
 	}
 	if !result.Success {
-		return fmt.Errorf("transaction failed on-chain: %s", result.Output)
+		return fmt.Errorf("transaction failed on-chain: %s", result.Outputs)
 	}
 	return nil
 }
@@ -1395,12 +1397,12 @@ func (b *Backend) Message(message string, url string) error {
 	}
 
 	// Generate transaction
-	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, &actions.Transfer{
+	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, nil, []chain.Action{&actions.Transfer{
 		To:    recipientAddr,
 		Asset: ids.Empty,
 		Value: fee,
 		Memo:  data,
-	}, b.factory)
+	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
 	}
@@ -1420,7 +1422,7 @@ func (b *Backend) Message(message string, url string) error {
 		return err
 	}
 	if !result.Success {
-		return fmt.Errorf("transaction failed on-chain: %s", result.Output)
+		return fmt.Errorf("transaction failed on-chain: %s", result.Outputs)
 	}
 	return nil
 }
@@ -17,6 +17,7 @@ import (
 	hrpc "github.com/ava-labs/hypersdk/rpc"
 	hstorage "github.com/ava-labs/hypersdk/storage"
 	"github.com/ava-labs/hypersdk/vm"
+	"github.com/ava-labs/hypersdk/x/staking"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
@@ -35,10 +36,12 @@ var _ vm.Controller = (*Controller)(nil)
 type Controller struct {
 	inner *vm.VM
 
-	snowCtx      *snow.Context
-	genesis      *genesis.Genesis
-	config       *config.Config
-	stateManager *StateManager
+	snowCtx        *snow.Context
+	genesis        *genesis.Genesis
+	config         *config.Config
+	stateManager   *StateManager
+	feeDistributor *FeeDistributor
+	buildPolicy    *chain.DefaultBlockBuildPolicy
 
 	metrics *metrics
 
@@ -73,7 +76,6 @@ func (c *Controller) Initialize(
 ) {
 	c.inner = inner
 	c.snowCtx = snowCtx
-	c.stateManager = &StateManager{}
 
 	// Instantiate metrics
 	var err error
@@ -98,6 +100,16 @@ func (c *Controller) Initialize(
 		)
 	}
 	snowCtx.Log.Info("loaded genesis", zap.Any("genesis", c.genesis))
+	priorityFeeRecipient, err := c.genesis.GetPriorityFeeRecipient()
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf(
+			"invalid priority fee recipient: %w",
+			err,
+		)
+	}
+	c.stateManager = &StateManager{priorityFeeRecipient: priorityFeeRecipient}
+	c.feeDistributor = &FeeDistributor{}
+	c.buildPolicy = &chain.DefaultBlockBuildPolicy{}
 
 	// Create DBs
 	blockDB, stateDB, metaDB, err := hstorage.New(snowCtx.ChainDataDir, gatherer)
@@ -157,6 +169,14 @@ func (c *Controller) StateManager() chain.StateManager {
 	return c.stateManager
 }
 
+func (c *Controller) FeeDistributor() chain.FeeDistributor {
+	return c.feeDistributor
+}
+
+func (c *Controller) BlockBuildPolicy() chain.BlockBuildPolicy {
+	return c.buildPolicy
+}
+
 func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) error {
 	batch := c.metaDB.NewBatch()
 	defer batch.Reset()
@@ -178,8 +198,11 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				return err
 			}
 		}
-		if result.Success {
-			switch action := tx.Action.(type) {
+		if !result.Success {
+			continue
+		}
+		for j, act := range tx.Actions {
+			switch action := act.(type) {
 			case *actions.CreateAsset:
 				c.metrics.createAsset.Inc()
 			case *actions.MintAsset:
@@ -188,12 +211,14 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				c.metrics.burnAsset.Inc()
 			case *actions.Transfer:
 				c.metrics.transfer.Inc()
+			case *actions.ScheduleTransfer:
+				c.metrics.scheduleTransfer.Inc()
 			case *actions.CreateOrder:
 				c.metrics.createOrder.Inc()
-				c.orderBook.Add(tx.ID(), tx.Auth.Actor(), action)
+				c.orderBook.Add(chain.CreateActionID(tx.ID(), uint8(j)), tx.Auth.Actor(), action)
 			case *actions.FillOrder:
 				c.metrics.fillOrder.Inc()
-				orderResult, err := actions.UnmarshalOrderResult(result.Output)
+				orderResult, err := actions.UnmarshalOrderResult(result.Outputs[j])
 				if err != nil {
 					// This should never happen
 					return err
@@ -210,6 +235,22 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				c.metrics.importAsset.Inc()
 			case *actions.ExportAsset:
 				c.metrics.exportAsset.Inc()
+			case *actions.Propose:
+				c.metrics.propose.Inc()
+			case *actions.Vote:
+				c.metrics.vote.Inc()
+			case *actions.ExecuteProposal:
+				c.metrics.executeProposal.Inc()
+			case *actions.ReclaimVote:
+				c.metrics.reclaimVote.Inc()
+			case *staking.Stake:
+				c.metrics.stake.Inc()
+			case *staking.Delegate:
+				c.metrics.delegate.Inc()
+			case *staking.Unstake:
+				c.metrics.unstake.Inc()
+			case *staking.Claim:
+				c.metrics.claim.Inc()
 			}
 		}
 	}
@@ -220,8 +261,10 @@ func (*Controller) Rejected(context.Context, *chain.StatelessBlock) error {
 	return nil
 }
 
-func (*Controller) Shutdown(context.Context) error {
+func (c *Controller) Shutdown(context.Context) error {
 	// Do not close any databases provided during initialization. The VM will
 	// close any databases your provided.
-	return nil
+	//
+	// [metaDB] is only used by the controller, so we must close it.
+	return c.metaDB.Close()
 }
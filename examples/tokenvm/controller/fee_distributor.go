@@ -0,0 +1,68 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.FeeDistributor = (*FeeDistributor)(nil)
+
+// FeeDistributor distributes all fees to stakers (see [actions.Staking]) and
+// burns any remainder (i.e. if there is no stake) by removing it from the
+// supply of the native asset. Forfeited fees are always burned.
+type FeeDistributor struct{}
+
+func (*FeeDistributor) StateKeys() []string {
+	return append(actions.Staking.DistributeStateKeys(), string(storage.AssetKey(ids.Empty)))
+}
+
+func (*FeeDistributor) Distribute(ctx context.Context, fees chain.Dimensions, forfeited uint64, mu state.Mutable) error {
+	var (
+		total uint64
+		err   error
+	)
+	for _, fee := range fees {
+		total, err = smath.Add64(total, fee)
+		if err != nil {
+			return err
+		}
+	}
+	distributed, err := actions.Staking.Distribute(ctx, mu, total)
+	if err != nil {
+		return err
+	}
+	burned, err := smath.Add64(total-distributed, forfeited)
+	if err != nil {
+		return err
+	}
+	return burn(ctx, mu, burned)
+}
+
+// burn removes [amount] from the supply of the native asset (which has
+// already been deducted from the balance of some account).
+func burn(ctx context.Context, mu state.Mutable, amount uint64) error {
+	if amount == 0 {
+		return nil
+	}
+	exists, symbol, decimals, metadata, supply, owner, warp, err := storage.GetAsset(ctx, mu, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNativeAssetMissing
+	}
+	newSupply, err := smath.Sub(supply, amount)
+	if err != nil {
+		return err
+	}
+	return storage.SetAsset(ctx, mu, ids.Empty, symbol, decimals, metadata, newSupply, owner, warp)
+}
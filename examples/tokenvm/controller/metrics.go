@@ -15,7 +15,8 @@ type metrics struct {
 	mintAsset   prometheus.Counter
 	burnAsset   prometheus.Counter
 
-	transfer prometheus.Counter
+	transfer         prometheus.Counter
+	scheduleTransfer prometheus.Counter
 
 	createOrder prometheus.Counter
 	fillOrder   prometheus.Counter
@@ -23,6 +24,16 @@ type metrics struct {
 
 	importAsset prometheus.Counter
 	exportAsset prometheus.Counter
+
+	propose         prometheus.Counter
+	vote            prometheus.Counter
+	executeProposal prometheus.Counter
+	reclaimVote     prometheus.Counter
+
+	stake    prometheus.Counter
+	delegate prometheus.Counter
+	unstake  prometheus.Counter
+	claim    prometheus.Counter
 }
 
 func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
@@ -47,6 +58,11 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "transfer",
 			Help:      "number of transfer actions",
 		}),
+		scheduleTransfer: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "schedule_transfer",
+			Help:      "number of schedule transfer actions",
+		}),
 		createOrder: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "actions",
 			Name:      "create_order",
@@ -72,6 +88,46 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "export_asset",
 			Help:      "number of export asset actions",
 		}),
+		propose: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "propose",
+			Help:      "number of propose actions",
+		}),
+		vote: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "vote",
+			Help:      "number of vote actions",
+		}),
+		executeProposal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "execute_proposal",
+			Help:      "number of execute proposal actions",
+		}),
+		reclaimVote: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "reclaim_vote",
+			Help:      "number of reclaim vote actions",
+		}),
+		stake: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "stake",
+			Help:      "number of stake actions",
+		}),
+		delegate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "delegate",
+			Help:      "number of delegate actions",
+		}),
+		unstake: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "unstake",
+			Help:      "number of unstake actions",
+		}),
+		claim: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim",
+			Help:      "number of claim actions",
+		}),
 	}
 	r := prometheus.NewRegistry()
 	errs := wrappers.Errs{}
@@ -81,6 +137,7 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 		r.Register(m.burnAsset),
 
 		r.Register(m.transfer),
+		r.Register(m.scheduleTransfer),
 
 		r.Register(m.createOrder),
 		r.Register(m.fillOrder),
@@ -88,6 +145,16 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 
 		r.Register(m.importAsset),
 		r.Register(m.exportAsset),
+
+		r.Register(m.propose),
+		r.Register(m.vote),
+		r.Register(m.executeProposal),
+		r.Register(m.reclaimVote),
+
+		r.Register(m.stake),
+		r.Register(m.delegate),
+		r.Register(m.unstake),
+		r.Register(m.claim),
 		gatherer.Register(consts.Name, r),
 	)
 	return m, errs.Err
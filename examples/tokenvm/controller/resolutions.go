@@ -11,9 +11,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/x/staking"
+	"go.uber.org/zap"
 )
 
 func (c *Controller) Genesis() *genesis.Genesis {
@@ -77,3 +80,81 @@ func (c *Controller) GetLoanFromState(
 ) (uint64, error) {
 	return storage.GetLoanFromState(ctx, c.inner.ReadState, asset, destination)
 }
+
+func (c *Controller) GetProposalFromState(
+	ctx context.Context,
+	proposal ids.ID,
+) (
+	bool, // exists
+	uint8, // parameter
+	chain.Dimensions, // value
+	int64, // end
+	uint64, // yes
+	uint64, // no
+	bool, // executed
+	error,
+) {
+	return storage.GetProposalFromState(ctx, c.inner.ReadState, proposal)
+}
+
+func (c *Controller) GetValidatorStakeFromState(
+	ctx context.Context,
+	nodeID ids.NodeID,
+) (
+	*staking.Validator, // nil if no stake has been registered
+	uint64, // stake in the current epoch
+	bool, // is validator
+	error,
+) {
+	validator, err := actions.Staking.GetValidatorFromState(ctx, c.inner.ReadState, nodeID)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	// Stake is tracked independently of the P-Chain, so we still return it if
+	// we can't determine if [nodeID] is currently a validator.
+	isValidator, err := c.inner.IsValidator(ctx, nodeID)
+	if err != nil {
+		c.inner.Logger().Warn(
+			"unable to determine if nodeID is validator",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+	if validator == nil {
+		return nil, 0, isValidator, nil
+	}
+	stake := actions.Staking.ValidatorStake(validator, c.inner.LastAcceptedBlock().Tmstmp)
+	return validator, stake, isValidator, nil
+}
+
+func (c *Controller) GetStakePositionFromState(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	addr codec.Address,
+) (
+	*staking.Position, // nil if [addr] has no stake or rewards on [nodeID]
+	uint64, // pending rewards
+	*staking.Withdrawal, // nil if [addr] has nothing to withdraw
+	error,
+) {
+	position, err := actions.Staking.GetPositionFromState(ctx, c.inner.ReadState, nodeID, addr)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	var rewards uint64
+	if position != nil {
+		totals, err := actions.Staking.GetTotalsFromState(ctx, c.inner.ReadState)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		rewards, err = staking.PendingRewards(position, totals)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+	}
+	withdrawal, err := actions.Staking.GetWithdrawalFromState(ctx, c.inner.ReadState, addr)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return position, rewards, withdrawal, nil
+}
@@ -4,11 +4,19 @@
 package controller
 
 import (
+	"context"
+
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
 )
 
-type StateManager struct{}
+type StateManager struct {
+	// priorityFeeRecipient is credited with all priority fees (if
+	// not [codec.EmptyAddress], otherwise they are burned)
+	priorityFeeRecipient codec.Address
+}
 
 func (*StateManager) HeightKey() []byte {
 	return storage.HeightKey()
@@ -22,6 +30,31 @@ func (*StateManager) FeeKey() []byte {
 	return storage.HeightKey()
 }
 
+func (*StateManager) NonceKey(addr codec.Address) []byte {
+	return storage.NonceKey(addr)
+}
+
+func (*StateManager) SchedulerPrefix() []byte {
+	return storage.SchedulerPrefix()
+}
+
+func (s *StateManager) PriorityFeeStateKeys() []string {
+	if s.priorityFeeRecipient == codec.EmptyAddress {
+		return []string{string(storage.AssetKey(ids.Empty))}
+	}
+	return []string{string(storage.BalanceKey(s.priorityFeeRecipient, ids.Empty))}
+}
+
+func (s *StateManager) PayPriorityFees(ctx context.Context, mu state.Mutable, amount uint64) error {
+	if s.priorityFeeRecipient == codec.EmptyAddress {
+		// Priority fees were deducted from the balance of each sponsor, so we
+		// must remove them from the supply to keep it equal to the sum of all
+		// balances.
+		return burn(ctx, mu, amount)
+	}
+	return storage.AddBalance(ctx, mu, s.priorityFeeRecipient, ids.Empty, amount, true)
+}
+
 func (*StateManager) IncomingWarpKeyPrefix(sourceChainID ids.ID, msgID ids.ID) []byte {
 	return storage.IncomingWarpKeyPrefix(sourceChainID, msgID)
 }
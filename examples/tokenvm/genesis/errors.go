@@ -8,4 +8,7 @@ import "errors"
 var (
 	ErrInvalidHRP    = errors.New("invalid HRP")
 	ErrInvalidTarget = errors.New("invalid target")
+
+	ErrUnorderedUpgrades   = errors.New("upgrades must be ordered by increasing timestamp")
+	ErrDuplicateActivation = errors.New("duplicate activation")
 )
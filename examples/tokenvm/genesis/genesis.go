@@ -29,10 +29,8 @@ type CustomAllocation struct {
 	Balance uint64 `json:"balance"`
 }
 
-type Genesis struct {
-	// State Parameters
-	StateBranchFactor merkledb.BranchFactor `json:"stateBranchFactor"`
-
+// Params are the parameters of the chain that can be modified by an [Upgrade].
+type Params struct {
 	// Chain Parameters
 	MinBlockGap      int64 `json:"minBlockGap"`      // ms
 	MinEmptyBlockGap int64 `json:"minEmptyBlockGap"` // ms
@@ -44,7 +42,8 @@ type Genesis struct {
 	MaxBlockUnits              chain.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
 
 	// Tx Parameters
-	ValidityWindow int64 `json:"validityWindow"` // ms
+	ValidityWindow  int64 `json:"validityWindow"` // ms
+	MaxActionsPerTx uint8 `json:"maxActionsPerTx"`
 
 	// Tx Fee Parameters
 	BaseComputeUnits          uint64 `json:"baseUnits"`
@@ -58,8 +57,37 @@ type Genesis struct {
 	StorageKeyWriteUnits      uint64 `json:"storageKeyWriteUnits"`
 	StorageValueWriteUnits    uint64 `json:"storageValueWriteUnits"` // per chunk
 
+	// Scheduler Parameters
+	MaxScheduledActions int              `json:"maxScheduledActions"` // per block
+	MaxScheduledUnits   chain.Dimensions `json:"maxScheduledUnits"`   // per block
+
+	// Governance Parameters
+	//
+	// Votes are weighted by the amount of [GovernanceAsset] locked by each
+	// voter. A proposal is approved if it receives more yes votes than no votes
+	// and at least [GovernanceQuorum] yes votes before its voting period ends.
+	GovernanceAsset        ids.ID `json:"governanceAsset"`
+	GovernanceVotingPeriod int64  `json:"governanceVotingPeriod"` // ms
+	GovernanceQuorum       uint64 `json:"governanceQuorum"`
+}
+
+type Genesis struct {
+	// State Parameters
+	StateBranchFactor merkledb.BranchFactor `json:"stateBranchFactor"`
+
+	Params
+
+	// PriorityFeeRecipient receives all priority fees (bech32 address). If empty,
+	// priority fees are burned (removed from the supply of the native asset).
+	PriorityFeeRecipient string `json:"priorityFeeRecipient"`
+
 	// Allocates
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
+
+	// upgrades are parsed from the upgradeBytes provided to [New]
+	upgrades          []*Upgrade
+	actionActivations map[uint8]int64
+	authActivations   map[uint8]int64
 }
 
 func Default() *Genesis {
@@ -67,44 +95,61 @@ func Default() *Genesis {
 		// State Parameters
 		StateBranchFactor: merkledb.BranchFactor16,
 
-		// Chain Parameters
-		MinBlockGap:      100,
-		MinEmptyBlockGap: 2_500,
-
-		// Chain Fee Parameters
-		MinUnitPrice:               chain.Dimensions{100, 100, 100, 100, 100},
-		UnitPriceChangeDenominator: chain.Dimensions{48, 48, 48, 48, 48},
-		WindowTargetUnits:          chain.Dimensions{20_000_000, 1_000, 1_000, 1_000, 1_000},
-		MaxBlockUnits:              chain.Dimensions{1_800_000, 2_000, 2_000, 2_000, 2_000},
-
-		// Tx Parameters
-		ValidityWindow: 60 * hconsts.MillisecondsPerSecond, // ms
-
-		// Tx Fee Compute Parameters
-		BaseComputeUnits:          1,
-		BaseWarpComputeUnits:      1_024,
-		WarpComputeUnitsPerSigner: 128,
-		OutgoingWarpComputeUnits:  1_024,
-
-		// Tx Fee Storage Parameters
-		//
-		// TODO: tune this
-		StorageKeyReadUnits:       5,
-		StorageValueReadUnits:     2,
-		StorageKeyAllocateUnits:   20,
-		StorageValueAllocateUnits: 5,
-		StorageKeyWriteUnits:      10,
-		StorageValueWriteUnits:    3,
+		Params: Params{
+			// Chain Parameters
+			MinBlockGap:      100,
+			MinEmptyBlockGap: 2_500,
+
+			// Chain Fee Parameters
+			MinUnitPrice:               chain.Dimensions{100, 100, 100, 100, 100},
+			UnitPriceChangeDenominator: chain.Dimensions{48, 48, 48, 48, 48},
+			WindowTargetUnits:          chain.Dimensions{20_000_000, 1_000, 1_000, 1_000, 1_000},
+			MaxBlockUnits:              chain.Dimensions{1_800_000, 2_000, 2_000, 2_000, 2_000},
+
+			// Tx Parameters
+			ValidityWindow:  60 * hconsts.MillisecondsPerSecond, // ms
+			MaxActionsPerTx: 16,
+
+			// Tx Fee Compute Parameters
+			BaseComputeUnits:          1,
+			BaseWarpComputeUnits:      1_024,
+			WarpComputeUnitsPerSigner: 128,
+			OutgoingWarpComputeUnits:  1_024,
+
+			// Tx Fee Storage Parameters
+			//
+			// TODO: tune this
+			StorageKeyReadUnits:       5,
+			StorageValueReadUnits:     2,
+			StorageKeyAllocateUnits:   20,
+			StorageValueAllocateUnits: 5,
+			StorageKeyWriteUnits:      10,
+			StorageValueWriteUnits:    3,
+
+			// Scheduler Parameters
+			MaxScheduledActions: 16,
+			MaxScheduledUnits:   chain.Dimensions{0, 1_000, 1_000, 1_000, 1_000},
+
+			// Governance Parameters
+			GovernanceAsset:        ids.Empty, // native asset
+			GovernanceVotingPeriod: 24 * 60 * 60 * hconsts.MillisecondsPerSecond,
+			GovernanceQuorum:       1_000_000_000_000,
+		},
 	}
 }
 
-func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
+func New(b []byte, upgradeBytes []byte) (*Genesis, error) {
 	g := Default()
 	if len(b) > 0 {
 		if err := json.Unmarshal(b, g); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
 		}
 	}
+	if len(upgradeBytes) > 0 {
+		if err := g.loadUpgrades(upgradeBytes); err != nil {
+			return nil, err
+		}
+	}
 	return g, nil
 }
 
@@ -143,6 +188,15 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutabl
 	)
 }
 
+// GetPriorityFeeRecipient returns the parsed [PriorityFeeRecipient] (or
+// [codec.EmptyAddress] if it is not set).
+func (g *Genesis) GetPriorityFeeRecipient() (codec.Address, error) {
+	if len(g.PriorityFeeRecipient) == 0 {
+		return codec.EmptyAddress, nil
+	}
+	return codec.ParseAddressBech32(consts.HRP, g.PriorityFeeRecipient)
+}
+
 func (g *Genesis) GetStateBranchFactor() merkledb.BranchFactor {
 	return g.StateBranchFactor
 }
@@ -4,22 +4,59 @@
 package genesis
 
 import (
+	"context"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
 )
 
-var _ chain.Rules = (*Rules)(nil)
+var _ chain.StateRules = (*Rules)(nil)
 
 type Rules struct {
 	g *Genesis
+	p *Params
 
 	networkID uint32
 	chainID   ids.ID
 }
 
-// TODO: use upgradeBytes
-func (g *Genesis) Rules(_ int64, networkID uint32, chainID ids.ID) *Rules {
-	return &Rules{g, networkID, chainID}
+// Rules returns the [Rules] in effect at [t] (after applying any [Upgrade]
+// that is active at [t]).
+func (g *Genesis) Rules(t int64, networkID uint32, chainID ids.ID) *Rules {
+	return &Rules{g, g.params(t), networkID, chainID}
+}
+
+// Load returns the [Rules] with any parameters changed by governance in [im]
+// (these take precedence over the parameters in genesis and any [Upgrade]).
+//
+// The [ValidityWindow] can't be increased beyond its value in genesis (or the
+// latest [Upgrade]) because the VM relies on it to prune replay protection.
+func (r *Rules) Load(ctx context.Context, im state.Immutable) (chain.Rules, error) {
+	p := *r.p
+	for parameter := uint8(0); parameter < storage.NumParameters; parameter++ {
+		exists, value, err := storage.GetParameter(ctx, im, parameter)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		switch parameter {
+		case storage.MinUnitPriceParameter:
+			p.MinUnitPrice = value
+		case storage.WindowTargetUnitsParameter:
+			p.WindowTargetUnits = value
+		case storage.MaxBlockUnitsParameter:
+			p.MaxBlockUnits = value
+		case storage.ValidityWindowParameter:
+			if window := int64(value[0]); window > 0 && window <= r.p.ValidityWindow {
+				p.ValidityWindow = window
+			}
+		}
+	}
+	return &Rules{r.g, &p, r.networkID, r.chainID}, nil
 }
 
 func (*Rules) GetWarpConfig(ids.ID) (bool, uint64, uint64) {
@@ -39,71 +76,109 @@ func (r *Rules) ChainID() ids.ID {
 }
 
 func (r *Rules) GetMinBlockGap() int64 {
-	return r.g.MinBlockGap
+	return r.p.MinBlockGap
 }
 
 func (r *Rules) GetMinEmptyBlockGap() int64 {
-	return r.g.MinEmptyBlockGap
+	return r.p.MinEmptyBlockGap
 }
 
 func (r *Rules) GetValidityWindow() int64 {
-	return r.g.ValidityWindow
+	return r.p.ValidityWindow
+}
+
+func (r *Rules) GetMaxActionsPerTx() uint8 {
+	return r.p.MaxActionsPerTx
 }
 
 func (r *Rules) GetMaxBlockUnits() chain.Dimensions {
-	return r.g.MaxBlockUnits
+	return r.p.MaxBlockUnits
 }
 
 func (r *Rules) GetBaseComputeUnits() uint64 {
-	return r.g.BaseComputeUnits
+	return r.p.BaseComputeUnits
 }
 
 func (r *Rules) GetBaseWarpComputeUnits() uint64 {
-	return r.g.BaseWarpComputeUnits
+	return r.p.BaseWarpComputeUnits
 }
 
 func (r *Rules) GetWarpComputeUnitsPerSigner() uint64 {
-	return r.g.WarpComputeUnitsPerSigner
+	return r.p.WarpComputeUnitsPerSigner
 }
 
 func (r *Rules) GetOutgoingWarpComputeUnits() uint64 {
-	return r.g.OutgoingWarpComputeUnits
+	return r.p.OutgoingWarpComputeUnits
 }
 
 func (r *Rules) GetStorageKeyReadUnits() uint64 {
-	return r.g.StorageKeyReadUnits
+	return r.p.StorageKeyReadUnits
 }
 
 func (r *Rules) GetStorageValueReadUnits() uint64 {
-	return r.g.StorageValueReadUnits
+	return r.p.StorageValueReadUnits
 }
 
 func (r *Rules) GetStorageKeyAllocateUnits() uint64 {
-	return r.g.StorageKeyAllocateUnits
+	return r.p.StorageKeyAllocateUnits
 }
 
 func (r *Rules) GetStorageValueAllocateUnits() uint64 {
-	return r.g.StorageValueAllocateUnits
+	return r.p.StorageValueAllocateUnits
 }
 
 func (r *Rules) GetStorageKeyWriteUnits() uint64 {
-	return r.g.StorageKeyWriteUnits
+	return r.p.StorageKeyWriteUnits
 }
 
 func (r *Rules) GetStorageValueWriteUnits() uint64 {
-	return r.g.StorageValueWriteUnits
+	return r.p.StorageValueWriteUnits
 }
 
 func (r *Rules) GetMinUnitPrice() chain.Dimensions {
-	return r.g.MinUnitPrice
+	return r.p.MinUnitPrice
 }
 
 func (r *Rules) GetUnitPriceChangeDenominator() chain.Dimensions {
-	return r.g.UnitPriceChangeDenominator
+	return r.p.UnitPriceChangeDenominator
 }
 
 func (r *Rules) GetWindowTargetUnits() chain.Dimensions {
-	return r.g.WindowTargetUnits
+	return r.p.WindowTargetUnits
+}
+
+func (r *Rules) GetMaxScheduledActions() int {
+	return r.p.MaxScheduledActions
+}
+
+func (r *Rules) GetMaxScheduledUnits() chain.Dimensions {
+	return r.p.MaxScheduledUnits
+}
+
+func (r *Rules) GetActionActivation(typeID uint8) int64 {
+	if t, ok := r.g.actionActivations[typeID]; ok {
+		return t
+	}
+	return -1
+}
+
+func (r *Rules) GetAuthActivation(typeID uint8) int64 {
+	if t, ok := r.g.authActivations[typeID]; ok {
+		return t
+	}
+	return -1
+}
+
+func (r *Rules) GetGovernanceAsset() ids.ID {
+	return r.p.GovernanceAsset
+}
+
+func (r *Rules) GetGovernanceVotingPeriod() int64 {
+	return r.p.GovernanceVotingPeriod
+}
+
+func (r *Rules) GetGovernanceQuorum() uint64 {
+	return r.p.GovernanceQuorum
 }
 
 func (*Rules) FetchCustom(string) (any, bool) {
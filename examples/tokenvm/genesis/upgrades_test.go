@@ -0,0 +1,91 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgrades(t *testing.T) {
+	require := require.New(t)
+
+	g, err := New(nil, []byte(`[
+		{"timestamp": 1000, "rules": {"minBlockGap": 200}, "activateActions": [3]},
+		{"timestamp": 2000, "rules": {"maxActionsPerTx": 4}, "activateAuths": [1]}
+	]`))
+	require.NoError(err)
+	defaultGenesis := Default()
+
+	// Before the first upgrade
+	r := g.Rules(999, 1, ids.Empty)
+	require.Equal(defaultGenesis.MinBlockGap, r.GetMinBlockGap())
+	require.Equal(defaultGenesis.MaxActionsPerTx, r.GetMaxActionsPerTx())
+
+	// Upgrades are active at their timestamp
+	r = g.Rules(1000, 1, ids.Empty)
+	require.Equal(int64(200), r.GetMinBlockGap())
+	require.Equal(defaultGenesis.MaxActionsPerTx, r.GetMaxActionsPerTx())
+
+	// Overrides are cumulative
+	r = g.Rules(2000, 1, ids.Empty)
+	require.Equal(int64(200), r.GetMinBlockGap())
+	require.Equal(uint8(4), r.GetMaxActionsPerTx())
+	require.Equal(defaultGenesis.ValidityWindow, r.GetValidityWindow())
+
+	// Activations don't depend on the epoch of [Rules]
+	for _, ts := range []int64{0, 1000, 2000} {
+		r = g.Rules(ts, 1, ids.Empty)
+		require.Equal(int64(1000), r.GetActionActivation(3))
+		require.Equal(int64(-1), r.GetActionActivation(1))
+		require.Equal(int64(2000), r.GetAuthActivation(1))
+		require.Equal(int64(-1), r.GetAuthActivation(3))
+	}
+}
+
+func TestInvalidUpgrades(t *testing.T) {
+	tests := []struct {
+		name     string
+		upgrades string
+		err      error
+	}{
+		{
+			name:     "unordered",
+			upgrades: `[{"timestamp": 2000}, {"timestamp": 1000}]`,
+			err:      ErrUnorderedUpgrades,
+		},
+		{
+			name:     "same timestamp",
+			upgrades: `[{"timestamp": 1000}, {"timestamp": 1000}]`,
+			err:      ErrUnorderedUpgrades,
+		},
+		{
+			name:     "negative timestamp",
+			upgrades: `[{"timestamp": -1}]`,
+			err:      ErrUnorderedUpgrades,
+		},
+		{
+			name:     "duplicate action activation",
+			upgrades: `[{"timestamp": 1000, "activateActions": [3]}, {"timestamp": 2000, "activateActions": [3]}]`,
+			err:      ErrDuplicateActivation,
+		},
+		{
+			name:     "duplicate auth activation",
+			upgrades: `[{"timestamp": 1000, "activateAuths": [1, 1]}]`,
+			err:      ErrDuplicateActivation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(nil, []byte(tt.upgrades))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	// Unknown (or non-upgradeable) parameters can't be overridden
+	_, err := New(nil, []byte(`[{"timestamp": 1000, "rules": {"stateBranchFactor": 2}}]`))
+	require.Error(t, err)
+}
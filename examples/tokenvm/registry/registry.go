@@ -35,8 +35,25 @@ func init() {
 		consts.ActionRegistry.Register((&actions.ImportAsset{}).GetTypeID(), actions.UnmarshalImportAsset, true),
 		consts.ActionRegistry.Register((&actions.ExportAsset{}).GetTypeID(), actions.UnmarshalExportAsset, false),
 
+		consts.ActionRegistry.Register((&actions.ScheduleTransfer{}).GetTypeID(), actions.UnmarshalScheduleTransfer, false),
+
+		consts.ActionRegistry.Register((&actions.Propose{}).GetTypeID(), actions.UnmarshalPropose, false),
+		consts.ActionRegistry.Register((&actions.Vote{}).GetTypeID(), actions.UnmarshalVote, false),
+		consts.ActionRegistry.Register((&actions.ExecuteProposal{}).GetTypeID(), actions.UnmarshalExecuteProposal, false),
+		consts.ActionRegistry.Register((&actions.ReclaimVote{}).GetTypeID(), actions.UnmarshalReclaimVote, false),
+
+		consts.ActionRegistry.Register(actions.Staking.StakeID(), actions.Staking.UnmarshalStake, false),
+		consts.ActionRegistry.Register(actions.Staking.DelegateID(), actions.Staking.UnmarshalDelegate, false),
+		consts.ActionRegistry.Register(actions.Staking.UnstakeID(), actions.Staking.UnmarshalUnstake, false),
+		consts.ActionRegistry.Register(actions.Staking.ClaimID(), actions.Staking.UnmarshalClaim, false),
+
+		consts.ActionRegistry.Register(auth.Session.RegisterID(), auth.Session.UnmarshalRegister, false),
+		consts.ActionRegistry.Register(auth.Session.RevokeID(), auth.Session.UnmarshalRevoke, false),
+
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519, false),
+		consts.AuthRegistry.Register(auth.Multisig.TypeID(), auth.Multisig.Unmarshal, false),
+		consts.AuthRegistry.Register(auth.Session.AuthID(), auth.Session.Unmarshal, false),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
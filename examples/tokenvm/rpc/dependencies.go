@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/x/staking"
 )
 
 type Controller interface {
@@ -32,4 +33,26 @@ type Controller interface {
 		error,
 	)
 	GetLoanFromState(context.Context, ids.ID, ids.ID) (uint64, error)
+	GetProposalFromState(context.Context, ids.ID) (
+		bool, // exists
+		uint8, // parameter
+		chain.Dimensions, // value
+		int64, // end
+		uint64, // yes
+		uint64, // no
+		bool, // executed
+		error,
+	)
+	GetValidatorStakeFromState(context.Context, ids.NodeID) (
+		*staking.Validator, // nil if no stake has been registered
+		uint64, // stake in the current epoch
+		bool, // is validator
+		error,
+	)
+	GetStakePositionFromState(context.Context, ids.NodeID, codec.Address) (
+		*staking.Position, // nil if no stake or rewards
+		uint64, // pending rewards
+		*staking.Withdrawal, // nil if nothing to withdraw
+		error,
+	)
 }
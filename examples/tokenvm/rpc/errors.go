@@ -9,4 +9,6 @@ var (
 	ErrTxNotFound    = errors.New("tx not found")
 	ErrAssetNotFound = errors.New("asset not found")
 	ErrOrderNotFound = errors.New("order not found")
+
+	ErrProposalNotFound = errors.New("proposal not found")
 )
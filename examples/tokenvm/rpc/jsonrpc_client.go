@@ -174,6 +174,50 @@ func (cli *JSONRPCClient) Loan(
 	return resp.Amount, err
 }
 
+func (cli *JSONRPCClient) Proposal(
+	ctx context.Context,
+	proposal ids.ID,
+) (bool, *ProposalReply, error) {
+	resp := new(ProposalReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"proposal",
+		&ProposalArgs{
+			Proposal: proposal,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrProposalNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+// Stake returns the stake registered on [nodeID]. If [addr] is not empty,
+// the position of [addr] on [nodeID] is also returned.
+func (cli *JSONRPCClient) Stake(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	addr string,
+) (*StakeReply, error) {
+	resp := new(StakeReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"stake",
+		&StakeArgs{
+			NodeID:  nodeID,
+			Address: addr,
+		},
+		resp,
+	)
+	return resp, err
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr string,
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/x/staking"
 )
 
 type JSONRPCServer struct {
@@ -186,3 +187,80 @@ func (j *JSONRPCServer) Loan(req *http.Request, args *LoanArgs, reply *LoanReply
 	reply.Amount = amount
 	return nil
 }
+
+type ProposalArgs struct {
+	Proposal ids.ID `json:"proposal"`
+}
+
+type ProposalReply struct {
+	Parameter uint8            `json:"parameter"`
+	Value     chain.Dimensions `json:"value"`
+	End       int64            `json:"end"`
+	Yes       uint64           `json:"yes"`
+	No        uint64           `json:"no"`
+	Executed  bool             `json:"executed"`
+}
+
+func (j *JSONRPCServer) Proposal(req *http.Request, args *ProposalArgs, reply *ProposalReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Proposal")
+	defer span.End()
+
+	exists, parameter, value, end, yes, no, executed, err := j.c.GetProposalFromState(ctx, args.Proposal)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProposalNotFound
+	}
+	reply.Parameter = parameter
+	reply.Value = value
+	reply.End = end
+	reply.Yes = yes
+	reply.No = no
+	reply.Executed = executed
+	return nil
+}
+
+type StakeArgs struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	Address string     `json:"address"` // optional
+}
+
+type StakeReply struct {
+	Validator   *staking.Validator `json:"validator"`
+	Stake       uint64             `json:"stake"`
+	IsValidator bool               `json:"isValidator"`
+
+	// Only populated if [StakeArgs.Address] is provided
+	Position       *staking.Position   `json:"position"`
+	PendingRewards uint64              `json:"pendingRewards"`
+	Withdrawal     *staking.Withdrawal `json:"withdrawal"`
+}
+
+func (j *JSONRPCServer) Stake(req *http.Request, args *StakeArgs, reply *StakeReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Stake")
+	defer span.End()
+
+	validator, stake, isValidator, err := j.c.GetValidatorStakeFromState(ctx, args.NodeID)
+	if err != nil {
+		return err
+	}
+	reply.Validator = validator
+	reply.Stake = stake
+	reply.IsValidator = isValidator
+	if len(args.Address) == 0 {
+		return nil
+	}
+	addr, err := codec.ParseAddressBech32(consts.HRP, args.Address)
+	if err != nil {
+		return err
+	}
+	position, rewards, withdrawal, err := j.c.GetStakePositionFromState(ctx, args.NodeID, addr)
+	if err != nil {
+		return err
+	}
+	reply.Position = position
+	reply.PendingRewards = rewards
+	reply.Withdrawal = withdrawal
+	return nil
+}
@@ -40,6 +40,18 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // 0x6/ (hypersdk-fee)
 // 0x7/ (hypersdk-incoming warp)
 // 0x8/ (hypersdk-outgoing warp)
+// 0x9/ (hypersdk-nonce)
+// 0xa/ (hypersdk-scheduler)
+// 0xb/ (proposals)
+//   -> [txID] => parameter|value|end|yes|no|executed
+// 0xc/ (votes)
+//   -> [proposalID|voter] => approve|amount|asset
+// 0xd/ (parameters)
+//   -> [parameter] => value
+// 0xe/ (staking)
+//   -> see x/staking
+// 0xf/ (sessions)
+//   -> see x/session
 
 const (
 	// metaDB
@@ -55,6 +67,13 @@ const (
 	feePrefix          = 0x6
 	incomingWarpPrefix = 0x7
 	outgoingWarpPrefix = 0x8
+	noncePrefix        = 0x9
+	schedulerPrefix    = 0xa
+	proposalPrefix     = 0xb
+	votePrefix         = 0xc
+	parameterPrefix    = 0xd
+	stakingPrefix      = 0xe
+	sessionPrefix      = 0xf
 )
 
 const (
@@ -62,6 +81,20 @@ const (
 	AssetChunks   uint16 = 5
 	OrderChunks   uint16 = 2
 	LoanChunks    uint16 = 1
+
+	ProposalChunks  uint16 = 2
+	VoteChunks      uint16 = 1
+	ParameterChunks uint16 = 1
+)
+
+// Rules parameters that can be modified by governance
+const (
+	MinUnitPriceParameter uint8 = iota
+	WindowTargetUnitsParameter
+	MaxBlockUnitsParameter
+	ValidityWindowParameter
+
+	NumParameters
 )
 
 var (
@@ -71,6 +104,11 @@ var (
 	timestampKey = []byte{timestampPrefix}
 	feeKey       = []byte{feePrefix}
 
+	// StakingPrefix is the prefix of all keys used by the staking module
+	StakingPrefix = []byte{stakingPrefix}
+	// SessionPrefix is the prefix of all keys used by the session module
+	SessionPrefix = []byte{sessionPrefix}
+
 	balanceKeyPool = sync.Pool{
 		New: func() any {
 			return make([]byte, 1+codec.AddressLen+consts.IDLen+consts.Uint16Len)
@@ -603,3 +641,213 @@ func OutgoingWarpKeyPrefix(txID ids.ID) (k []byte) {
 	copy(k[1:], txID[:])
 	return k
 }
+
+// [noncePrefix] + [address]
+func NonceKey(addr codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen)
+	k[0] = noncePrefix
+	copy(k[1:], addr[:])
+	return k
+}
+
+// [schedulerPrefix]
+func SchedulerPrefix() []byte {
+	return []byte{schedulerPrefix}
+}
+
+// [proposalPrefix] + [txID]
+func ProposalKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = proposalPrefix
+	copy(k[1:], txID[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], ProposalChunks)
+	return
+}
+
+func SetProposal(
+	ctx context.Context,
+	mu state.Mutable,
+	txID ids.ID,
+	parameter uint8,
+	value chain.Dimensions,
+	end int64,
+	yes uint64,
+	no uint64,
+	executed bool,
+) error {
+	k := ProposalKey(txID)
+	v := make([]byte, consts.ByteLen+chain.DimensionsLen+consts.Uint64Len*3+consts.BoolLen)
+	v[0] = parameter
+	copy(v[consts.ByteLen:], value.Bytes())
+	binary.BigEndian.PutUint64(v[consts.ByteLen+chain.DimensionsLen:], uint64(end))
+	binary.BigEndian.PutUint64(v[consts.ByteLen+chain.DimensionsLen+consts.Uint64Len:], yes)
+	binary.BigEndian.PutUint64(v[consts.ByteLen+chain.DimensionsLen+consts.Uint64Len*2:], no)
+	if executed {
+		v[consts.ByteLen+chain.DimensionsLen+consts.Uint64Len*3] = successByte
+	}
+	return mu.Insert(ctx, k, v)
+}
+
+func GetProposal(
+	ctx context.Context,
+	im state.Immutable,
+	proposal ids.ID,
+) (
+	bool, // exists
+	uint8, // parameter
+	chain.Dimensions, // value
+	int64, // end
+	uint64, // yes
+	uint64, // no
+	bool, // executed
+	error,
+) {
+	k := ProposalKey(proposal)
+	v, err := im.GetValue(ctx, k)
+	return innerGetProposal(v, err)
+}
+
+// Used to serve RPC queries
+func GetProposalFromState(
+	ctx context.Context,
+	f ReadState,
+	proposal ids.ID,
+) (
+	bool, // exists
+	uint8, // parameter
+	chain.Dimensions, // value
+	int64, // end
+	uint64, // yes
+	uint64, // no
+	bool, // executed
+	error,
+) {
+	values, errs := f(ctx, [][]byte{ProposalKey(proposal)})
+	return innerGetProposal(values[0], errs[0])
+}
+
+func innerGetProposal(v []byte, err error) (
+	bool, // exists
+	uint8, // parameter
+	chain.Dimensions, // value
+	int64, // end
+	uint64, // yes
+	uint64, // no
+	bool, // executed
+	error,
+) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, chain.Dimensions{}, 0, 0, 0, false, nil
+	}
+	if err != nil {
+		return false, 0, chain.Dimensions{}, 0, 0, 0, false, err
+	}
+	value, err := chain.UnpackDimensions(v[consts.ByteLen : consts.ByteLen+chain.DimensionsLen])
+	if err != nil {
+		return false, 0, chain.Dimensions{}, 0, 0, 0, false, err
+	}
+	end := int64(binary.BigEndian.Uint64(v[consts.ByteLen+chain.DimensionsLen:]))
+	yes := binary.BigEndian.Uint64(v[consts.ByteLen+chain.DimensionsLen+consts.Uint64Len:])
+	no := binary.BigEndian.Uint64(v[consts.ByteLen+chain.DimensionsLen+consts.Uint64Len*2:])
+	executed := v[consts.ByteLen+chain.DimensionsLen+consts.Uint64Len*3] == successByte
+	return true, v[0], value, end, yes, no, executed, nil
+}
+
+// [votePrefix] + [proposalID] + [voter]
+func VoteKey(proposal ids.ID, voter codec.Address) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+codec.AddressLen+consts.Uint16Len)
+	k[0] = votePrefix
+	copy(k[1:], proposal[:])
+	copy(k[1+consts.IDLen:], voter[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen+codec.AddressLen:], VoteChunks)
+	return
+}
+
+func SetVote(
+	ctx context.Context,
+	mu state.Mutable,
+	proposal ids.ID,
+	voter codec.Address,
+	approve bool,
+	amount uint64,
+	asset ids.ID,
+) error {
+	k := VoteKey(proposal, voter)
+	v := make([]byte, consts.BoolLen+consts.Uint64Len+consts.IDLen)
+	if approve {
+		v[0] = successByte
+	}
+	binary.BigEndian.PutUint64(v[consts.BoolLen:], amount)
+	copy(v[consts.BoolLen+consts.Uint64Len:], asset[:])
+	return mu.Insert(ctx, k, v)
+}
+
+func GetVote(
+	ctx context.Context,
+	im state.Immutable,
+	proposal ids.ID,
+	voter codec.Address,
+) (
+	bool, // exists
+	bool, // approve
+	uint64, // amount
+	ids.ID, // asset
+	error,
+) {
+	k := VoteKey(proposal, voter)
+	v, err := im.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, false, 0, ids.Empty, nil
+	}
+	if err != nil {
+		return false, false, 0, ids.Empty, err
+	}
+	var asset ids.ID
+	copy(asset[:], v[consts.BoolLen+consts.Uint64Len:])
+	return true, v[0] == successByte, binary.BigEndian.Uint64(v[consts.BoolLen:]), asset, nil
+}
+
+func DeleteVote(ctx context.Context, mu state.Mutable, proposal ids.ID, voter codec.Address) error {
+	k := VoteKey(proposal, voter)
+	return mu.Remove(ctx, k)
+}
+
+// [parameterPrefix] + [parameter]
+func ParameterKey(parameter uint8) (k []byte) {
+	k = make([]byte, 1+consts.ByteLen+consts.Uint16Len)
+	k[0] = parameterPrefix
+	k[1] = parameter
+	binary.BigEndian.PutUint16(k[1+consts.ByteLen:], ParameterChunks)
+	return
+}
+
+func SetParameter(
+	ctx context.Context,
+	mu state.Mutable,
+	parameter uint8,
+	value chain.Dimensions,
+) error {
+	k := ParameterKey(parameter)
+	return mu.Insert(ctx, k, value.Bytes())
+}
+
+// GetParameter returns the value of [parameter] set by governance (if any).
+func GetParameter(
+	ctx context.Context,
+	im state.Immutable,
+	parameter uint8,
+) (bool, chain.Dimensions, error) {
+	k := ParameterKey(parameter)
+	v, err := im.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, chain.Dimensions{}, nil
+	}
+	if err != nil {
+		return false, chain.Dimensions{}, err
+	}
+	value, err := chain.UnpackDimensions(v)
+	if err != nil {
+		return false, chain.Dimensions{}, err
+	}
+	return true, value, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
@@ -530,10 +531,10 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    aother,
 					Value: sendAmount,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -608,13 +609,13 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          auth.NewED25519Address(other.PublicKey()),
 					Asset:       ids.Empty,
 					Value:       sendAmount,
 					Return:      false,
 					Destination: destination,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -654,11 +655,11 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    auth.NewED25519Address(other.PublicKey()),
 					Asset: ids.Empty,
 					Value: 500_000_000,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -745,7 +746,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{},
+				[]chain.Action{&actions.ImportAsset{}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -810,13 +811,13 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          rsender,
 					Asset:       newAsset,
 					Value:       100,
 					Return:      false,
 					Destination: ids.GenerateTestID(),
-				},
+				}},
 				otherFactory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -851,14 +852,14 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          rsender,
 					Asset:       newAsset,
 					Value:       2000,
 					Return:      true,
 					Destination: source,
 					Reward:      100,
-				},
+				}},
 				otherFactory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -960,7 +961,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{},
+				[]chain.Action{&actions.ImportAsset{}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1020,13 +1021,13 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          auth.NewED25519Address(other.PublicKey()),
 					Asset:       newAsset,
 					Value:       2900,
 					Return:      true,
 					Destination: source,
-				},
+				}},
 				otherFactory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1122,7 +1123,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{},
+				[]chain.Action{&actions.ImportAsset{}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1181,7 +1182,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.ExportAsset{
+				[]chain.Action{&actions.ExportAsset{
 					To:          auth.NewED25519Address(other.PublicKey()),
 					Asset:       ids.Empty, // becomes newAsset
 					Value:       2000,
@@ -1191,7 +1192,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 					SwapOut:     200,
 					SwapExpiry:  time.Now().UnixMilli() + 100_000,
 					Destination: destination,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1272,9 +1273,9 @@ var _ = ginkgo.Describe("[Test]", func() {
 				context.Background(),
 				parser,
 				msg,
-				&actions.ImportAsset{
+				[]chain.Action{&actions.ImportAsset{
 					Fill: true,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -1533,10 +1534,10 @@ func generateBlocks(
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: 1,
-			},
+			}},
 			factory,
 		)
 		if failOnError {
@@ -1601,10 +1602,10 @@ func acceptTransaction(cli *rpc.JSONRPCClient, tcli *trpc.JSONRPCClient) {
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: sendAmount,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
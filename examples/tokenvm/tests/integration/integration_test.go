@@ -5,6 +5,7 @@ package integration_test
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"flag"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -37,10 +39,15 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/multisig"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/state"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/vm"
+	"github.com/ava-labs/hypersdk/x/session"
+	"github.com/ava-labs/hypersdk/x/staking"
 
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
@@ -48,6 +55,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/controller"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 )
 
 var (
@@ -71,6 +79,8 @@ func TestIntegration(t *testing.T) {
 	ginkgo.RunSpecs(t, "tokenvm integration test suites")
 }
 
+const adminToken = "admin"
+
 var (
 	requestTimeout time.Duration
 	vms            int
@@ -102,6 +112,8 @@ var (
 	rsender2 codec.Address
 	sender2  string
 
+	feeRecipient string
+
 	asset1         []byte
 	asset1Symbol   []byte
 	asset1Decimals uint8
@@ -122,18 +134,23 @@ var (
 
 	networkID uint32
 	gen       *genesis.Genesis
+	app       *appSender
 )
 
 type instance struct {
 	chainID            ids.ID
 	nodeID             ids.NodeID
+	snowCtx            *snow.Context
+	db                 database.Database
 	vm                 *vm.VM
 	toEngine           chan common.Message
 	JSONRPCServer      *httptest.Server
 	TokenJSONRPCServer *httptest.Server
 	WebSocketServer    *httptest.Server
+	AdminServer        *httptest.Server
 	cli                *rpc.JSONRPCClient // clients for embedded VMs
 	tcli               *trpc.JSONRPCClient
+	acli               *rpc.AdminJSONRPCClient
 }
 
 var _ = ginkgo.BeforeSuite(func() {
@@ -162,6 +179,10 @@ var _ = ginkgo.BeforeSuite(func() {
 		zap.String("pk", hex.EncodeToString(priv2[:])),
 	)
 
+	feePriv, err := ed25519.GeneratePrivateKey()
+	gomega.Ω(err).Should(gomega.BeNil())
+	feeRecipient = codec.MustAddressBech32(tconsts.HRP, auth.NewED25519Address(feePriv.PublicKey()))
+
 	asset1 = []byte("1")
 	asset1Symbol = []byte("s1")
 	asset1Decimals = uint8(1)
@@ -184,6 +205,9 @@ var _ = ginkgo.BeforeSuite(func() {
 			Balance: 10_000_000,
 		},
 	}
+	gen.PriorityFeeRecipient = feeRecipient
+	gen.GovernanceVotingPeriod = 3 * consts.MillisecondsPerSecond
+	gen.GovernanceQuorum = 10_000
 	genesisBytes, err = json.Marshal(gen)
 	gomega.Ω(err).Should(gomega.BeNil())
 
@@ -191,7 +215,7 @@ var _ = ginkgo.BeforeSuite(func() {
 	subnetID := ids.GenerateTestID()
 	chainID := ids.GenerateTestID()
 
-	app := &appSender{}
+	app = &appSender{}
 	for i := range instances {
 		nodeID := ids.GenerateTestNodeID()
 		sk, err := bls.NewSecretKey()
@@ -213,46 +237,7 @@ var _ = ginkgo.BeforeSuite(func() {
 			ValidatorState: &validators.TestState{},
 		}
 
-		toEngine := make(chan common.Message, 1)
-		db := memdb.New()
-
-		v := controller.New()
-		err = v.Initialize(
-			context.TODO(),
-			snowCtx,
-			db,
-			genesisBytes,
-			nil,
-			[]byte(
-				`{"parallelism":3, "testMode":true, "logLevel":"debug", "trackedPairs":["*"]}`,
-			),
-			toEngine,
-			nil,
-			app,
-		)
-		gomega.Ω(err).Should(gomega.BeNil())
-
-		var hd map[string]http.Handler
-		hd, err = v.CreateHandlers(context.TODO())
-		gomega.Ω(err).Should(gomega.BeNil())
-
-		jsonRPCServer := httptest.NewServer(hd[rpc.JSONRPCEndpoint])
-		tjsonRPCServer := httptest.NewServer(hd[trpc.JSONRPCEndpoint])
-		webSocketServer := httptest.NewServer(hd[rpc.WebSocketEndpoint])
-		instances[i] = instance{
-			chainID:            snowCtx.ChainID,
-			nodeID:             snowCtx.NodeID,
-			vm:                 v,
-			toEngine:           toEngine,
-			JSONRPCServer:      jsonRPCServer,
-			TokenJSONRPCServer: tjsonRPCServer,
-			WebSocketServer:    webSocketServer,
-			cli:                rpc.NewJSONRPCClient(jsonRPCServer.URL),
-			tcli:               trpc.NewJSONRPCClient(tjsonRPCServer.URL, snowCtx.NetworkID, snowCtx.ChainID),
-		}
-
-		// Force sync ready (to mimic bootstrapping from genesis)
-		v.ForceReady()
+		instances[i] = newInstance(snowCtx, memdb.New(), app)
 	}
 
 	// Verify genesis allocates loaded correctly (do here otherwise test may
@@ -285,13 +270,91 @@ var _ = ginkgo.BeforeSuite(func() {
 	color.Blue("created %d VMs", vms)
 })
 
+// newInstance initializes a tokenvm with [snowCtx] and [db]. If [db] and
+// [snowCtx.ChainDataDir] were used by an instance that was shutdown, the new
+// instance resumes from where it stopped (like a node restart).
+func newInstance(snowCtx *snow.Context, db database.Database, app *appSender) instance {
+	toEngine := make(chan common.Message, 1)
+	v := controller.New()
+	err := v.Initialize(
+		context.TODO(),
+		snowCtx,
+		db,
+		genesisBytes,
+		nil,
+		[]byte(
+			`{"parallelism":3, "testMode":true, "logLevel":"debug", "trackedPairs":["*"], "receiptWindow":16, "optimisticExecution":true, "mempoolPersistFrequency":100000000, "mempoolAdminToken":"`+adminToken+`"}`,
+		),
+		toEngine,
+		nil,
+		app,
+	)
+	gomega.Ω(err).Should(gomega.BeNil())
+
+	var hd map[string]http.Handler
+	hd, err = v.CreateHandlers(context.TODO())
+	gomega.Ω(err).Should(gomega.BeNil())
+
+	jsonRPCServer := httptest.NewServer(hd[rpc.JSONRPCEndpoint])
+	tjsonRPCServer := httptest.NewServer(hd[trpc.JSONRPCEndpoint])
+	webSocketServer := httptest.NewServer(hd[rpc.WebSocketEndpoint])
+	adminServer := httptest.NewServer(hd[rpc.AdminEndpoint])
+
+	// Force sync ready (to mimic bootstrapping from genesis)
+	v.ForceReady()
+	return instance{
+		chainID:            snowCtx.ChainID,
+		nodeID:             snowCtx.NodeID,
+		snowCtx:            snowCtx,
+		db:                 db,
+		vm:                 v,
+		toEngine:           toEngine,
+		JSONRPCServer:      jsonRPCServer,
+		TokenJSONRPCServer: tjsonRPCServer,
+		WebSocketServer:    webSocketServer,
+		AdminServer:        adminServer,
+		cli:                rpc.NewJSONRPCClient(jsonRPCServer.URL),
+		tcli:               trpc.NewJSONRPCClient(tjsonRPCServer.URL, snowCtx.NetworkID, snowCtx.ChainID),
+		acli:               rpc.NewAdminJSONRPCClient(adminServer.URL, adminToken),
+	}
+}
+
+// closeInstance shuts down [inst] and its servers.
+func closeInstance(inst instance) {
+	inst.JSONRPCServer.Close()
+	inst.TokenJSONRPCServer.Close()
+	inst.WebSocketServer.Close()
+	inst.AdminServer.Close()
+	err := inst.vm.Shutdown(context.TODO())
+	gomega.Ω(err).Should(gomega.BeNil())
+}
+
+// restartInstance shuts down instances[i] and replaces it with a new instance
+// that uses the same storage.
+func restartInstance(i int) {
+	inst := instances[i]
+	closeInstance(inst)
+
+	// Metrics can't be registered twice with the same gatherer
+	prev := inst.snowCtx
+	snowCtx := &snow.Context{
+		NetworkID:      prev.NetworkID,
+		SubnetID:       prev.SubnetID,
+		ChainID:        prev.ChainID,
+		NodeID:         prev.NodeID,
+		Log:            prev.Log,
+		ChainDataDir:   prev.ChainDataDir,
+		Metrics:        metrics.NewOptionalGatherer(),
+		PublicKey:      prev.PublicKey,
+		WarpSigner:     prev.WarpSigner,
+		ValidatorState: prev.ValidatorState,
+	}
+	instances[i] = newInstance(snowCtx, inst.db, app)
+}
+
 var _ = ginkgo.AfterSuite(func() {
 	for _, iv := range instances {
-		iv.JSONRPCServer.Close()
-		iv.TokenJSONRPCServer.Close()
-		iv.WebSocketServer.Close()
-		err := iv.vm.Shutdown(context.TODO())
-		gomega.Ω(err).Should(gomega.BeNil())
+		closeInstance(iv)
 	}
 })
 
@@ -337,10 +400,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 100_000, // must be more than StateLockup
-				},
+				}},
 				factory,
 			)
 			transferTxRoot = transferTx
@@ -370,16 +433,16 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 					MaxFee:    1000,
 				},
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 110,
-				},
+				}},
 			)
 			// Must do manual construction to avoid `tx.Sign` error (would fail with
 			// 0 timestamp)
 			msg, err := tx.Digest()
 			gomega.Ω(err).To(gomega.BeNil())
-			auth, err := factory.Sign(msg, tx.Action)
+			auth, err := factory.Sign(msg, tx.Actions)
 			gomega.Ω(err).To(gomega.BeNil())
 			tx.Auth = auth
 			p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -427,7 +490,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			results := blk.(*chain.StatelessBlock).Results()
 			gomega.Ω(results).Should(gomega.HaveLen(1))
 			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
-			gomega.Ω(results[0].Output).Should(gomega.BeNil())
+			gomega.Ω(results[0].Outputs[0]).Should(gomega.BeNil())
 
 			// Unit explanation
 			//
@@ -436,19 +499,19 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			// read: 2 keys reads, 1 had 0 chunks
 			// allocate: 1 key created
 			// write: 1 key modified, 1 key new
-			transferTxConsumed := chain.Dimensions{227, 7, 12, 25, 26}
+			transferTxConsumed := chain.Dimensions{247, 7, 12, 25, 26}
 			gomega.Ω(results[0].Consumed).Should(gomega.Equal(transferTxConsumed))
 
 			// Fee explanation
 			//
 			// Multiply all unit consumption by 1 and sum
-			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(297)))
+			gomega.Ω(results[0].Fee).Should(gomega.Equal(uint64(317)))
 		})
 
 		ginkgo.By("ensure balance is updated", func() {
 			balance, err := instances[1].tcli.Balance(context.Background(), sender, ids.Empty)
 			gomega.Ω(err).To(gomega.BeNil())
-			gomega.Ω(balance).To(gomega.Equal(uint64(9899703)))
+			gomega.Ω(balance).To(gomega.Equal(uint64(9899683)))
 			balance2, err := instances[1].tcli.Balance(context.Background(), sender2, ids.Empty)
 			gomega.Ω(err).To(gomega.BeNil())
 			gomega.Ω(balance2).To(gomega.Equal(uint64(100000)))
@@ -463,10 +526,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 101,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -493,10 +556,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 200,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -508,10 +571,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 201,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -538,10 +601,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				context.Background(),
 				parser,
 				nil,
-				&actions.Transfer{
+				[]chain.Action{&actions.Transfer{
 					To:    rsender2,
 					Value: 203,
-				},
+				}},
 				factory,
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
@@ -640,7 +703,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			transfer,
+			[]chain.Action{transfer},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -656,7 +719,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		blk, lresults, prices, err := cli.ListenBlock(context.TODO(), parser)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(len(blk.Txs)).Should(gomega.Equal(1))
-		tx := blk.Txs[0].Action.(*actions.Transfer)
+		tx := blk.Txs[0].Actions[0].(*actions.Transfer)
 		gomega.Ω(tx.Asset).To(gomega.Equal(ids.Empty))
 		gomega.Ω(tx.Value).To(gomega.Equal(uint64(1)))
 		gomega.Ω(lresults).Should(gomega.Equal(results))
@@ -689,7 +752,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			transfer,
+			[]chain.Action{transfer},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -733,11 +796,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: 10,
 				Memo:  []byte("hello"),
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -759,17 +822,17 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1001,
 			},
 			nil,
-			&actions.Transfer{
+			[]chain.Action{&actions.Transfer{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Value: 10,
 				Memo:  make([]byte, 1000),
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -792,11 +855,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Asset: assetID,
 				Value: 10,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -806,7 +869,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Outputs[0])).
 			Should(gomega.ContainSubstring("asset missing"))
 
 		exists, _, _, _, _, _, _, err := instances[0].tcli.Asset(context.TODO(), assetID, false)
@@ -822,17 +885,17 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1001,
 			},
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   []byte("s0"),
 				Decimals: 0,
 				Metadata: nil,
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -853,17 +916,17 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1001,
 			},
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   nil,
 				Decimals: 0,
 				Metadata: []byte("m"),
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -884,17 +947,17 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   []byte("s0"),
 				Decimals: 0,
 				Metadata: make([]byte, actions.MaxMetadataSize*2),
-			},
+			}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// too large)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -914,11 +977,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateAsset{
+			[]chain.Action{&actions.CreateAsset{
 				Symbol:   asset1Symbol,
 				Decimals: asset1Decimals,
 				Metadata: asset1,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -951,11 +1014,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    rsender2,
 				Asset: asset1ID,
 				Value: 15,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -992,11 +1055,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.MintAsset{
 				To:    auth.NewED25519Address(other.PublicKey()),
 				Asset: asset1ID,
 				Value: 10,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1006,7 +1069,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Outputs[0])).
 			Should(gomega.ContainSubstring("wrong owner"))
 
 		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
@@ -1020,122 +1083,140 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(warp).Should(gomega.BeFalse())
 	})
 
-	ginkgo.It("burn new asset", func() {
+	ginkgo.It("revert all actions if any action fails", func() {
 		parser, err := instances[0].tcli.Parser(context.Background())
 		gomega.Ω(err).Should(gomega.BeNil())
 		submit, _, _, err := instances[0].cli.GenerateTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.BurnAsset{
-				Asset: asset1ID,
-				Value: 5,
+			[]chain.Action{
+				&actions.MintAsset{
+					To:    rsender2,
+					Asset: asset1ID,
+					Value: 5,
+				},
+				&actions.Transfer{
+					To:    rsender2,
+					Asset: asset1ID,
+					Value: 100, // sender does not hold any of [asset1ID]
+				},
 			},
-			factory2,
+			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
 		accept := expectBlk(instances[0])
 		results := accept(false)
 		gomega.Ω(results).Should(gomega.HaveLen(1))
-		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(result.Outputs).Should(gomega.HaveLen(2))
+		gomega.Ω(result.Outputs[0]).Should(gomega.BeNil())
+		gomega.Ω(string(result.Outputs[1])).
+			Should(gomega.ContainSubstring("invalid balance"))
 
+		// Mint should be reverted
 		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, asset1ID)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
-		balance, err = instances[0].tcli.Balance(context.TODO(), sender, asset1ID)
-		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
-
-		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
+		gomega.Ω(balance).Should(gomega.Equal(uint64(15)))
+		_, _, _, _, supply, _, _, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(symbol).Should(gomega.Equal(asset1Symbol))
-		gomega.Ω(decimals).Should(gomega.Equal(asset1Decimals))
-		gomega.Ω(metadata).Should(gomega.Equal(asset1))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(10)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(supply).Should(gomega.Equal(uint64(15)))
 	})
 
-	ginkgo.It("burn missing asset", func() {
+	ginkgo.It("sponsor pays fees of sponsored transaction", func() {
 		parser, err := instances[0].tcli.Parser(context.Background())
 		gomega.Ω(err).Should(gomega.BeNil())
-		submit, _, _, err := instances[0].cli.GenerateTransaction(
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		submit, tx, maxFee, err := instances[0].cli.GenerateSponsoredTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.BurnAsset{
-				Asset: asset1ID,
-				Value: 10,
-			},
+			[]chain.Action{&actions.Transfer{
+				To:    rsender,
+				Asset: ids.Empty,
+				Value: 1,
+			}},
+			factory2,
+			rsender,
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(tx.Auth.Actor()).Should(gomega.Equal(rsender2))
+		gomega.Ω(tx.Sponsor()).Should(gomega.Equal(rsender))
 		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
 		accept := expectBlk(instances[0])
 		results := accept(false)
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
-		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
-			Should(gomega.ContainSubstring("invalid balance"))
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		gomega.Ω(result.Fee).Should(gomega.BeNumerically("<=", maxFee))
 
-		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
+		// Actor should only pay for the transfer
+		nbalance2, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(symbol).Should(gomega.Equal(asset1Symbol))
-		gomega.Ω(decimals).Should(gomega.Equal(asset1Decimals))
-		gomega.Ω(metadata).Should(gomega.Equal(asset1))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(10)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(nbalance2).Should(gomega.Equal(balance2 - 1))
+		nbalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nbalance).Should(gomega.Equal(balance + 1 - result.Fee))
 	})
 
-	ginkgo.It("rejects empty mint", func() {
-		other, err := ed25519.GeneratePrivateKey()
+	ginkgo.It("reject sponsored transaction with mismatched sponsor", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
 		gomega.Ω(err).Should(gomega.BeNil())
-		tx := chain.NewTx(
+		actionRegistry, authRegistry := parser.Registry()
+		tx := chain.NewSponsoredTx(
 			&chain.Base{
 				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				Timestamp: 1_000,
 				MaxFee:    1000,
 			},
 			nil,
-			&actions.MintAsset{
-				To:    auth.NewED25519Address(other.PublicKey()),
-				Asset: asset1ID,
-			},
-		)
-		// Must do manual construction to avoid `tx.Sign` error (would fail with
-		// bad codec)
-		msg, err := tx.Digest()
-		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
-		gomega.Ω(err).To(gomega.BeNil())
-		tx.Auth = auth
-		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
-		gomega.Ω(tx.Marshal(p)).To(gomega.BeNil())
-		gomega.Ω(p.Err()).To(gomega.BeNil())
-		_, err = instances[0].cli.SubmitTx(
-			context.Background(),
-			p.Bytes(),
+			[]chain.Action{&actions.Transfer{
+				To:    rsender,
+				Asset: ids.Empty,
+				Value: 1,
+			}},
+			rsender,
 		)
-		gomega.Ω(err.Error()).Should(gomega.ContainSubstring("Uint64 field is not populated"))
+		// Signature from [factory2] can't be used to pay for [rsender]
+		_, err = tx.SignSponsored(factory2, factory2, actionRegistry, authRegistry)
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(chain.ErrInvalidSponsor.Error())))
 	})
 
-	ginkgo.It("reject max mint", func() {
+	ginkgo.It("transfer from multisig account", func() {
 		parser, err := instances[0].tcli.Parser(context.Background())
 		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Create 2-of-3 account controlled by mixed keys
+		spriv, err := secp256r1.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		msFactory, err := auth.Multisig.NewFactory(2, []multisig.PublicKey{
+			multisig.NewED25519PublicKey(priv.PublicKey()),
+			multisig.NewED25519PublicKey(priv2.PublicKey()),
+			multisig.NewSECP256R1PublicKey(spriv.PublicKey()),
+		})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(msFactory.AddED25519PrivateKey(priv2)).Should(gomega.BeNil())
+		gomega.Ω(msFactory.AddSECP256R1PrivateKey(spriv)).Should(gomega.BeNil())
+		msAddr := msFactory.Address()
+		msSender := codec.MustAddressBech32(tconsts.HRP, msAddr)
+
+		// Fund account
 		submit, _, _, err := instances[0].cli.GenerateTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
-				To:    rsender2,
-				Asset: asset1ID,
-				Value: consts.MaxUint64,
-			},
+			[]chain.Action{&actions.Transfer{
+				To:    msAddr,
+				Asset: ids.Empty,
+				Value: 10_000,
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1143,73 +1224,60 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		accept := expectBlk(instances[0])
 		results := accept(false)
 		gomega.Ω(results).Should(gomega.HaveLen(1))
-		result := results[0]
-		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
-			Should(gomega.ContainSubstring("overflow"))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 
-		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, asset1ID)
+		// Spend from account
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
-		balance, err = instances[0].tcli.Balance(context.TODO(), sender, asset1ID)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender2,
+				Asset: ids.Empty,
+				Value: 1_000,
+			}},
+			msFactory,
+		)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
 
-		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
+		balance, err := instances[0].tcli.Balance(context.TODO(), msSender, ids.Empty)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(symbol).Should(gomega.Equal(asset1Symbol))
-		gomega.Ω(decimals).Should(gomega.Equal(asset1Decimals))
-		gomega.Ω(metadata).Should(gomega.Equal(asset1))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(10)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
-	})
-
-	ginkgo.It("rejects mint of native token", func() {
-		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(balance).Should(gomega.Equal(9_000 - result.Fee))
+		nbalance2, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
 		gomega.Ω(err).Should(gomega.BeNil())
-		tx := chain.NewTx(
-			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				MaxFee:    1000,
-			},
-			nil,
-			&actions.MintAsset{
-				To:    auth.NewED25519Address(other.PublicKey()),
-				Value: 10,
-			},
-		)
-		// Must do manual construction to avoid `tx.Sign` error (would fail with
-		// bad codec)
-		msg, err := tx.Digest()
-		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
-		gomega.Ω(err).To(gomega.BeNil())
-		tx.Auth = auth
-		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
-		gomega.Ω(tx.Marshal(p)).To(gomega.BeNil())
-		gomega.Ω(p.Err()).To(gomega.BeNil())
-		_, err = instances[0].cli.SubmitTx(
-			context.Background(),
-			p.Bytes(),
-		)
-		gomega.Ω(err.Error()).Should(gomega.ContainSubstring("ID field is not populated"))
+		gomega.Ω(nbalance2).Should(gomega.Equal(balance2 + 1_000))
 	})
 
-	ginkgo.It("mints another new asset (to self)", func() {
+	ginkgo.It("transfer with session key", func() {
 		parser, err := instances[0].tcli.Parser(context.Background())
 		gomega.Ω(err).Should(gomega.BeNil())
-		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+
+		// Create session account owned by [priv]
+		ownerFactory := auth.Session.NewOwnerFactory(priv)
+		sessionAddr := ownerFactory.Address()
+		sessionSender := codec.MustAddressBech32(tconsts.HRP, sessionAddr)
+		sessionPriv, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		expiry := time.Now().Add(time.Hour).UnixMilli()
+
+		// Fund account
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateAsset{
-				Symbol:   asset2Symbol,
-				Decimals: asset2Decimals,
-				Metadata: asset2,
-			},
+			[]chain.Action{&actions.Transfer{
+				To:    sessionAddr,
+				Asset: ids.Empty,
+				Value: 10_000,
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1218,18 +1286,18 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		results := accept(false)
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
-		asset2ID = tx.ID()
 
+		// Register session key
 		submit, _, _, err = instances[0].cli.GenerateTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
-				To:    rsender,
-				Asset: asset2ID,
-				Value: 10,
-			},
-			factory,
+			[]chain.Action{auth.Session.NewRegister(sessionPriv.PublicKey(), &session.Permissions{
+				Expiry:  expiry,
+				Actions: []uint8{(&actions.Transfer{}).GetTypeID()},
+				Caps:    []session.Cap{{Asset: ids.Empty, Amount: 2_000}}, // includes fees
+			})},
+			ownerFactory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
@@ -1238,43 +1306,65 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 
-		balance, err := instances[0].tcli.Balance(context.TODO(), sender, asset2ID)
-		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
-	})
-
-	ginkgo.It("mints another new asset (to self) on another account", func() {
-		parser, err := instances[0].tcli.Parser(context.Background())
+		// Spend with session key
+		sessionFactory := auth.Session.NewSessionFactory(priv.PublicKey(), sessionPriv, expiry)
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
 		gomega.Ω(err).Should(gomega.BeNil())
-		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateAsset{
-				Symbol:   asset3Symbol,
-				Decimals: asset3Decimals,
-				Metadata: asset3,
-			},
-			factory2,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender2,
+				Asset: ids.Empty,
+				Value: 1_000,
+			}},
+			sessionFactory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
-		accept := expectBlk(instances[0])
-		results := accept(false)
+		accept = expectBlk(instances[0])
+		results = accept(false)
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
-		asset3ID = tx.ID()
+		nbalance2, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nbalance2).Should(gomega.Equal(balance2 + 1_000))
 
+		// Can't exceed cap (including what was spent on the previous transfer
+		// and its fee)
 		submit, _, _, err = instances[0].cli.GenerateTransaction(
 			context.Background(),
 			parser,
 			nil,
-			&actions.MintAsset{
+			[]chain.Action{&actions.Transfer{
 				To:    rsender2,
-				Asset: asset3ID,
-				Value: 10,
-			},
-			factory2,
+				Asset: ids.Empty,
+				Value: 999,
+			}},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.MatchError(gomega.ContainSubstring(session.ErrSpendCapExceeded.Error())))
+
+		// Can't revoke session keys
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{auth.Session.NewRevoke(sessionPriv.PublicKey())},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.MatchError(gomega.ContainSubstring(session.ErrActionNotAllowed.Error())))
+
+		// Revoke session key
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{auth.Session.NewRevoke(sessionPriv.PublicKey())},
+			ownerFactory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
@@ -1283,7 +1373,1268 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 
-		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, asset3ID)
+		// Can't use revoked session key
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender2,
+				Asset: ids.Empty,
+				Value: 18, // must differ from accepted tx
+			}},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.MatchError(gomega.ContainSubstring(session.ErrUnknownSession.Error())))
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sessionSender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", 9_000))
+	})
+
+	ginkgo.It("simulates transaction without submitting it", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Simulate failing transaction
+		_, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender,
+				Asset: ids.Empty,
+				Value: balance + 1,
+			}},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		simulated, err := instances[0].cli.SimulateTx(context.Background(), tx.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(simulated.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(simulated.Outputs[0])).Should(gomega.ContainSubstring("invalid balance"))
+		nbalance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nbalance).Should(gomega.Equal(balance))
+
+		// Simulate transaction and then submit it
+		submit, tx, maxFee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender,
+				Asset: ids.Empty,
+				Value: 1,
+			}},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		simulated, err = instances[0].cli.SimulateTx(context.Background(), tx.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(simulated.Success).Should(gomega.BeTrue())
+		gomega.Ω(simulated.Fee).Should(gomega.BeNumerically("<=", maxFee))
+		gomega.Ω(simulated.ReadKeys).Should(gomega.ContainElement(storage.BalanceKey(rsender2, ids.Empty)))
+		gomega.Ω(simulated.WrittenKeys).Should(gomega.ContainElement(storage.BalanceKey(rsender, ids.Empty)))
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].Consumed).Should(gomega.Equal(simulated.Consumed))
+		gomega.Ω(results[0].Fee).Should(gomega.Equal(simulated.Fee))
+	})
+
+	ginkgo.It("executes transaction with access list", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		transfer := []chain.Action{&actions.Transfer{
+			To:    rsender,
+			Asset: ids.Empty,
+			Value: 3,
+		}}
+
+		// All keys accessed by [actions.Transfer] are specified
+		submit, tx, _, err := instances[0].cli.GenerateTransactionWithDiscovery(
+			context.Background(),
+			parser,
+			nil,
+			transfer,
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(tx.AccessList).Should(gomega.BeEmpty())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Keys in the access list can be accessed (and are charged for)
+		accessList := [][]byte{storage.AssetKey(ids.Empty)}
+		rules := parser.Rules(time.Now().UnixMilli())
+		maxUnits, err := chain.EstimateMaxUnits(rules, transfer, factory2, nil)
+		gomega.Ω(err).Should(gomega.BeNil())
+		accessListUnits, err := chain.EstimateAccessListUnits(rules, accessList)
+		gomega.Ω(err).Should(gomega.BeNil())
+		maxUnits, err = chain.Add(maxUnits, accessListUnits)
+		gomega.Ω(err).Should(gomega.BeNil())
+		unitPrices, err := instances[0].cli.UnitPrices(context.Background(), false)
+		gomega.Ω(err).Should(gomega.BeNil())
+		maxFee, err := chain.MulSum(unitPrices, maxUnits)
+		gomega.Ω(err).Should(gomega.BeNil())
+		actionRegistry, authRegistry := parser.Registry()
+		tx = chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxFee:    maxFee,
+			},
+			nil,
+			transfer,
+		)
+		tx.AccessList = accessList
+		tx, err = tx.Sign(factory2, actionRegistry, authRegistry)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(tx.AccessList).Should(gomega.Equal(accessList))
+		stateKeys, err := tx.StateKeys(instances[0].vm.StateManager())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stateKeys).Should(gomega.HaveKeyWithValue(string(accessList[0]), state.All))
+		gomega.Ω(instances[0].vm.Submit(context.Background(), true, []*chain.Transaction{tx})).Should(gomega.Equal([]error{nil}))
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].Consumed[chain.Bandwidth]).Should(gomega.Equal(uint64(tx.Size())))
+		gomega.Ω(results[0].Fee).Should(gomega.BeNumerically("<=", maxFee))
+	})
+
+	ginkgo.It("executes transactions with nonces in order", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		nonce, err := instances[0].cli.Nonce(context.Background(), rsender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nonce).Should(gomega.BeZero())
+
+		generate := func(nonce uint64, value uint64) func(context.Context) error {
+			submit, tx, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{&actions.Transfer{
+					To:    rsender,
+					Asset: ids.Empty,
+					Value: value,
+				}},
+				factory2,
+				rpc.WithNonce(nonce),
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(tx.Nonce()).Should(gomega.Equal(nonce))
+			return submit
+		}
+
+		// Transaction with a nonce gap is held in the mempool
+		gomega.Ω(generate(2, 1)(context.Background())).Should(gomega.BeNil())
+		gomega.Ω(generate(1, 1)(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(2))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[1].Success).Should(gomega.BeTrue())
+		gomega.Ω(instances[0].vm.Mempool().Len(context.Background())).Should(gomega.BeZero())
+
+		nonce, err = instances[0].cli.Nonce(context.Background(), rsender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nonce).Should(gomega.Equal(uint64(2)))
+
+		// Used nonces can't be reused
+		gomega.Ω(generate(2, 2)(context.Background())).Should(gomega.MatchError(gomega.ContainSubstring(chain.ErrNonceTooLow.Error())))
+	})
+
+	ginkgo.It("replaces and cancels pending transactions", func() {
+		cli, err := rpc.NewWebSocketClient(instances[0].WebSocketServer.URL, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		gomega.Ω(err).Should(gomega.BeNil())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		transfer := func(value uint64) []chain.Action {
+			return []chain.Action{&actions.Transfer{
+				To:    rsender2,
+				Asset: ids.Empty,
+				Value: value,
+			}}
+		}
+		waitMempool := func(l int) {
+			for instances[0].vm.Mempool().Len(context.TODO()) != l {
+				time.Sleep(100 * time.Millisecond)
+			}
+		}
+
+		// Replacement must pay a higher fee
+		_, pending, maxFee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			transfer(19),
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(cli.RegisterTx(pending)).Should(gomega.BeNil())
+		waitMempool(1)
+		submit, _, err := instances[0].cli.GenerateTransactionManual(
+			parser,
+			nil,
+			transfer(20),
+			factory,
+			maxFee,
+			rpc.WithReplaces(pending.ID()),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.MatchError(gomega.ContainSubstring(vm.ErrReplacementFee.Error())))
+		submit, replacement, err := instances[0].cli.GenerateTransactionManual(
+			parser,
+			nil,
+			transfer(20),
+			factory,
+			maxFee+1,
+			rpc.WithReplaces(pending.ID()),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		txID, dErr, _, err := cli.ListenTx(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(txID).Should(gomega.Equal(pending.ID()))
+		gomega.Ω(dErr).Should(gomega.MatchError(vm.ErrReplaced.Error()))
+		gomega.Ω(instances[0].vm.Mempool().Len(context.TODO())).Should(gomega.Equal(1))
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Txs[0].ID()).Should(gomega.Equal(replacement.ID()))
+
+		// Replaced transactions can't be re-added
+		_, err = instances[0].cli.SubmitTx(context.Background(), pending.Bytes())
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(vm.ErrReplaced.Error())))
+
+		// Cancel a pending transaction
+		_, pending, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			transfer(21),
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(cli.RegisterTx(pending)).Should(gomega.BeNil())
+		waitMempool(1)
+		submit, cancel, _, err := instances[0].cli.GenerateCancelTransaction(
+			context.Background(),
+			parser,
+			pending,
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(cancel.Cancels()).Should(gomega.BeTrue())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		txID, dErr, _, err = cli.ListenTx(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(txID).Should(gomega.Equal(pending.ID()))
+		gomega.Ω(dErr).Should(gomega.MatchError(vm.ErrCanceled.Error()))
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].Outputs).Should(gomega.BeEmpty())
+		blk = instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Txs[0].ID()).Should(gomega.Equal(cancel.ID()))
+
+		// Close connection when done
+		gomega.Ω(cli.Close()).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("inspects and evicts pending transactions", func() {
+		cli, err := rpc.NewWebSocketClient(instances[0].WebSocketServer.URL, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		gomega.Ω(err).Should(gomega.BeNil())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		generate := func(value uint64, factory chain.AuthFactory) *chain.Transaction {
+			_, tx, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{&actions.Transfer{
+					To:    rsender,
+					Asset: ids.Empty,
+					Value: value,
+				}},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(cli.RegisterTx(tx)).Should(gomega.BeNil())
+			return tx
+		}
+		tx1 := generate(22, factory)
+		tx2 := generate(23, factory)
+		other := generate(24, factory2)
+		for instances[0].vm.Mempool().Len(context.TODO()) != 3 {
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		// Filter and paginate pending transactions
+		pending, total, err := instances[0].cli.PendingTxs(context.Background(), &rpc.PendingTxsArgs{})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(total).Should(gomega.Equal(3))
+		gomega.Ω(pending).Should(gomega.HaveLen(3))
+		for i, ptx := range pending {
+			gomega.Ω(ptx.Position).Should(gomega.Equal(i))
+		}
+		gomega.Ω(pending[0].TxID).Should(gomega.Equal(tx1.ID()))
+		gomega.Ω(pending[0].Expiry).Should(gomega.Equal(tx1.Expiry()))
+		gomega.Ω(pending[0].Tx).Should(gomega.Equal(tx1.Bytes()))
+		pending, total, err = instances[0].cli.PendingTxs(context.Background(), &rpc.PendingTxsArgs{Sponsor: rsender, Offset: 1, Limit: 1})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(total).Should(gomega.Equal(2))
+		gomega.Ω(pending).Should(gomega.HaveLen(1))
+		gomega.Ω(pending[0].TxID).Should(gomega.Equal(tx2.ID()))
+		gomega.Ω(pending[0].Position).Should(gomega.Equal(1))
+		transferID := (&actions.Transfer{}).GetTypeID()
+		_, total, err = instances[0].cli.PendingTxs(context.Background(), &rpc.PendingTxsArgs{ActionID: &transferID})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(total).Should(gomega.Equal(3))
+		burnID := (&actions.BurnAsset{}).GetTypeID()
+		pending, total, err = instances[0].cli.PendingTxs(context.Background(), &rpc.PendingTxsArgs{ActionID: &burnID})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(total).Should(gomega.BeZero())
+		gomega.Ω(pending).Should(gomega.BeEmpty())
+		sponsors, err := instances[0].cli.PendingSponsors(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(sponsors).Should(gomega.Equal([]*rpc.PendingSponsor{
+			{Sponsor: rsender, Txs: 2},
+			{Sponsor: rsender2, Txs: 1},
+		}))
+
+		// Admin requests must be authenticated
+		_, err = rpc.NewAdminJSONRPCClient(instances[0].AdminServer.URL, "wrong").EvictTxs(context.Background(), []ids.ID{tx1.ID()})
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(rpc.ErrUnauthorized.Error())))
+		gomega.Ω(instances[0].vm.Mempool().Len(context.TODO())).Should(gomega.Equal(3))
+
+		// Evict a single transaction
+		evicted, err := instances[0].acli.EvictTxs(context.Background(), []ids.ID{tx1.ID(), ids.GenerateTestID()})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(evicted).Should(gomega.Equal([]ids.ID{tx1.ID()}))
+		txID, dErr, _, err := cli.ListenTx(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(txID).Should(gomega.Equal(tx1.ID()))
+		gomega.Ω(dErr).Should(gomega.MatchError(vm.ErrEvicted.Error()))
+		_, err = instances[0].cli.SubmitTx(context.Background(), tx1.Bytes())
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(vm.ErrEvicted.Error())))
+
+		// Evict the rest of a sponsor's backlog
+		evicted, err = instances[0].acli.EvictSponsor(context.Background(), rsender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(evicted).Should(gomega.Equal([]ids.ID{tx2.ID()}))
+		txID, dErr, _, err = cli.ListenTx(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(txID).Should(gomega.Equal(tx2.ID()))
+		gomega.Ω(dErr).Should(gomega.MatchError(vm.ErrEvicted.Error()))
+		gomega.Ω(instances[0].vm.Mempool().Len(context.TODO())).Should(gomega.Equal(1))
+
+		// Other sponsors are unaffected
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Txs[0].ID()).Should(gomega.Equal(other.ID()))
+		txID, dErr, _, err = cli.ListenTx(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(txID).Should(gomega.Equal(other.ID()))
+		gomega.Ω(dErr).Should(gomega.BeNil())
+
+		// Close connection when done
+		gomega.Ω(cli.Close()).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("restores pending transactions after restart", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		generate := func(value uint64, factory chain.AuthFactory) *chain.Transaction {
+			submit, tx, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{&actions.Transfer{
+					To:    rsender,
+					Asset: ids.Empty,
+					Value: value,
+				}},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			return tx
+		}
+		tx1 := generate(25, factory)
+		tx2 := generate(26, factory)
+		tx3 := generate(27, factory2)
+		gomega.Ω(instances[0].vm.Mempool().Len(context.TODO())).Should(gomega.Equal(3))
+
+		// Evicted transactions are not restored
+		evicted, err := instances[0].acli.EvictTxs(context.Background(), []ids.ID{tx2.ID()})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(evicted).Should(gomega.Equal([]ids.ID{tx2.ID()}))
+
+		// Pending transactions are persisted on shutdown and restored once
+		// the restarted node is ready
+		restartInstance(0)
+		for instances[0].vm.Mempool().Len(context.TODO()) != 2 {
+			log.Info("waiting for txs to be restored")
+			time.Sleep(100 * time.Millisecond)
+		}
+		pending, total, err := instances[0].cli.PendingTxs(context.Background(), &rpc.PendingTxsArgs{})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(total).Should(gomega.Equal(2))
+		restored := []ids.ID{}
+		for _, ptx := range pending {
+			restored = append(restored, ptx.TxID)
+		}
+		gomega.Ω(restored).Should(gomega.ConsistOf(tx1.ID(), tx3.ID()))
+
+		// Restored transactions are included like any other transaction
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(2))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[1].Success).Should(gomega.BeTrue())
+		gomega.Ω(instances[0].vm.Mempool().Len(context.TODO())).Should(gomega.BeZero())
+	})
+
+	ginkgo.It("rejects transactions from insolvent sponsors", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		priv3, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		factory3 := auth.NewED25519Factory(priv3)
+		rsender3 := auth.NewED25519Address(priv3.PublicKey())
+		generateFrom := func(f chain.AuthFactory, to codec.Address, value uint64) (*chain.Transaction, uint64) {
+			_, tx, maxFee, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{&actions.Transfer{
+					To:    to,
+					Asset: ids.Empty,
+					Value: value,
+				}},
+				f,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			return tx, maxFee
+		}
+		generate := func(value uint64) (*chain.Transaction, uint64) {
+			return generateFrom(factory3, rsender, value)
+		}
+
+		// Fund the sponsor with enough to pay for one pending transaction
+		// (but not two)
+		_, maxFee := generate(1)
+		fund, _ := generateFrom(factory, rsender3, maxFee*3/2)
+		_, err = instances[0].cli.SubmitTx(context.Background(), fund.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Transactions received together (like gossip) count the fees of
+		// those admitted before them
+		tx1, _ := generate(1)
+		tx2, _ := generate(2)
+		errs := instances[0].vm.Submit(context.Background(), true, []*chain.Transaction{tx1, tx2})
+		gomega.Ω(errs).Should(gomega.HaveLen(2))
+		gomega.Ω(errs[0]).Should(gomega.BeNil())
+		gomega.Ω(errs[1]).Should(gomega.MatchError(chain.ErrSponsorInsolvent))
+		gomega.Ω(instances[0].vm.Mempool().Len(context.TODO())).Should(gomega.Equal(1))
+
+		// Transactions submitted over RPC count the fees of those in the mempool
+		_, err = instances[0].cli.SubmitTx(context.Background(), tx2.Bytes())
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(chain.ErrSponsorInsolvent.Error())))
+		code, ok := rpc.ErrorCode(err)
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		gomega.Ω(code).Should(gomega.Equal(rpc.ErrCodeSponsorInsolvent))
+
+		// Other errors don't use the code
+		_, err = instances[0].cli.SubmitTx(context.Background(), tx1.Bytes())
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+		code, ok = rpc.ErrorCode(err)
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		gomega.Ω(code).ShouldNot(gomega.Equal(rpc.ErrCodeSponsorInsolvent))
+
+		// Bundles count the fees of pending transactions (even if the bundle is
+		// sponsored by someone else)
+		solvent, _ := generateFrom(factory, rsender3, 28)
+		tx3, _ := generate(3)
+		_, err = instances[0].cli.SubmitBundle(context.Background(), [][]byte{solvent.Bytes(), tx3.Bytes()})
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(chain.ErrSponsorInsolvent.Error())))
+		code, ok = rpc.ErrorCode(err)
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		gomega.Ω(code).Should(gomega.Equal(rpc.ErrCodeSponsorInsolvent))
+
+		// Bundles received over gossip are checked the same way
+		bundle, err := chain.NewBundle([]*chain.Transaction{solvent, tx3})
+		gomega.Ω(err).Should(gomega.BeNil())
+		errs = instances[0].vm.SubmitBundles(context.Background(), true, []*chain.Bundle{bundle})
+		gomega.Ω(errs).Should(gomega.HaveLen(1))
+		gomega.Ω(errs[0]).Should(gomega.MatchError(chain.ErrSponsorInsolvent))
+		gomega.Ω(instances[0].vm.BundleMempool().Len(context.TODO())).Should(gomega.BeZero())
+
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Txs[0].ID()).Should(gomega.Equal(tx1.ID()))
+
+		// Transactions in pending bundles count towards their own sponsor
+		// (not the sponsor of the bundle)
+		priv4, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		factory4 := auth.NewED25519Factory(priv4)
+		rsender4 := auth.NewED25519Address(priv4.PublicKey())
+		fund, _ = generateFrom(factory, rsender4, maxFee*3/2)
+		_, err = instances[0].cli.SubmitTx(context.Background(), fund.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		solvent, _ = generateFrom(factory, rsender4, 27)
+		tx4, _ := generateFrom(factory4, rsender, 4)
+		_, err = instances[0].cli.SubmitBundle(context.Background(), [][]byte{solvent.Bytes(), tx4.Bytes()})
+		gomega.Ω(err).Should(gomega.BeNil())
+		tx5, _ := generateFrom(factory4, rsender, 5)
+		_, err = instances[0].cli.SubmitTx(context.Background(), tx5.Bytes())
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(chain.ErrSponsorInsolvent.Error())))
+
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(2))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[1].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("pays priority fee to recipient", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		recipientBalance, err := instances[0].tcli.Balance(context.Background(), feeRecipient, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(recipientBalance).Should(gomega.BeZero())
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender2,
+				Asset: ids.Empty,
+				Value: 4,
+			}},
+			factory,
+			rpc.WithPriorityFee(100),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(tx.PriorityFee()).Should(gomega.Equal(uint64(100)))
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[0].Fee).Should(gomega.BeNumerically(">", 100))
+
+		// Sponsor pays the priority fee (which is credited to the recipient) in
+		// addition to the fee computed from unit prices
+		newBalance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - 4 - results[0].Fee))
+		recipientBalance, err = instances[0].tcli.Balance(context.Background(), feeRecipient, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(recipientBalance).Should(gomega.Equal(uint64(100)))
+	})
+
+	ginkgo.It("burns fees from native asset supply", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, _, _, _, supply, _, _, err := instances[0].tcli.Asset(context.Background(), ids.Empty, false)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    rsender2,
+				Asset: ids.Empty,
+				Value: 5,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		_, _, _, _, newSupply, _, _, err := instances[0].tcli.Asset(context.Background(), ids.Empty, false)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newSupply).Should(gomega.Equal(supply - results[0].Fee))
+	})
+
+	ginkgo.It("executes scheduled transfer", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		recipient := auth.NewED25519Address(other.PublicKey())
+
+		// Schedule transfer
+		timestamp := (time.Now().UnixMilli()/consts.MillisecondsPerSecond + 2) * consts.MillisecondsPerSecond
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.ScheduleTransfer{
+				Timestamp: timestamp,
+				Fee:       10_000,
+				To:        recipient,
+				Asset:     ids.Empty,
+				Value:     7,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), codec.MustAddressBech32(tconsts.HRP, recipient), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+		senderBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Scheduled transfer is executed at the start of the next block
+		time.Sleep(time.Until(time.UnixMilli(timestamp)))
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    recipient,
+				Asset: ids.Empty,
+				Value: 6,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err = instances[0].tcli.Balance(context.TODO(), codec.MustAddressBech32(tconsts.HRP, recipient), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(13)))
+
+		// Unused portion of prepaid fee is refunded
+		newSenderBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newSenderBalance).Should(gomega.BeNumerically(">", senderBalance-results[0].Fee-13))
+	})
+
+	ginkgo.It("stores receipt with state diffs", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		recipient := auth.NewED25519Address(other.PublicKey())
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Transfer{
+				To:    recipient,
+				Asset: ids.Empty,
+				Value: 8,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		receipt, err := instances[0].cli.Receipt(context.Background(), tx.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(receipt.TxID).Should(gomega.Equal(tx.ID()))
+		senderKey := storage.BalanceKey(rsender, ids.Empty)
+		recipientKey := storage.BalanceKey(recipient, ids.Empty)
+		gomega.Ω(receipt.Reads).Should(gomega.ContainElement(senderKey))
+		gomega.Ω(receipt.Modifies).Should(gomega.ContainElement(senderKey))
+		gomega.Ω(receipt.Allocates).Should(gomega.ContainElement(recipientKey))
+		gomega.Ω(receipt.Removes).Should(gomega.BeEmpty())
+		for _, diff := range receipt.Diffs {
+			switch string(diff.Key) {
+			case string(senderKey):
+				before := binary.BigEndian.Uint64(diff.Before)
+				after := binary.BigEndian.Uint64(diff.After)
+				gomega.Ω(after).Should(gomega.Equal(before - results[0].Fee - 8))
+			case string(recipientKey):
+				gomega.Ω(diff.Before).Should(gomega.BeNil())
+				gomega.Ω(binary.BigEndian.Uint64(diff.After)).Should(gomega.Equal(uint64(8)))
+			}
+		}
+
+		// Receipts are not stored for unknown transactions
+		_, err = instances[0].cli.Receipt(context.Background(), ids.GenerateTestID())
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+	})
+
+	ginkgo.It("replays accepted blocks", func() {
+		height := instances[0].vm.LastAcceptedBlock().Hght
+		replay, err := instances[0].acli.ReplayBlock(context.Background(), height)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(replay.Height).Should(gomega.Equal(height))
+		gomega.Ω(replay.Txs).Should(gomega.HaveLen(1))
+		gomega.Ω(replay.Txs[0].Result.Success).Should(gomega.BeTrue())
+		gomega.Ω(replay.Txs[0].Recorded).ShouldNot(gomega.BeNil())
+		gomega.Ω(replay.Txs[0].Mismatch).Should(gomega.BeFalse())
+		gomega.Ω(replay.Mismatches).Should(gomega.BeEmpty())
+		gomega.Ω(replay.Diverged()).Should(gomega.BeFalse())
+
+		// Blocks that are not the last accepted block can also be replayed
+		replay, err = instances[0].acli.ReplayBlock(context.Background(), height-1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(replay.Diverged()).Should(gomega.BeFalse())
+
+		// Blocks that have not been accepted cannot be replayed
+		_, err = instances[0].acli.ReplayBlock(context.Background(), height+1)
+		gomega.Ω(err).ShouldNot(gomega.BeNil())
+
+		// Replay requires the admin token
+		_, err = rpc.NewAdminJSONRPCClient(instances[0].AdminServer.URL, "wrong").ReplayBlock(context.Background(), height)
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(rpc.ErrUnauthorized.Error())))
+	})
+
+	ginkgo.It("includes bundled transactions contiguously", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		generate := func(asset ids.ID, value uint64) *chain.Transaction {
+			_, tx, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{&actions.Transfer{
+					To:    rsender2,
+					Asset: asset,
+					Value: value,
+				}},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			return tx
+		}
+
+		// Bundles must contain more than one transaction
+		tx := generate(ids.Empty, 9)
+		_, err = instances[0].cli.SubmitBundle(context.Background(), [][]byte{tx.Bytes()})
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(chain.ErrBundleTooSmall.Error())))
+
+		// Bundled transactions are included in order
+		tx2 := generate(ids.Empty, 10)
+		bundleID, err := instances[0].cli.SubmitBundle(context.Background(), [][]byte{tx.Bytes(), tx2.Bytes()})
+		gomega.Ω(err).Should(gomega.BeNil())
+		bundle, err := chain.NewBundle([]*chain.Transaction{tx, tx2})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(bundleID).Should(gomega.Equal(bundle.ID()))
+		gomega.Ω(instances[0].vm.BundleMempool().Len(context.Background())).Should(gomega.Equal(1))
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(2))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[1].Success).Should(gomega.BeTrue())
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(blk.Txs[0].ID()).Should(gomega.Equal(tx.ID()))
+		gomega.Ω(blk.Txs[1].ID()).Should(gomega.Equal(tx2.ID()))
+		gomega.Ω(instances[0].vm.BundleMempool().Len(context.Background())).Should(gomega.BeZero())
+
+		// Bundles containing accepted transactions are rejected
+		_, err = instances[0].cli.SubmitBundle(context.Background(), [][]byte{tx.Bytes(), generate(ids.Empty, 11).Bytes()})
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring(chain.ErrDuplicateTx.Error())))
+
+		// A bundle is dropped if any of its transactions fails
+		single := generate(ids.Empty, 12)
+		gomega.Ω(instances[0].cli.SubmitTx(context.Background(), single.Bytes())).Error().Should(gomega.BeNil())
+		_, err = instances[0].cli.SubmitBundle(context.Background(), [][]byte{
+			generate(ids.Empty, 13).Bytes(),
+			generate(ids.GenerateTestID(), 1).Bytes(), // sender does not hold asset
+		})
+		gomega.Ω(err).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(instances[0].vm.LastAcceptedBlock().Txs[0].ID()).Should(gomega.Equal(single.ID()))
+		gomega.Ω(instances[0].vm.BundleMempool().Len(context.Background())).Should(gomega.BeZero())
+	})
+
+	ginkgo.It("changes rules parameters with governance", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		execute := func(action chain.Action) *chain.Result {
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{action},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlk(instances[0])
+			results := accept(false)
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			return results[0]
+		}
+
+		// Propose a new [MaxBlockUnits]
+		maxBlockUnits := gen.MaxBlockUnits
+		maxBlockUnits[0]++
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.Propose{
+				Parameter: storage.MaxBlockUnitsParameter,
+				Value:     maxBlockUnits,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		proposalID := chain.CreateActionID(tx.ID(), 0)
+		exists, proposal, err := instances[0].tcli.Proposal(context.Background(), proposalID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(proposal.Value).Should(gomega.Equal(maxBlockUnits))
+
+		// Votes lock the governance asset
+		balance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		result := execute(&actions.Vote{
+			Proposal: proposalID,
+			Asset:    ids.Empty,
+			Approve:  true,
+			Amount:   14_000,
+		})
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		newBalance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - 14_000 - result.Fee))
+		result = execute(&actions.Vote{
+			Proposal: proposalID,
+			Asset:    ids.Empty,
+			Approve:  true,
+			Amount:   15,
+		})
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Outputs[0])).Should(gomega.Equal(string(actions.OutputAlreadyVoted)))
+
+		// Proposals can't be executed until voting ends
+		result = execute(&actions.ExecuteProposal{Proposal: proposalID})
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Outputs[0])).Should(gomega.Equal(string(actions.OutputVotingNotEnded)))
+		time.Sleep(time.Until(time.UnixMilli(proposal.End)))
+		result = execute(&actions.ExecuteProposal{Proposal: proposalID})
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		_, proposal, err = instances[0].tcli.Proposal(context.Background(), proposalID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(proposal.Executed).Should(gomega.BeTrue())
+		gomega.Ω(proposal.Yes).Should(gomega.Equal(uint64(14_000)))
+
+		// Rules loaded from state use the new parameter value
+		db, err := instances[0].vm.State()
+		gomega.Ω(err).Should(gomega.BeNil())
+		r, err := chain.LoadRules(context.Background(), instances[0].vm.Rules(time.Now().UnixMilli()), db)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(r.GetMaxBlockUnits()).Should(gomega.Equal(maxBlockUnits))
+		gomega.Ω(instances[0].vm.Rules(time.Now().UnixMilli()).GetMaxBlockUnits()).Should(gomega.Equal(gen.MaxBlockUnits))
+
+		// Locked amount is returned after voting ends
+		balance, err = instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		result = execute(&actions.ReclaimVote{Proposal: proposalID, Asset: ids.Empty})
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		newBalance, err = instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance + 14_000 - result.Fee))
+	})
+
+	ginkgo.It("distributes fees to stakers", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		execute := func(action chain.Action, f chain.AuthFactory) *chain.Result {
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				[]chain.Action{action},
+				f,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlk(instances[0])
+			results := accept(false)
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			return results[0]
+		}
+		nodeID := instances[0].nodeID
+
+		// Validators must be registered by the owner with the min stake
+		result := execute(actions.Staking.NewDelegate(nodeID, 16), factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Outputs[0])).Should(gomega.Equal(string(staking.OutputValidatorMissing)))
+		result = execute(actions.Staking.NewStake(nodeID, 17), factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Outputs[0])).Should(gomega.Equal(string(staking.OutputBelowMinStake)))
+
+		// Register validator and delegate
+		balance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		result = execute(actions.Staking.NewStake(nodeID, actions.MinValidatorStake), factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		newBalance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - actions.MinValidatorStake - result.Fee))
+		stakeFee := result.Fee
+		result = execute(actions.Staking.NewDelegate(nodeID, actions.MinValidatorStake), factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		delegateFee := result.Fee
+		stake, err := instances[0].tcli.Stake(context.Background(), nodeID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stake.Validator.Owner).Should(gomega.Equal(rsender))
+		gomega.Ω(stake.Validator.Stake).Should(gomega.Equal(uint64(actions.MinValidatorStake)))
+		gomega.Ω(stake.Validator.Delegated).Should(gomega.Equal(uint64(actions.MinValidatorStake)))
+		gomega.Ω(stake.Position.Amount).Should(gomega.Equal(uint64(actions.MinValidatorStake)))
+
+		// Fees are distributed to all stake at the end of each block
+		stake, err = instances[0].tcli.Stake(context.Background(), nodeID, sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stake.PendingRewards).Should(gomega.Equal(stakeFee + delegateFee/2))
+		balance, err = instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		result = execute(actions.Staking.NewClaim(nodeID), factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		newBalance, err = instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance + stake.PendingRewards - result.Fee))
+		stake, err = instances[0].tcli.Stake(context.Background(), nodeID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stake.PendingRewards).Should(gomega.Equal(delegateFee/2 + result.Fee/2))
+
+		// Unstaked amount is locked until the next epoch
+		result = execute(actions.Staking.NewUnstake(nodeID, actions.MinValidatorStake), factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		stake, err = instances[0].tcli.Stake(context.Background(), nodeID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stake.Validator.Delegated).Should(gomega.BeZero())
+		gomega.Ω(stake.Position.Amount).Should(gomega.BeZero())
+		gomega.Ω(stake.Withdrawal.Amount).Should(gomega.Equal(uint64(actions.MinValidatorStake)))
+		blk := instances[0].vm.LastAcceptedBlock()
+		gomega.Ω(stake.Withdrawal.UnlockEpoch).Should(gomega.Equal(actions.Staking.Epoch(blk.Tmstmp) + 1))
+	})
+
+	ginkgo.It("burn new asset", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.BurnAsset{
+				Asset: asset1ID,
+				Value: 5,
+			}},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, asset1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, asset1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+
+		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(symbol).Should(gomega.Equal(asset1Symbol))
+		gomega.Ω(decimals).Should(gomega.Equal(asset1Decimals))
+		gomega.Ω(metadata).Should(gomega.Equal(asset1))
+		gomega.Ω(supply).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(owner).Should(gomega.Equal(sender))
+		gomega.Ω(warp).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("burn missing asset", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.BurnAsset{
+				Asset: asset1ID,
+				Value: 10,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Outputs[0])).
+			Should(gomega.ContainSubstring("invalid balance"))
+
+		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(symbol).Should(gomega.Equal(asset1Symbol))
+		gomega.Ω(decimals).Should(gomega.Equal(asset1Decimals))
+		gomega.Ω(metadata).Should(gomega.Equal(asset1))
+		gomega.Ω(supply).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(owner).Should(gomega.Equal(sender))
+		gomega.Ω(warp).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("rejects empty mint", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxFee:    1000,
+			},
+			nil,
+			[]chain.Action{&actions.MintAsset{
+				To:    auth.NewED25519Address(other.PublicKey()),
+				Asset: asset1ID,
+			}},
+		)
+		// Must do manual construction to avoid `tx.Sign` error (would fail with
+		// bad codec)
+		msg, err := tx.Digest()
+		gomega.Ω(err).To(gomega.BeNil())
+		auth, err := factory.Sign(msg, tx.Actions)
+		gomega.Ω(err).To(gomega.BeNil())
+		tx.Auth = auth
+		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
+		gomega.Ω(tx.Marshal(p)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring("Uint64 field is not populated"))
+	})
+
+	ginkgo.It("reject max mint", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.MintAsset{
+				To:    rsender2,
+				Asset: asset1ID,
+				Value: consts.MaxUint64,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Outputs[0])).
+			Should(gomega.ContainSubstring("overflow"))
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, asset1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, asset1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+
+		exists, symbol, decimals, metadata, supply, owner, warp, err := instances[0].tcli.Asset(context.TODO(), asset1ID, false)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(symbol).Should(gomega.Equal(asset1Symbol))
+		gomega.Ω(decimals).Should(gomega.Equal(asset1Decimals))
+		gomega.Ω(metadata).Should(gomega.Equal(asset1))
+		gomega.Ω(supply).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(owner).Should(gomega.Equal(sender))
+		gomega.Ω(warp).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("rejects mint of native token", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxFee:    1000,
+			},
+			nil,
+			[]chain.Action{&actions.MintAsset{
+				To:    auth.NewED25519Address(other.PublicKey()),
+				Value: 10,
+			}},
+		)
+		// Must do manual construction to avoid `tx.Sign` error (would fail with
+		// bad codec)
+		msg, err := tx.Digest()
+		gomega.Ω(err).To(gomega.BeNil())
+		auth, err := factory.Sign(msg, tx.Actions)
+		gomega.Ω(err).To(gomega.BeNil())
+		tx.Auth = auth
+		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
+		gomega.Ω(tx.Marshal(p)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring("ID field is not populated"))
+	})
+
+	ginkgo.It("mints another new asset (to self)", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:   asset2Symbol,
+				Decimals: asset2Decimals,
+				Metadata: asset2,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		asset2ID = tx.ID()
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.MintAsset{
+				To:    rsender,
+				Asset: asset2ID,
+				Value: 10,
+			}},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+	})
+
+	ginkgo.It("mints another new asset (to self) on another account", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:   asset3Symbol,
+				Decimals: asset3Decimals,
+				Metadata: asset3,
+			}},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		asset3ID = tx.ID()
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			[]chain.Action{&actions.MintAsset{
+				To:    rsender2,
+				Asset: asset3ID,
+				Value: 10,
+			}},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, asset3ID)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
 	})
@@ -1295,13 +2646,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset3ID,
 				InTick:  1,
 				Out:     asset2ID,
 				OutTick: 2,
 				Supply:  4,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1333,13 +2684,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  4,
 				Out:     asset3ID,
 				OutTick: 2,
 				Supply:  5, // put half of balance
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1349,7 +2700,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Outputs[0])).
 			Should(gomega.ContainSubstring("supply is misaligned"))
 	})
 
@@ -1360,13 +2711,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  4,
 				Out:     asset3ID,
 				OutTick: 1,
 				Supply:  5, // put half of balance
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1398,13 +2749,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  5,
 				Out:     asset3ID,
 				OutTick: 1,
 				Supply:  5, // put half of balance
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1414,7 +2765,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Outputs[0])).
 			Should(gomega.ContainSubstring("invalid balance"))
 	})
 
@@ -1431,13 +2782,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 10, // rate of this order is 4 asset2 = 1 asset3
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1447,7 +2798,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Outputs[0])).
 			Should(gomega.ContainSubstring("value is misaligned"))
 	})
 
@@ -1464,13 +2815,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 20, // rate of this order is 4 asset2 = 1 asset3
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1480,7 +2831,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Outputs[0])).
 			Should(gomega.ContainSubstring("invalid balance"))
 	})
 
@@ -1497,13 +2848,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 4, // rate of this order is 4 asset2 = 1 asset3
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1513,7 +2864,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeTrue())
-		or, err := actions.UnmarshalOrderResult(result.Output)
+		or, err := actions.UnmarshalOrderResult(result.Outputs[0])
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(or.In).Should(gomega.Equal(uint64(4)))
 		gomega.Ω(or.Out).Should(gomega.Equal(uint64(1)))
@@ -1544,10 +2895,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CloseOrder{
+			[]chain.Action{&actions.CloseOrder{
 				Order: order.ID,
 				Out:   asset3ID,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1557,7 +2908,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).
+		gomega.Ω(string(result.Outputs[0])).
 			Should(gomega.ContainSubstring("unauthorized"))
 	})
 
@@ -1572,10 +2923,10 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CloseOrder{
+			[]chain.Action{&actions.CloseOrder{
 				Order: order.ID,
 				Out:   asset3ID,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1611,13 +2962,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.CreateOrder{
+			[]chain.Action{&actions.CreateOrder{
 				In:      asset2ID,
 				InTick:  2,
 				Out:     asset3ID,
 				OutTick: 1,
 				Supply:  1,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1655,13 +3006,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.FillOrder{
+			[]chain.Action{&actions.FillOrder{
 				Order: order.ID,
 				Owner: owner,
 				In:    asset2ID,
 				Out:   asset3ID,
 				Value: 4,
-			},
+			}},
 			factory2,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1671,7 +3022,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeTrue())
-		or, err := actions.UnmarshalOrderResult(result.Output)
+		or, err := actions.UnmarshalOrderResult(result.Outputs[0])
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(or.In).Should(gomega.Equal(uint64(2)))
 		gomega.Ω(or.Out).Should(gomega.Equal(uint64(1)))
@@ -1703,13 +3054,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			nil,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// empty warp)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -1732,13 +3083,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// empty warp)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -1763,13 +3114,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// invalid object)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -1797,13 +3148,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 				MaxFee:    1000,
 			},
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 		)
 		// Must do manual construction to avoid `tx.Sign` error (would fail with
 		// invalid object)
 		msg, err := tx.Digest()
 		gomega.Ω(err).To(gomega.BeNil())
-		auth, err := factory.Sign(msg, tx.Action)
+		auth, err := factory.Sign(msg, tx.Actions)
 		gomega.Ω(err).To(gomega.BeNil())
 		tx.Auth = auth
 		p := codec.NewWriter(0, consts.MaxInt) // test codec growth
@@ -1840,7 +3191,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			wm,
-			&actions.ImportAsset{},
+			[]chain.Action{&actions.ImportAsset{}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1868,7 +3219,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("warp verification failed"))
+		gomega.Ω(string(result.Outputs[0])).Should(gomega.ContainSubstring("warp verification failed"))
 	})
 
 	ginkgo.It("export native asset", func() {
@@ -1883,14 +3234,14 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.ExportAsset{
+			[]chain.Action{&actions.ExportAsset{
 				To:          rsender,
 				Asset:       ids.Empty,
 				Value:       100,
 				Return:      false,
 				Reward:      10,
 				Destination: dest,
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1929,14 +3280,14 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			parser,
 			nil,
-			&actions.ExportAsset{
+			[]chain.Action{&actions.ExportAsset{
 				To:          rsender,
 				Asset:       ids.Empty,
 				Value:       100,
 				Return:      true,
 				Reward:      10,
 				Destination: ids.GenerateTestID(),
-			},
+			}},
 			factory,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1946,7 +3297,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(results).Should(gomega.HaveLen(1))
 		result := results[0]
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
-		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("not warp asset"))
+		gomega.Ω(string(result.Outputs[0])).Should(gomega.ContainSubstring("not warp asset"))
 	})
 })
 
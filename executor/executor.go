@@ -7,6 +7,8 @@ import (
 	"sync"
 
 	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/state"
 )
 
 const defaultSetSize = 8
@@ -17,6 +19,10 @@ const defaultSetSize = 8
 // Executor ensures that conflicting tasks
 // are executed in the order they were queued.
 // Tasks with no conflicts are executed immediately.
+//
+// Tasks only conflict if at least one of them
+// writes to a shared key (any number of tasks
+// can read the same key concurrently).
 type Executor struct {
 	metrics    Metrics
 	wg         sync.WaitGroup
@@ -30,7 +36,8 @@ type Executor struct {
 	done      bool
 	completed int
 	tasks     map[int]*task
-	edges     map[string]int
+	writers   map[string]int   // last task to write each key
+	readers   map[string][]int // tasks that read each key since it was last written
 }
 
 // New creates a new [Executor].
@@ -39,8 +46,9 @@ func New(items, concurrency int, metrics Metrics) *Executor {
 		metrics:    metrics,
 		stop:       make(chan struct{}),
 		tasks:      make(map[int]*task, items),
-		edges:      make(map[string]int, items*2), // TODO: tune this
-		executable: make(chan *task, items),       // ensure we don't block while holding lock
+		writers:    make(map[string]int, items*2), // TODO: tune this
+		readers:    make(map[string][]int, items*2),
+		executable: make(chan *task, items), // ensure we don't block while holding lock
 	}
 	for i := 0; i < concurrency; i++ {
 		e.createWorker()
@@ -105,7 +113,11 @@ func (e *Executor) createWorker() {
 
 // Run executes [f] after all previously enqueued [f] with
 // overlapping [conflicts] are executed.
-func (e *Executor) Run(conflicts set.Set[string], f func() error) {
+//
+// If [f] only reads a key, it waits for the last [f] that
+// wrote the key. If [f] writes a key, it also waits for all [f]
+// that read the key since it was last written.
+func (e *Executor) Run(conflicts state.Keys, f func() error) {
 	e.l.Lock()
 	defer e.l.Unlock()
 
@@ -118,22 +130,19 @@ func (e *Executor) Run(conflicts set.Set[string], f func() error) {
 	e.tasks[id] = t
 
 	// Record dependencies
-	for k := range conflicts {
-		latest, ok := e.edges[k]
-		if ok {
-			lt := e.tasks[latest]
-			if !lt.executed {
-				if t.dependencies == nil {
-					t.dependencies = set.NewSet[int](defaultSetSize)
-				}
-				t.dependencies.Add(lt.id)
-				if lt.blocking == nil {
-					lt.blocking = set.NewSet[int](defaultSetSize)
-				}
-				lt.blocking.Add(id)
-			}
+	for k, permissions := range conflicts {
+		if latest, ok := e.writers[k]; ok {
+			e.addDependency(t, latest)
+		}
+		if !permissions.Has(state.Write) {
+			e.readers[k] = append(e.readers[k], id)
+			continue
 		}
-		e.edges[k] = id
+		for _, reader := range e.readers[k] {
+			e.addDependency(t, reader)
+		}
+		delete(e.readers, k)
+		e.writers[k] = id
 	}
 
 	// Start execution if there are no blocking dependencies
@@ -150,6 +159,22 @@ func (e *Executor) Run(conflicts set.Set[string], f func() error) {
 	}
 }
 
+// addDependency blocks [t] on [dependency] (if it has not been executed).
+func (e *Executor) addDependency(t *task, dependency int) {
+	dt := e.tasks[dependency]
+	if dt.executed {
+		return
+	}
+	if t.dependencies == nil {
+		t.dependencies = set.NewSet[int](defaultSetSize)
+	}
+	t.dependencies.Add(dt.id)
+	if dt.blocking == nil {
+		dt.blocking = set.NewSet[int](defaultSetSize)
+	}
+	dt.blocking.Add(t.id)
+}
+
 func (e *Executor) Stop() {
 	e.stopOnce.Do(func() {
 		e.err = ErrStopped
@@ -10,8 +10,9 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/state"
 )
 
 func TestExecutorNoConflicts(t *testing.T) {
@@ -23,9 +24,9 @@ func TestExecutorNoConflicts(t *testing.T) {
 		canWait   = make(chan struct{})
 	)
 	for i := 0; i < 100; i++ {
-		s := set.NewSet[string](i + 1)
+		s := make(state.Keys, i+1)
 		for k := 0; k < i+1; k++ {
-			s.Add(ids.GenerateTestID().String())
+			s.Add(ids.GenerateTestID().String(), state.Write)
 		}
 		ti := i
 		e.Run(s, func() error {
@@ -51,9 +52,9 @@ func TestExecutorNoConflictsSlow(t *testing.T) {
 		e         = New(100, 4, nil)
 	)
 	for i := 0; i < 100; i++ {
-		s := set.NewSet[string](i + 1)
+		s := make(state.Keys, i+1)
 		for k := 0; k < i+1; k++ {
-			s.Add(ids.GenerateTestID().String())
+			s.Add(ids.GenerateTestID().String(), state.Write)
 		}
 		ti := i
 		e.Run(s, func() error {
@@ -80,12 +81,12 @@ func TestExecutorSimpleConflict(t *testing.T) {
 		e           = New(100, 4, nil)
 	)
 	for i := 0; i < 100; i++ {
-		s := set.NewSet[string](i + 1)
+		s := make(state.Keys, i+1)
 		for k := 0; k < i+1; k++ {
-			s.Add(ids.GenerateTestID().String())
+			s.Add(ids.GenerateTestID().String(), state.Write)
 		}
 		if i%10 == 0 {
-			s.Add(conflictKey)
+			s.Add(conflictKey, state.Write)
 		}
 		ti := i
 		e.Run(s, func() error {
@@ -113,15 +114,15 @@ func TestExecutorMultiConflict(t *testing.T) {
 		e            = New(100, 4, nil)
 	)
 	for i := 0; i < 100; i++ {
-		s := set.NewSet[string](i + 1)
+		s := make(state.Keys, i+1)
 		for k := 0; k < i+1; k++ {
-			s.Add(ids.GenerateTestID().String())
+			s.Add(ids.GenerateTestID().String(), state.Write)
 		}
 		if i%10 == 0 {
-			s.Add(conflictKey)
+			s.Add(conflictKey, state.Write)
 		}
 		if i == 15 || i == 20 {
-			s.Add(conflictKey2)
+			s.Add(conflictKey2, state.Write)
 		}
 		ti := i
 		e.Run(s, func() error {
@@ -151,9 +152,9 @@ func TestEarlyExit(t *testing.T) {
 		terr      = errors.New("uh oh")
 	)
 	for i := 0; i < 500; i++ {
-		s := set.NewSet[string](i + 1)
+		s := make(state.Keys, i+1)
 		for k := 0; k < i+1; k++ {
-			s.Add(ids.GenerateTestID().String())
+			s.Add(ids.GenerateTestID().String(), state.Write)
 		}
 		ti := i
 		e.Run(s, func() error {
@@ -178,9 +179,9 @@ func TestStop(t *testing.T) {
 		e         = New(500, 4, nil)
 	)
 	for i := 0; i < 500; i++ {
-		s := set.NewSet[string](i + 1)
+		s := make(state.Keys, i+1)
 		for k := 0; k < i+1; k++ {
-			s.Add(ids.GenerateTestID().String())
+			s.Add(ids.GenerateTestID().String(), state.Write)
 		}
 		ti := i
 		e.Run(s, func() error {
@@ -196,3 +197,93 @@ func TestStop(t *testing.T) {
 	require.True(len(completed) < 500)
 	require.ErrorIs(e.Wait(), ErrStopped) // no task running
 }
+
+func TestExecutorReadConflicts(t *testing.T) {
+	var (
+		require   = require.New(t)
+		sharedKey = ids.GenerateTestID().String()
+		l         sync.Mutex
+		completed = make([]int, 0, 30)
+		e         = New(30, 4, nil)
+	)
+	for i := 0; i < 30; i++ {
+		s := make(state.Keys, 2)
+		s.Add(ids.GenerateTestID().String(), state.All)
+		if i == 10 || i == 20 {
+			s.Add(sharedKey, state.All)
+		} else {
+			s.Add(sharedKey, state.Read)
+		}
+		ti := i
+		e.Run(s, func() error {
+			if ti == 0 || ti == 11 {
+				time.Sleep(1 * time.Second)
+			}
+
+			l.Lock()
+			completed = append(completed, ti)
+			l.Unlock()
+			return nil
+		})
+	}
+	require.NoError(e.Wait())
+	require.Len(completed, 30)
+
+	// Readers are executed concurrently but writers wait for all
+	// previous readers (and readers wait for the last writer)
+	position := make(map[int]int, len(completed))
+	for i, ti := range completed {
+		position[ti] = i
+	}
+	require.Less(position[1], position[0])
+	for i := 0; i < 10; i++ {
+		require.Less(position[i], position[10])
+	}
+	for i := 11; i < 20; i++ {
+		require.Less(position[10], position[i])
+		require.Less(position[i], position[20])
+	}
+	require.Less(position[12], position[11])
+	for i := 21; i < 30; i++ {
+		require.Less(position[20], position[i])
+	}
+}
+
+func BenchmarkExecutorSharedKey(b *testing.B) {
+	const (
+		items = 1_000
+		cores = 8
+	)
+	for _, tt := range []struct {
+		name        string
+		permissions state.Permissions
+	}{
+		{name: "read", permissions: state.Read},
+		{name: "write", permissions: state.All},
+	} {
+		sharedKey := ids.GenerateTestID().String()
+		conflicts := make([]state.Keys, items)
+		for i := 0; i < items; i++ {
+			s := make(state.Keys, 3)
+			s.Add(ids.GenerateTestID().String(), state.All)
+			s.Add(ids.GenerateTestID().String(), state.All)
+			s.Add(sharedKey, tt.permissions)
+			conflicts[i] = s
+		}
+		b.Run(tt.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				e := New(items, cores, nil)
+				for i := 0; i < items; i++ {
+					e.Run(conflicts[i], func() error {
+						// Simulate a task that takes some time to execute
+						time.Sleep(50 * time.Microsecond)
+						return nil
+					})
+				}
+				if err := e.Wait(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import "sync"
+
+// Optimistic speculatively executes tasks concurrently (without regard
+// for any conflicts between them) and commits them, one at a time, in the
+// order they were queued.
+//
+// Each task is validated immediately before it is committed. If validation
+// fails (because the task observed state that was modified by a previously
+// queued task), the task is executed again before it is committed. All
+// previously queued tasks are committed by this point, so re-execution is
+// guaranteed to observe the same state as serial execution.
+//
+// Optimistic performs best when declared conflicts rarely materialize (i.e.
+// many tasks access the same keys but rarely modify them). Each speculative
+// execution is recorded as executable and each re-execution is recorded as
+// blocked.
+type Optimistic struct {
+	metrics Metrics
+	wg      sync.WaitGroup
+
+	executable  chan *optimisticTask
+	committable chan *optimisticTask
+
+	stop     chan struct{}
+	err      error
+	stopOnce sync.Once
+}
+
+// NewOptimistic creates a new [Optimistic] executor.
+func NewOptimistic(items, concurrency int, metrics Metrics) *Optimistic {
+	o := &Optimistic{
+		metrics:     metrics,
+		executable:  make(chan *optimisticTask, items), // ensure we don't block in [Run]
+		committable: make(chan *optimisticTask, items),
+		stop:        make(chan struct{}),
+	}
+	for i := 0; i < concurrency; i++ {
+		o.createWorker()
+	}
+	o.createCommitter()
+	return o
+}
+
+type optimisticTask struct {
+	execute  func() error
+	validate func() bool
+	commit   func() error
+
+	err      error
+	executed chan struct{}
+}
+
+func (o *Optimistic) createWorker() {
+	o.wg.Add(1)
+
+	go func() {
+		defer o.wg.Done()
+
+		for {
+			select {
+			case t, ok := <-o.executable:
+				if !ok {
+					return
+				}
+
+				// Errors are not returned until the task is validated, as they
+				// may have been caused by observing inconsistent state.
+				t.err = t.execute()
+				close(t.executed)
+			case <-o.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (o *Optimistic) createCommitter() {
+	o.wg.Add(1)
+
+	go func() {
+		defer o.wg.Done()
+
+		for {
+			select {
+			case t, ok := <-o.committable:
+				if !ok {
+					return
+				}
+				select {
+				case <-t.executed:
+				case <-o.stop:
+					return
+				}
+				if !t.validate() {
+					if o.metrics != nil {
+						o.metrics.RecordBlocked()
+					}
+					t.err = t.execute()
+				}
+				if t.err == nil {
+					t.err = t.commit()
+				}
+				if t.err != nil {
+					o.stopOnce.Do(func() {
+						o.err = t.err
+						close(o.stop)
+					})
+					return
+				}
+			case <-o.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Run speculatively executes [execute] and then calls [commit] after all
+// previously enqueued tasks are committed.
+//
+// If [validate] returns false before [commit] is called, [execute] is called
+// again (and is guaranteed to observe all previously committed tasks). [execute]
+// is never called concurrently with itself.
+//
+// Run should not be called concurrently (tasks are committed in the
+// order they are enqueued).
+func (o *Optimistic) Run(execute func() error, validate func() bool, commit func() error) {
+	t := &optimisticTask{
+		execute:  execute,
+		validate: validate,
+		commit:   commit,
+		executed: make(chan struct{}),
+	}
+	o.committable <- t
+	o.executable <- t
+	if o.metrics != nil {
+		o.metrics.RecordExecutable()
+	}
+}
+
+func (o *Optimistic) Stop() {
+	o.stopOnce.Do(func() {
+		o.err = ErrStopped
+		close(o.stop)
+	})
+}
+
+// Wait returns as soon as all enqueued tasks are committed.
+//
+// You should not call [Run] after [Wait] is called.
+func (o *Optimistic) Wait() error {
+	close(o.executable)
+	close(o.committable)
+	o.wg.Wait()
+	return o.err
+}
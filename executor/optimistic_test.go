@@ -0,0 +1,167 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimisticCommitOrder(t *testing.T) {
+	var (
+		require   = require.New(t)
+		l         sync.Mutex
+		executed  = make([]int, 0, 100)
+		committed = make([]int, 0, 100)
+		o         = NewOptimistic(100, 4, nil)
+	)
+	for i := 0; i < 100; i++ {
+		ti := i
+		o.Run(func() error {
+			if ti == 0 {
+				time.Sleep(3 * time.Second)
+			}
+			l.Lock()
+			executed = append(executed, ti)
+			l.Unlock()
+			return nil
+		}, func() bool {
+			return true
+		}, func() error {
+			committed = append(committed, ti)
+			return nil
+		})
+	}
+	require.NoError(o.Wait())
+	require.Len(executed, 100)
+	require.Equal(0, executed[99])
+	for i := 0; i < 100; i++ {
+		require.Equal(i, committed[i])
+	}
+}
+
+func TestOptimisticConflict(t *testing.T) {
+	var (
+		require = require.New(t)
+		l       sync.Mutex
+		version int
+		counter int
+		o       = NewOptimistic(100, 4, nil)
+	)
+	for i := 0; i < 100; i++ {
+		var (
+			ti      = i
+			read    int
+			next    int
+			readVer int
+		)
+		o.Run(func() error {
+			l.Lock()
+			read, readVer = counter, version
+			l.Unlock()
+
+			// Only every 10th task modifies the counter
+			next = read
+			if ti%10 == 0 {
+				next = read + ti
+			}
+			return nil
+		}, func() bool {
+			l.Lock()
+			defer l.Unlock()
+			return readVer == version
+		}, func() error {
+			l.Lock()
+			defer l.Unlock()
+			if next != read {
+				counter = next
+				version++
+			}
+			return nil
+		})
+	}
+	require.NoError(o.Wait())
+	require.Equal(450, counter)
+	require.Equal(9, version) // task 0 does not modify the counter
+}
+
+func TestOptimisticSpeculativeError(t *testing.T) {
+	var (
+		require = require.New(t)
+		o       = NewOptimistic(10, 4, nil)
+		terr    = errors.New("uh oh")
+	)
+	for i := 0; i < 10; i++ {
+		var (
+			ti         = i
+			executions int
+		)
+		o.Run(func() error {
+			executions++
+			if ti == 5 && executions == 1 {
+				return terr
+			}
+			return nil
+		}, func() bool {
+			// Task 5 observed stale state, so its error should be ignored
+			return ti != 5 || executions > 1
+		}, func() error {
+			return nil
+		})
+	}
+	require.NoError(o.Wait())
+}
+
+func TestOptimisticEarlyExit(t *testing.T) {
+	var (
+		require   = require.New(t)
+		completed = make([]int, 0, 500)
+		o         = NewOptimistic(500, 4, nil)
+		terr      = errors.New("uh oh")
+	)
+	for i := 0; i < 500; i++ {
+		ti := i
+		o.Run(func() error {
+			if ti == 200 {
+				return terr
+			}
+			return nil
+		}, func() bool {
+			return true
+		}, func() error {
+			completed = append(completed, ti)
+			return nil
+		})
+	}
+	require.ErrorIs(o.Wait(), terr)
+	require.Len(completed, 200)
+}
+
+func TestOptimisticStop(t *testing.T) {
+	var (
+		require   = require.New(t)
+		completed = make([]int, 0, 500)
+		o         = NewOptimistic(500, 4, nil)
+	)
+	for i := 0; i < 500; i++ {
+		ti := i
+		o.Run(func() error {
+			return nil
+		}, func() bool {
+			return true
+		}, func() error {
+			completed = append(completed, ti)
+			if ti == 200 {
+				o.Stop()
+			}
+			return nil
+		})
+	}
+	require.ErrorIs(o.Wait(), ErrStopped)
+	require.True(len(completed) < 500)
+}
@@ -6,3 +6,9 @@ package gossiper
 // initialCapacity is the initial size of a txs array we allocate when
 // unmarshaling a batch of txs.
 const initialCapacity = 1000
+
+// Each gossip message is prefixed with the type of its contents.
+const (
+	txsMsg uint8 = iota
+	bundlesMsg
+)
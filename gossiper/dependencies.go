@@ -20,6 +20,7 @@ type VM interface {
 	StopChan() chan struct{}
 	Tracer() trace.Tracer
 	Mempool() chain.Mempool
+	BundleMempool() chain.BundleMempool
 	GetTargetGossipDuration() time.Duration
 	Proposers(ctx context.Context, diff int, depth int) (set.Set[ids.NodeID], error)
 	IsValidator(context.Context, ids.NodeID) (bool, error)
@@ -29,6 +30,7 @@ type VM interface {
 	NodeID() ids.NodeID
 	Rules(int64) chain.Rules
 	Submit(ctx context.Context, verify bool, txs []*chain.Transaction) []error
+	SubmitBundles(ctx context.Context, verify bool, bundles []*chain.Bundle) []error
 	GetAuthBatchVerifier(authTypeID uint8, cores int, count int) (chain.AuthBatchVerifier, bool)
 	StateManager() chain.StateManager
 
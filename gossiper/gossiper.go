@@ -16,5 +16,6 @@ type Gossiper interface {
 	Force(context.Context) error // may be triggered by run already
 	HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error
 	BlockVerified(int64)
-	Done() // wait after stop
+	Evict([]ids.ID) // remove replaced txs from any caches
+	Done()          // wait after stop
 }
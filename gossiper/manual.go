@@ -65,25 +65,90 @@ func (g *Manual) Force(ctx context.Context) error {
 	if mempoolErr != nil {
 		return mempoolErr
 	}
-	if len(txs) == 0 {
+	if len(txs) > 0 {
+		b, err := chain.MarshalTxs(txs)
+		if err != nil {
+			return err
+		}
+		if err := g.appSender.SendAppGossip(ctx, append([]byte{txsMsg}, b...)); err != nil {
+			g.vm.Logger().Warn(
+				"GossipTxs failed",
+				zap.Error(err),
+			)
+			return err
+		}
+		g.vm.Logger().Debug("gossiped txs", zap.Int("count", len(txs)))
+	}
+
+	// Gossip highest paying bundles
+	var (
+		bundles    = []*chain.Bundle{}
+		bundleSize = 0
+	)
+	bundleErr := g.vm.BundleMempool().Top(
+		ctx,
+		g.vm.GetTargetGossipDuration(),
+		func(ictx context.Context, next *chain.Bundle) (cont bool, rest bool, err error) {
+			// Remove bundles that are expired
+			if next.Expiry() < now {
+				return true, false, nil
+			}
+
+			// Gossip up to [consts.NetworkSizeLimit]
+			size := next.Size()
+			if size+bundleSize > consts.NetworkSizeLimit {
+				return false, true, nil
+			}
+			bundles = append(bundles, next)
+			bundleSize += size
+			return true, true, nil
+		},
+	)
+	if bundleErr != nil {
+		return bundleErr
+	}
+	if len(bundles) == 0 {
 		return nil
 	}
-	b, err := chain.MarshalTxs(txs)
+	b, err := chain.MarshalBundles(bundles)
 	if err != nil {
 		return err
 	}
-	if err := g.appSender.SendAppGossip(ctx, b); err != nil {
+	if err := g.appSender.SendAppGossip(ctx, append([]byte{bundlesMsg}, b...)); err != nil {
 		g.vm.Logger().Warn(
-			"GossipTxs failed",
+			"GossipBundles failed",
 			zap.Error(err),
 		)
 		return err
 	}
-	g.vm.Logger().Debug("gossiped txs", zap.Int("count", len(txs)))
+	g.vm.Logger().Debug("gossiped bundles", zap.Int("count", len(bundles)))
 	return nil
 }
 
 func (g *Manual) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	if len(msg) == 0 {
+		g.vm.Logger().Warn(
+			"AppGossip provided empty message",
+			zap.Stringer("peerID", nodeID),
+		)
+		return nil
+	}
+	switch msg[0] {
+	case txsMsg:
+		return g.handleTxs(ctx, nodeID, msg[1:])
+	case bundlesMsg:
+		return g.handleBundles(ctx, nodeID, msg[1:])
+	default:
+		g.vm.Logger().Warn(
+			"AppGossip provided unknown message type",
+			zap.Stringer("peerID", nodeID),
+			zap.Uint8("type", msg[0]),
+		)
+		return nil
+	}
+}
+
+func (g *Manual) handleTxs(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
 	actionRegistry, authRegistry := g.vm.Registry()
 	_, txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
 	if err != nil {
@@ -116,8 +181,49 @@ func (g *Manual) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []b
 	return nil
 }
 
+func (g *Manual) handleBundles(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	actionRegistry, authRegistry := g.vm.Registry()
+	_, bundles, err := chain.UnmarshalBundles(msg, initialCapacity, actionRegistry, authRegistry)
+	if err != nil {
+		g.vm.Logger().Warn(
+			"AppGossip provided invalid bundles",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	var txs int
+	for _, bundle := range bundles {
+		txs += len(bundle.Txs)
+	}
+	g.vm.RecordTxsReceived(txs)
+
+	start := time.Now()
+	for _, err := range g.vm.SubmitBundles(ctx, true, bundles) {
+		if err == nil {
+			continue
+		}
+		g.vm.Logger().Warn(
+			"AppGossip failed to submit bundles",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+	}
+	g.vm.Logger().Info(
+		"bundle gossip received",
+		zap.Int("bundles", len(bundles)),
+		zap.Int("txs", txs),
+		zap.Stringer("nodeID", nodeID),
+		zap.Duration("t", time.Since(start)),
+	)
+	return nil
+}
+
 func (*Manual) BlockVerified(int64) {}
 
+// Evict is a no-op in [Manual] (it doesn't cache any txs).
+func (*Manual) Evict([]ids.ID) {}
+
 func (g *Manual) Done() {
 	<-g.doneGossip
 }
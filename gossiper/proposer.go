@@ -150,33 +150,101 @@ func (g *Proposer) Force(ctx context.Context) error {
 	if mempoolErr != nil {
 		return mempoolErr
 	}
-	if len(txs) == 0 {
+
+	// Gossip newest bundles
+	//
+	// Bundles are removed from the [BundleMempool] for
+	// the same reason as transactions.
+	var (
+		bundles    = []*chain.Bundle{}
+		bundleTxs  = 0
+		bundleSize = 0
+	)
+	bundleErr := g.vm.BundleMempool().Top(
+		ctx,
+		g.vm.GetTargetGossipDuration(),
+		func(ictx context.Context, next *chain.Bundle) (cont bool, rest bool, err error) {
+			// Remove bundles that are expired
+			if next.Expiry() < now {
+				return true, false, nil
+			}
+
+			// Don't gossip bundles that are about to expire
+			life := next.Expiry() - now
+			if life < g.cfg.GossipMinLife {
+				return true, true, nil
+			}
+
+			// Gossip up to [GossipMaxSize]
+			size := next.Size()
+			if size+bundleSize > g.cfg.GossipMaxSize {
+				return false, true, nil
+			}
+
+			// Don't remove anything from mempool
+			// that will be dropped
+			bundleID := next.ID()
+			if _, ok := g.cache.Get(bundleID); ok {
+				return true, true, nil
+			}
+			g.cache.Put(bundleID, nil)
+
+			bundles = append(bundles, next)
+			bundleTxs += len(next.Txs)
+			bundleSize += size
+			return true, false, nil
+		},
+	)
+	if bundleErr != nil {
+		return bundleErr
+	}
+	if len(txs) == 0 && len(bundles) == 0 {
 		g.vm.Logger().Warn("no transactions to gossip")
 		return nil
 	}
-	g.vm.Logger().Info("gossiping transactions", zap.Int("txs", len(txs)), zap.Duration("t", time.Since(start)))
-	g.vm.RecordTxsGossiped(len(txs))
-	return g.sendTxs(ctx, txs)
+	if len(txs) > 0 {
+		g.vm.Logger().Info("gossiping transactions", zap.Int("txs", len(txs)), zap.Duration("t", time.Since(start)))
+		g.vm.RecordTxsGossiped(len(txs))
+		if err := g.sendTxs(ctx, txs); err != nil {
+			return err
+		}
+	}
+	if len(bundles) > 0 {
+		g.vm.Logger().Info("gossiping bundles", zap.Int("bundles", len(bundles)), zap.Int("txs", bundleTxs), zap.Duration("t", time.Since(start)))
+		g.vm.RecordTxsGossiped(bundleTxs)
+		return g.sendBundles(ctx, bundles)
+	}
+	return nil
 }
 
 func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
-	actionRegistry, authRegistry := g.vm.Registry()
-	authCounts, txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
-	if err != nil {
+	if len(msg) == 0 {
 		g.vm.Logger().Warn(
-			"received invalid txs",
+			"received empty gossip",
 			zap.Stringer("peerID", nodeID),
-			zap.Error(err),
 		)
 		return nil
 	}
-	g.vm.RecordTxsReceived(len(txs))
+	switch msg[0] {
+	case txsMsg:
+		return g.handleTxs(ctx, nodeID, msg[1:])
+	case bundlesMsg:
+		return g.handleBundles(ctx, nodeID, msg[1:])
+	default:
+		g.vm.Logger().Warn(
+			"received gossip of unknown type",
+			zap.Stringer("peerID", nodeID),
+			zap.Uint8("type", msg[0]),
+		)
+		return nil
+	}
+}
 
-	// Add incoming transactions to our caches to prevent useless gossip and perform
-	// batch signature verification.
-	//
-	// We rely on AppGossipConcurrency to regulate concurrency here, so we don't create
-	// a separate pool of workers for this verification.
+// verifyAuth performs batch signature verification of [txs].
+//
+// We rely on AppGossipConcurrency to regulate concurrency here, so we don't create
+// a separate pool of workers for this verification.
+func (g *Proposer) verifyAuth(nodeID ids.NodeID, authCounts map[uint8]int, txs []*chain.Transaction) bool {
 	job, err := workers.NewSerial().NewJob(len(txs))
 	if err != nil {
 		g.vm.Logger().Warn(
@@ -184,10 +252,9 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
-		return nil
+		return false
 	}
 	batchVerifier := chain.NewAuthBatch(g.vm, job, authCounts)
-	var seen int
 	for _, tx := range txs {
 		// Verify signature async
 		txDigest, err := tx.Digest()
@@ -198,19 +265,13 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 				zap.Error(err),
 			)
 			batchVerifier.Done(nil)
-			return nil
+			return false
 		}
-		batchVerifier.Add(txDigest, tx.Auth)
-
-		// Add incoming txs to the cache to make
-		// sure we never gossip anything we receive (someone
-		// else will)
-		if g.cache.Put(tx.ID(), nil) {
-			seen++
+		for _, auth := range tx.Auths() {
+			batchVerifier.Add(txDigest, auth)
 		}
 	}
 	batchVerifier.Done(nil)
-	g.vm.RecordSeenTxsReceived(seen)
 
 	// Wait for signature verification to finish
 	if err := job.Wait(); err != nil {
@@ -219,6 +280,35 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		return false
+	}
+	return true
+}
+
+func (g *Proposer) handleTxs(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	actionRegistry, authRegistry := g.vm.Registry()
+	authCounts, txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
+	if err != nil {
+		g.vm.Logger().Warn(
+			"received invalid txs",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	g.vm.RecordTxsReceived(len(txs))
+
+	// Add incoming txs to the cache to make
+	// sure we never gossip anything we receive (someone
+	// else will)
+	var seen int
+	for _, tx := range txs {
+		if g.cache.Put(tx.ID(), nil) {
+			seen++
+		}
+	}
+	g.vm.RecordSeenTxsReceived(seen)
+	if !g.verifyAuth(nodeID, authCounts, txs) {
 		return nil
 	}
 
@@ -259,6 +349,73 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 	return nil
 }
 
+func (g *Proposer) handleBundles(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	actionRegistry, authRegistry := g.vm.Registry()
+	authCounts, bundles, err := chain.UnmarshalBundles(msg, initialCapacity, actionRegistry, authRegistry)
+	if err != nil {
+		g.vm.Logger().Warn(
+			"received invalid bundles",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	txs := []*chain.Transaction{}
+	for _, bundle := range bundles {
+		txs = append(txs, bundle.Txs...)
+	}
+	g.vm.RecordTxsReceived(len(txs))
+
+	// Add incoming bundles to the cache to make
+	// sure we never gossip anything we receive
+	//
+	// [seen] counts the txs in previously seen bundles (like [handleTxs]).
+	var seen int
+	for _, bundle := range bundles {
+		if g.cache.Put(bundle.ID(), nil) {
+			seen += len(bundle.Txs)
+		}
+	}
+	g.vm.RecordSeenTxsReceived(seen)
+	if !g.verifyAuth(nodeID, authCounts, txs) {
+		return nil
+	}
+
+	// Mark incoming gossip as held by [nodeID], if it is a validator
+	isValidator, err := g.vm.IsValidator(ctx, nodeID)
+	if err != nil {
+		g.vm.Logger().Warn(
+			"unable to determine if nodeID is validator",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+	}
+
+	// Submit incoming gossip to bundle mempool
+	start := time.Now()
+	for _, err := range g.vm.SubmitBundles(ctx, false, bundles) {
+		if err == nil || errors.Is(err, chain.ErrDuplicateTx) {
+			continue
+		}
+		g.vm.Logger().Debug(
+			"failed to submit gossiped bundles",
+			zap.Stringer("nodeID", nodeID),
+			zap.Bool("validator", isValidator),
+			zap.Error(err),
+		)
+	}
+	g.vm.Logger().Info(
+		"bundle gossip received",
+		zap.Int("bundles", len(bundles)),
+		zap.Int("txs", len(txs)),
+		zap.Int("previously seen", seen),
+		zap.Stringer("nodeID", nodeID),
+		zap.Bool("validator", isValidator),
+		zap.Duration("t", time.Since(start)),
+	)
+	return nil
+}
+
 func (g *Proposer) notify() {
 	select {
 	case g.q <- struct{}{}:
@@ -339,6 +496,12 @@ func (g *Proposer) BlockVerified(t int64) {
 	g.lastVerified = t
 }
 
+func (g *Proposer) Evict(txIDs []ids.ID) {
+	for _, txID := range txIDs {
+		g.cache.Remove(txID)
+	}
+}
+
 func (g *Proposer) Done() {
 	g.timer.Stop()
 	<-g.doneGossip
@@ -353,7 +516,22 @@ func (g *Proposer) sendTxs(ctx context.Context, txs []*chain.Transaction) error
 	if err != nil {
 		return err
 	}
+	return g.send(ctx, append([]byte{txsMsg}, b...))
+}
+
+func (g *Proposer) sendBundles(ctx context.Context, bundles []*chain.Bundle) error {
+	ctx, span := g.vm.Tracer().Start(ctx, "Gossiper.sendBundles")
+	defer span.End()
+
+	// Marshal gossip
+	b, err := chain.MarshalBundles(bundles)
+	if err != nil {
+		return err
+	}
+	return g.send(ctx, append([]byte{bundlesMsg}, b...))
+}
 
+func (g *Proposer) send(ctx context.Context, b []byte) error {
 	// Select next set of proposers and send gossip to them
 	proposers, err := g.vm.Proposers(
 		ctx,
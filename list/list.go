@@ -94,6 +94,11 @@ func (l *List[T]) PushBack(v T) *Element[T] {
 	return l.insertValueAfter(v, l.root.prev)
 }
 
+// InsertAfter inserts [v] immediately after [at] (which must be in [l]).
+func (l *List[T]) InsertAfter(v T, at *Element[T]) *Element[T] {
+	return l.insertValueAfter(v, at)
+}
+
 func (l *List[T]) Remove(e *Element[T]) T {
 	if e.list == l {
 		l.remove(e)
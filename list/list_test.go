@@ -86,3 +86,23 @@ func TestList(t *testing.T) {
 	require.Nil(l.First())
 	require.Nil(l.Last())
 }
+
+func TestListInsertAfter(t *testing.T) {
+	require := require.New(t)
+	l := List[*TestItem]{}
+
+	foo := l.PushBack(GenerateTestItem("foo"))
+	l.PushBack(GenerateTestItem("bar"))
+	l.InsertAfter(GenerateTestItem("baz"), foo)
+	require.Equal(3, l.Size())
+
+	// list is now (foo, baz, bar)
+	require.Equal("foo", l.First().Value().Str())
+	require.Equal("baz", l.First().Next().Value().Str())
+	require.Equal("bar", l.Last().Value().Str())
+	require.Equal("baz", l.Last().Prev().Value().Str())
+
+	l.InsertAfter(GenerateTestItem("qux"), l.Last())
+	require.Equal(4, l.Size())
+	require.Equal("qux", l.Last().Value().Str())
+}
@@ -5,6 +5,7 @@ package mempool
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -14,8 +15,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/eheap"
+	"github.com/ava-labs/hypersdk/heap"
 	"github.com/ava-labs/hypersdk/list"
 	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/exp/maps"
 )
 
 const maxPrealloc = 4_096
@@ -25,8 +28,43 @@ type Item interface {
 
 	Sponsor() codec.Address
 	Size() int
+
+	// Nonce is the sequence number of the item for its [Sponsor]. Items with
+	// a non-zero nonce are never returned ahead of items from the same [Sponsor]
+	// with a lower nonce.
+	Nonce() uint64
+
+	// PriorityFee is the tip paid by the item. Items that expire at the same time
+	// are returned in order of decreasing [PriorityFee].
+	PriorityFee() uint64
+
+	// MaxFee is the most the [Sponsor] may be charged for the item (excluding
+	// the [PriorityFee]).
+	MaxFee() uint64
+}
+
+// MultiSponsorItem is an [Item] whose fees may be paid by more than one
+// sponsor (like a bundle of transactions). [PendingFees] counts the [Fees]
+// of a [MultiSponsorItem] towards each of its sponsors (instead of counting
+// its [MaxFee] and [PriorityFee] towards its [Sponsor]).
+type MultiSponsorItem interface {
+	Item
+
+	// Fees returns the most each sponsor may be charged for the item
+	// (including the [PriorityFee]).
+	Fees() map[codec.Address]uint64
 }
 
+// Priority returns the value used to order an item in a [Mempool] created with
+// [NewPriority]. Items with a higher priority are returned first and items
+// with the lowest priority are evicted when the [Mempool] is full.
+type Priority[T Item] func(T) uint64
+
+// Evicted is called with each item a [Mempool] created with [NewPriority]
+// evicts to make room for a higher priority item. It is called while the
+// [Mempool] is locked, so it must not call back into the [Mempool].
+type Evicted[T Item] func(T)
+
 type Mempool[T Item] struct {
 	tracer trace.Tracer
 
@@ -40,10 +78,27 @@ type Mempool[T Item] struct {
 	queue *list.List[T]
 	eh    *eheap.ExpiryHeap[*list.Element[T]]
 
+	// priority is nil if items are returned in the order they were added
+	// (FIFO). Otherwise, items are tracked by [priority] in [byPriority]
+	// (to return the highest priority items first) and in [cheapest] (to
+	// evict the lowest priority items when full).
+	priority   Priority[T]
+	evicted    Evicted[T]
+	byPriority *heap.Heap[*list.Element[T], uint64]
+	cheapest   *heap.Heap[*list.Element[T], uint64]
+
 	// owned tracks the number of items in the mempool owned by a single
 	// [Sponsor]
 	owned map[codec.Address]int
 
+	// fees tracks the sum of the [MaxFee] and [PriorityFee] of all items in
+	// the mempool paid by a single sponsor (see [MultiSponsorItem])
+	fees map[codec.Address]uint64
+
+	// sequenced tracks items with a non-zero [Nonce] by [Sponsor], sorted
+	// by [Nonce]
+	sequenced map[codec.Address][]*list.Element[T]
+
 	// streamedItems have been removed from the mempool during streaming
 	// and should not be re-added by calls to [Add].
 	streamLock        sync.Mutex // should never be needed
@@ -62,6 +117,36 @@ func New[T Item](
 	maxSize int, // items
 	maxSponsorSize int,
 	exemptSponsors []codec.Address,
+) *Mempool[T] {
+	return newMempool[T](tracer, maxSize, maxSponsorSize, exemptSponsors, nil, nil)
+}
+
+// NewPriority creates a new [Mempool] that returns items in order of
+// decreasing [priority] (instead of the order they were added). When full, an
+// item with a higher [priority] than the lowest priority item in the
+// [Mempool] evicts it (and calls [evicted] with it, if not nil). [maxSize]
+// must be > 0 or else the implementation may panic.
+//
+// Items with a non-zero [Nonce] are still never returned ahead of items from
+// the same [Sponsor] with a lower [Nonce].
+func NewPriority[T Item](
+	tracer trace.Tracer,
+	maxSize int, // items
+	maxSponsorSize int,
+	exemptSponsors []codec.Address,
+	priority Priority[T],
+	evicted Evicted[T],
+) *Mempool[T] {
+	return newMempool[T](tracer, maxSize, maxSponsorSize, exemptSponsors, priority, evicted)
+}
+
+func newMempool[T Item](
+	tracer trace.Tracer,
+	maxSize int,
+	maxSponsorSize int,
+	exemptSponsors []codec.Address,
+	priority Priority[T],
+	evicted Evicted[T],
 ) *Mempool[T] {
 	m := &Mempool[T]{
 		tracer: tracer,
@@ -73,14 +158,58 @@ func New[T Item](
 		eh:    eheap.New[*list.Element[T]](math.Min(maxSize, maxPrealloc)),
 
 		owned:          map[codec.Address]int{},
+		fees:           map[codec.Address]uint64{},
+		sequenced:      map[codec.Address][]*list.Element[T]{},
 		exemptSponsors: set.Set[codec.Address]{},
 	}
+	if priority != nil {
+		m.priority = priority
+		m.evicted = evicted
+		m.byPriority = heap.New[*list.Element[T], uint64](math.Min(maxSize, maxPrealloc), false)
+		m.cheapest = heap.New[*list.Element[T], uint64](math.Min(maxSize, maxPrealloc), true)
+	}
 	for _, sponsor := range exemptSponsors {
 		m.exemptSponsors.Add(sponsor)
 	}
 	return m
 }
 
+// fee returns the most the [Sponsor] of [item] may be charged for it. Sums of
+// fees may overflow but, because they are only ever increased and decreased by
+// the same amounts, they are correct whenever the actual sum fits in a uint64.
+func fee[T Item](item T) uint64 {
+	return item.MaxFee() + item.PriorityFee()
+}
+
+func (m *Mempool[T]) addFees(item T) {
+	msi, ok := any(item).(MultiSponsorItem)
+	if !ok {
+		m.fees[item.Sponsor()] += fee(item)
+		return
+	}
+	for sponsor, amount := range msi.Fees() {
+		m.fees[sponsor] += amount
+	}
+}
+
+func (m *Mempool[T]) removeFees(item T) {
+	remove := func(sponsor codec.Address, amount uint64) {
+		if m.fees[sponsor] == amount {
+			delete(m.fees, sponsor)
+			return
+		}
+		m.fees[sponsor] -= amount
+	}
+	msi, ok := any(item).(MultiSponsorItem)
+	if !ok {
+		remove(item.Sponsor(), fee(item))
+		return
+	}
+	for sponsor, amount := range msi.Fees() {
+		remove(sponsor, amount)
+	}
+}
+
 func (m *Mempool[T]) removeFromOwned(item T) {
 	sender := item.Sponsor()
 	items, ok := m.owned[sender]
@@ -88,6 +217,7 @@ func (m *Mempool[T]) removeFromOwned(item T) {
 		// May no longer be populated
 		return
 	}
+	m.removeFees(item)
 	if items == 1 {
 		delete(m.owned, sender)
 		return
@@ -95,6 +225,134 @@ func (m *Mempool[T]) removeFromOwned(item T) {
 	m.owned[sender] = items - 1
 }
 
+func (m *Mempool[T]) addToSequenced(elem *list.Element[T]) {
+	item := elem.Value()
+	nonce := item.Nonce()
+	if nonce == 0 {
+		return
+	}
+	sender := item.Sponsor()
+	elems := m.sequenced[sender]
+	i := sort.Search(len(elems), func(i int) bool {
+		return elems[i].Value().Nonce() > nonce
+	})
+	elems = append(elems, nil)
+	copy(elems[i+1:], elems[i:])
+	elems[i] = elem
+	m.sequenced[sender] = elems
+}
+
+func (m *Mempool[T]) removeFromSequenced(elem *list.Element[T]) {
+	item := elem.Value()
+	if item.Nonce() == 0 {
+		return
+	}
+	sender := item.Sponsor()
+	elems := m.sequenced[sender]
+	for i, e := range elems {
+		if e != elem {
+			continue
+		}
+		if len(elems) == 1 {
+			delete(m.sequenced, sender)
+			return
+		}
+		m.sequenced[sender] = append(elems[:i], elems[i+1:]...)
+		return
+	}
+}
+
+// pushBack adds [item] to the back of the queue, ahead of any items
+// that expire at the same time and pay a lower [PriorityFee].
+func (m *Mempool[T]) pushBack(item T) *list.Element[T] {
+	at := m.queue.Last()
+	for at != nil && at.Expiry() == item.Expiry() && at.Value().PriorityFee() < item.PriorityFee() {
+		at = at.Prev()
+	}
+	if at == nil {
+		return m.queue.PushFront(item)
+	}
+	return m.queue.InsertAfter(item, at)
+}
+
+func (m *Mempool[T]) addToPriority(elem *list.Element[T], priority uint64) {
+	if m.priority == nil {
+		return
+	}
+	id := elem.ID()
+	m.byPriority.Push(&heap.Entry[*list.Element[T], uint64]{ID: id, Item: elem, Val: priority, Index: m.byPriority.Len()})
+	m.cheapest.Push(&heap.Entry[*list.Element[T], uint64]{ID: id, Item: elem, Val: priority, Index: m.cheapest.Len()})
+}
+
+func (m *Mempool[T]) removeFromPriority(elem *list.Element[T]) {
+	if m.priority == nil {
+		return
+	}
+	id := elem.ID()
+	if entry, ok := m.byPriority.Get(id); ok {
+		m.byPriority.Remove(entry.Index)
+	}
+	if entry, ok := m.cheapest.Get(id); ok {
+		m.cheapest.Remove(entry.Index)
+	}
+}
+
+// removeElem removes [elem] from everything but [m.eh].
+func (m *Mempool[T]) removeElem(elem *list.Element[T]) T {
+	m.removeFromSequenced(elem)
+	m.removeFromPriority(elem)
+	v := m.queue.Remove(elem)
+	m.removeFromOwned(v)
+	m.pendingSize -= v.Size()
+	return v
+}
+
+// evict removes the lowest priority item if it has a lower priority than
+// [priority]. It returns false if nothing was evicted.
+func (m *Mempool[T]) evict(priority uint64) bool {
+	if m.priority == nil {
+		return false
+	}
+	cheapest := m.cheapest.First()
+	if cheapest == nil || cheapest.Val >= priority {
+		return false
+	}
+	m.eh.Remove(cheapest.ID)
+	item := m.removeElem(cheapest.Item)
+	if m.evicted != nil {
+		m.evicted(item)
+	}
+	return true
+}
+
+// first returns the element with the highest priority (or the front of the
+// queue if [m.priority] is nil).
+func (m *Mempool[T]) first() *list.Element[T] {
+	if m.priority == nil {
+		return m.queue.First()
+	}
+	entry := m.byPriority.First()
+	if entry == nil {
+		return nil
+	}
+	return entry.Item
+}
+
+// next returns the element that should be returned next from the
+// mempool. If the first element has a [Nonce], the element with the
+// lowest [Nonce] from the same [Sponsor] is returned instead.
+func (m *Mempool[T]) next() *list.Element[T] {
+	first := m.first()
+	if first == nil {
+		return nil
+	}
+	item := first.Value()
+	if item.Nonce() == 0 {
+		return first
+	}
+	return m.sequenced[item.Sponsor()][0]
+}
+
 // Has returns if the eh of [m] contains [itemID]
 func (m *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 	_, span := m.tracer.Start(ctx, "Mempool.Has")
@@ -106,10 +364,102 @@ func (m *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 	return m.eh.Has(itemID)
 }
 
+// Get returns the item with [itemID] (if it is in the eh of [m])
+func (m *Mempool[T]) Get(ctx context.Context, itemID ids.ID) (T, bool) {
+	_, span := m.tracer.Start(ctx, "Mempool.Get")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	elem, ok := m.eh.Get(itemID)
+	if !ok {
+		return *new(T), false
+	}
+	return elem.Value(), true
+}
+
+// Items returns all items in m in the order they are considered for
+// inclusion: the order they were added or, if m was created with
+// [NewPriority], decreasing priority. An item with a non-zero [Nonce] is
+// still not returned ahead of items from the same [Sponsor] with a lower
+// [Nonce], so it may be returned later than its position suggests.
+func (m *Mempool[T]) Items(ctx context.Context) []T {
+	_, span := m.tracer.Start(ctx, "Mempool.Items")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	elems := make([]*list.Element[T], 0, m.queue.Size())
+	for elem := m.queue.First(); elem != nil; elem = elem.Next() {
+		elems = append(elems, elem)
+	}
+	if m.priority != nil {
+		priorities := make(map[ids.ID]uint64, len(elems))
+		for _, entry := range m.byPriority.Items() {
+			priorities[entry.ID] = entry.Val
+		}
+		sort.SliceStable(elems, func(i, j int) bool {
+			return priorities[elems[i].ID()] > priorities[elems[j].ID()]
+		})
+	}
+	items := make([]T, len(elems))
+	for i, elem := range elems {
+		items[i] = elem.Value()
+	}
+	return items
+}
+
+// Owned returns the number of items in m owned by each [Sponsor].
+func (m *Mempool[T]) Owned(ctx context.Context) map[codec.Address]int {
+	_, span := m.tracer.Start(ctx, "Mempool.Owned")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return maps.Clone(m.owned)
+}
+
+// PendingFees returns the sum of the [MaxFee] and [PriorityFee] of all items
+// in m paid by [sponsor] (including its share of any [MultiSponsorItem]).
+func (m *Mempool[T]) PendingFees(ctx context.Context, sponsor codec.Address) uint64 {
+	_, span := m.tracer.Start(ctx, "Mempool.PendingFees")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.fees[sponsor]
+}
+
+// RemoveSponsor removes and returns all items from m owned by [sponsor].
+func (m *Mempool[T]) RemoveSponsor(ctx context.Context, sponsor codec.Address) []T {
+	_, span := m.tracer.Start(ctx, "Mempool.RemoveSponsor")
+	defer span.End()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := make([]T, 0, m.owned[sponsor])
+	elem := m.queue.First()
+	for elem != nil && len(removed) < cap(removed) {
+		next := elem.Next()
+		if elem.Value().Sponsor() == sponsor {
+			m.eh.Remove(elem.ID())
+			removed = append(removed, m.removeElem(elem))
+		}
+		elem = next
+	}
+	return removed
+}
+
 // Add pushes all new items from [items] to m. Does not add a item if
 // the item sponsor is not exempt and their items in the mempool exceed m.maxSponsorSize.
-// If the size of m exceeds m.maxSize, Add pops the lowest value item
-// from m.eh.
+// If the size of m exceeds m.maxSize, Add drops the item (or, if m was created
+// with [NewPriority], evicts the lowest priority item if it is lower than the
+// priority of the item).
 func (m *Mempool[T]) Add(ctx context.Context, items []T) {
 	_, span := m.tracer.Start(ctx, "Mempool.Add")
 	defer span.End()
@@ -120,6 +470,26 @@ func (m *Mempool[T]) Add(ctx context.Context, items []T) {
 	m.add(items, false)
 }
 
+// Admit is like [Add] but also returns the items from [items] that are in m
+// once they are added (excluding any that were dropped or evicted by a later
+// item in [items]).
+func (m *Mempool[T]) Admit(ctx context.Context, items []T) []T {
+	_, span := m.tracer.Start(ctx, "Mempool.Admit")
+	defer span.End()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.add(items, false)
+	admitted := make([]T, 0, len(items))
+	for _, item := range items {
+		if m.eh.Has(item.ID()) {
+			admitted = append(admitted, item)
+		}
+	}
+	return admitted
+}
+
 func (m *Mempool[T]) add(items []T, front bool) {
 	for _, item := range items {
 		sender := item.Sponsor()
@@ -141,19 +511,26 @@ func (m *Mempool[T]) add(items []T, front bool) {
 		}
 
 		// Ensure mempool isn't full
-		if m.queue.Size() == m.maxSize {
+		var priority uint64
+		if m.priority != nil {
+			priority = m.priority(item)
+		}
+		if m.queue.Size() == m.maxSize && !m.evict(priority) {
 			continue // do nothing, wait for items to expire
 		}
 
 		// Add to mempool
 		var elem *list.Element[T]
 		if !front {
-			elem = m.queue.PushBack(item)
+			elem = m.pushBack(item)
 		} else {
 			elem = m.queue.PushFront(item)
 		}
 		m.eh.Add(elem)
+		m.addToSequenced(elem)
+		m.addToPriority(elem, priority)
 		m.owned[sender]++
+		m.addFees(item)
 		m.pendingSize += item.Size()
 	}
 }
@@ -167,11 +544,11 @@ func (m *Mempool[T]) PeekNext(ctx context.Context) (T, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	first := m.queue.First()
-	if first == nil {
+	next := m.next()
+	if next == nil {
 		return *new(T), false
 	}
-	return first.Value(), true
+	return next.Value(), true
 }
 
 // PopNext removes and returns the highest valued item in m.eh.
@@ -187,15 +564,12 @@ func (m *Mempool[T]) PopNext(ctx context.Context) (T, bool) { // O(log N)
 }
 
 func (m *Mempool[T]) popNext() (T, bool) {
-	first := m.queue.First()
-	if first == nil {
+	next := m.next()
+	if next == nil {
 		return *new(T), false
 	}
-	v := m.queue.Remove(first)
-	m.eh.Remove(v.ID())
-	m.removeFromOwned(v)
-	m.pendingSize -= v.Size()
-	return v, true
+	m.eh.Remove(next.ID())
+	return m.removeElem(next), true
 }
 
 // Remove removes [items] from m.
@@ -211,9 +585,7 @@ func (m *Mempool[T]) Remove(ctx context.Context, items []T) {
 		if !ok {
 			continue
 		}
-		m.queue.Remove(elem)
-		m.removeFromOwned(item)
-		m.pendingSize -= item.Size()
+		m.removeElem(elem)
 	}
 }
 
@@ -247,11 +619,7 @@ func (m *Mempool[T]) SetMinTimestamp(ctx context.Context, t int64) []T {
 	removedElems := m.eh.SetMin(t)
 	removed := make([]T, len(removedElems))
 	for i, remove := range removedElems {
-		m.queue.Remove(remove)
-		v := remove.Value()
-		m.removeFromOwned(v)
-		m.pendingSize -= v.Size()
-		removed[i] = v
+		removed[i] = m.removeElem(remove)
 	}
 	return removed
 }
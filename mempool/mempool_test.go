@@ -6,6 +6,7 @@ package mempool
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/codec"
@@ -20,6 +21,9 @@ type TestItem struct {
 	id        ids.ID
 	sponsor   codec.Address
 	timestamp int64
+	nonce     uint64
+	fee       uint64
+	maxFee    uint64
 }
 
 func (mti *TestItem) ID() ids.ID {
@@ -38,6 +42,18 @@ func (*TestItem) Size() int {
 	return 2 // distinguish from len
 }
 
+func (mti *TestItem) Nonce() uint64 {
+	return mti.nonce
+}
+
+func (mti *TestItem) PriorityFee() uint64 {
+	return mti.fee
+}
+
+func (mti *TestItem) MaxFee() uint64 {
+	return mti.maxFee
+}
+
 func GenerateTestItem(sponsor codec.Address, t int64) *TestItem {
 	id := ids.GenerateTestID()
 	return &TestItem{
@@ -47,6 +63,18 @@ func GenerateTestItem(sponsor codec.Address, t int64) *TestItem {
 	}
 }
 
+func GenerateTestItemWithNonce(sponsor codec.Address, t int64, nonce uint64) *TestItem {
+	item := GenerateTestItem(sponsor, t)
+	item.nonce = nonce
+	return item
+}
+
+func GenerateTestItemWithPriorityFee(sponsor codec.Address, t int64, fee uint64) *TestItem {
+	item := GenerateTestItem(sponsor, t)
+	item.fee = fee
+	return item
+}
+
 func TestMempool(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -162,6 +190,137 @@ func TestMempoolRemoveTxs(t *testing.T) {
 	require.Equal(0, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
 
+func TestMempoolGet(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := New[*TestItem](tracer, 3, 20, nil)
+	item := GenerateTestItem(testSponsor, 10)
+	_, ok := txm.Get(ctx, item.ID())
+	require.False(ok, "TX found before added")
+	txm.Add(ctx, []*TestItem{item})
+	got, ok := txm.Get(ctx, item.ID())
+	require.True(ok, "TX not found")
+	require.Equal(item, got)
+
+	// Items that are popped can't be found
+	_, ok = txm.PopNext(ctx)
+	require.True(ok)
+	_, ok = txm.Get(ctx, item.ID())
+	require.False(ok, "TX found after removed")
+}
+
+func TestMempoolItems(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	// Items are listed in the order they were added
+	txm := New[*TestItem](tracer, 10, 20, nil)
+	first := GenerateTestItemWithPriorityFee(testSponsor, 10, 1)
+	second := GenerateTestItemWithPriorityFee(testSponsor, 20, 10)
+	third := GenerateTestItemWithPriorityFee(testSponsor, 15, 5)
+	txm.Add(ctx, []*TestItem{first, second, third})
+	require.Equal([]*TestItem{first, second, third}, txm.Items(ctx))
+	require.Equal(3, txm.Len(ctx), "Items should not remove items")
+
+	// Items are listed by priority
+	ptxm := NewPriority[*TestItem](tracer, 10, 20, nil, testPriority, nil)
+	ptxm.Add(ctx, []*TestItem{first, second, third})
+	require.Equal([]*TestItem{second, third, first}, ptxm.Items(ctx))
+}
+
+func TestMempoolRemoveSponsor(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	otherSponsor := codec.CreateAddress(1, ids.GenerateTestID())
+	txm := New[*TestItem](tracer, 10, 20, nil)
+	item1 := GenerateTestItem(testSponsor, 10)
+	other := GenerateTestItem(otherSponsor, 11)
+	item2 := GenerateTestItemWithNonce(testSponsor, 12, 1)
+	txm.Add(ctx, []*TestItem{item1, other, item2})
+	require.Equal(map[codec.Address]int{testSponsor: 2, otherSponsor: 1}, txm.Owned(ctx))
+
+	removed := txm.RemoveSponsor(ctx, testSponsor)
+	require.Equal([]*TestItem{item1, item2}, removed)
+	require.Equal(1, txm.Len(ctx))
+	require.Equal(map[codec.Address]int{otherSponsor: 1}, txm.Owned(ctx))
+	require.Empty(txm.sequenced)
+	require.Empty(txm.RemoveSponsor(ctx, testSponsor))
+}
+
+func TestMempoolPendingFees(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	otherSponsor := codec.CreateAddress(1, ids.GenerateTestID())
+	txm := New[*TestItem](tracer, 10, 20, nil)
+	item1 := GenerateTestItemWithPriorityFee(testSponsor, 10, 1)
+	item1.maxFee = 100
+	item2 := GenerateTestItemWithPriorityFee(testSponsor, 11, 2)
+	item2.maxFee = 200
+	other := GenerateTestItem(otherSponsor, 12)
+	other.maxFee = 50
+	txm.Add(ctx, []*TestItem{item1, item2, other})
+	require.Equal(uint64(303), txm.PendingFees(ctx, testSponsor))
+	require.Equal(uint64(50), txm.PendingFees(ctx, otherSponsor))
+
+	// Fees are no longer pending once items are removed
+	next, ok := txm.PopNext(ctx)
+	require.True(ok)
+	require.Equal(item1, next)
+	require.Equal(uint64(202), txm.PendingFees(ctx, testSponsor))
+	txm.Remove(ctx, []*TestItem{item2})
+	require.Zero(txm.PendingFees(ctx, testSponsor))
+	require.Empty(txm.SetMinTimestamp(ctx, 12))
+	require.Equal(uint64(50), txm.PendingFees(ctx, otherSponsor))
+	require.Len(txm.SetMinTimestamp(ctx, 13), 1)
+	require.Zero(txm.PendingFees(ctx, otherSponsor))
+	require.Empty(txm.fees)
+}
+
+// testMultiSponsorItem is a [TestItem] whose fees are paid by [fees]
+type testMultiSponsorItem struct {
+	*TestItem
+
+	fees map[codec.Address]uint64
+}
+
+func (mti *testMultiSponsorItem) Fees() map[codec.Address]uint64 {
+	return mti.fees
+}
+
+func TestMempoolPendingFeesMultiSponsor(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	otherSponsor := codec.CreateAddress(1, ids.GenerateTestID())
+	txm := New[*testMultiSponsorItem](tracer, 10, 20, nil)
+	item1 := &testMultiSponsorItem{
+		TestItem: GenerateTestItem(testSponsor, 10),
+		fees:     map[codec.Address]uint64{testSponsor: 10, otherSponsor: 100},
+	}
+	item2 := &testMultiSponsorItem{
+		TestItem: GenerateTestItem(otherSponsor, 11),
+		fees:     map[codec.Address]uint64{otherSponsor: 50},
+	}
+	txm.Add(ctx, []*testMultiSponsorItem{item1, item2})
+
+	// Fees count towards each sponsor (not just the [Sponsor] of the item)
+	require.Equal(uint64(10), txm.PendingFees(ctx, testSponsor))
+	require.Equal(uint64(150), txm.PendingFees(ctx, otherSponsor))
+	txm.Remove(ctx, []*testMultiSponsorItem{item1})
+	require.Zero(txm.PendingFees(ctx, testSponsor))
+	require.Equal(uint64(50), txm.PendingFees(ctx, otherSponsor))
+	txm.Remove(ctx, []*testMultiSponsorItem{item2})
+	require.Empty(txm.fees)
+}
+
 func TestMempoolSetMinTimestamp(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -191,3 +350,224 @@ func TestMempoolSetMinTimestamp(t *testing.T) {
 	// Mempool has same length
 	require.Equal(5, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
+
+func TestMempoolNonceOrder(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	otherSponsor := codec.CreateAddress(1, ids.GenerateTestID())
+	txm := New[*TestItem](tracer, 20, 20, nil)
+	item3 := GenerateTestItemWithNonce(testSponsor, 10, 3)
+	other := GenerateTestItem(otherSponsor, 10)
+	item1 := GenerateTestItemWithNonce(testSponsor, 10, 1)
+	unsequenced := GenerateTestItem(testSponsor, 10)
+	item2 := GenerateTestItemWithNonce(testSponsor, 10, 2)
+	txm.Add(ctx, []*TestItem{item3, other, item1, unsequenced, item2})
+	require.Equal(5, txm.Len(ctx))
+
+	// Whenever an item with a nonce reaches the front of the queue, the item
+	// with the lowest nonce from the same sponsor is returned instead
+	next, ok := txm.PeekNext(ctx)
+	require.True(ok)
+	require.Equal(item1.ID(), next.ID())
+	for _, expected := range []*TestItem{item1, item2, item3, other, unsequenced} {
+		next, ok := txm.PopNext(ctx)
+		require.True(ok)
+		require.Equal(expected.ID(), next.ID())
+	}
+	require.Zero(txm.Len(ctx))
+	require.Empty(txm.sequenced)
+
+	// Removed items are no longer sequenced
+	txm.Add(ctx, []*TestItem{item2, item1, item3})
+	txm.Remove(ctx, []*TestItem{item1})
+	next, ok = txm.PopNext(ctx)
+	require.True(ok)
+	require.Equal(item2.ID(), next.ID())
+	require.Len(txm.SetMinTimestamp(ctx, 11), 1)
+	require.Empty(txm.sequenced)
+}
+
+func TestMempoolPriorityFeeTiebreak(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := New[*TestItem](tracer, 20, 20, nil)
+	early := GenerateTestItemWithPriorityFee(testSponsor, 10, 0)
+	low := GenerateTestItemWithPriorityFee(testSponsor, 20, 1)
+	none := GenerateTestItemWithPriorityFee(testSponsor, 20, 0)
+	high := GenerateTestItemWithPriorityFee(testSponsor, 20, 5)
+	mid := GenerateTestItemWithPriorityFee(testSponsor, 20, 3)
+	late := GenerateTestItemWithPriorityFee(testSponsor, 30, 10)
+	tie := GenerateTestItemWithPriorityFee(testSponsor, 20, 3)
+	txm.Add(ctx, []*TestItem{early, low, none, high, mid, late, tie})
+	require.Equal(7, txm.Len(ctx))
+
+	// Consecutive items that expire at the same time are ordered by priority fee
+	// (and then by arrival). Otherwise, items are returned in the order they arrived.
+	for _, expected := range []*TestItem{early, high, mid, low, none, late, tie} {
+		next, ok := txm.PopNext(ctx)
+		require.True(ok)
+		require.Equal(expected.ID(), next.ID())
+	}
+	require.Zero(txm.Len(ctx))
+}
+
+func testPriority(item *TestItem) uint64 {
+	return item.fee
+}
+
+func TestMempoolPriorityOrder(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	otherSponsor := codec.CreateAddress(1, ids.GenerateTestID())
+	txm := NewPriority[*TestItem](tracer, 20, 20, nil, testPriority, nil)
+	low := GenerateTestItemWithPriorityFee(otherSponsor, 10, 1)
+	high := GenerateTestItemWithPriorityFee(otherSponsor, 30, 10)
+	mid := GenerateTestItemWithPriorityFee(otherSponsor, 20, 5)
+	item1 := GenerateTestItemWithNonce(testSponsor, 10, 1)
+	item1.fee = 2
+	item2 := GenerateTestItemWithNonce(testSponsor, 10, 2)
+	item2.fee = 8
+	txm.Add(ctx, []*TestItem{low, high, mid, item2, item1})
+	require.Equal(5, txm.Len(ctx))
+
+	// Items are returned by priority (regardless of expiry or arrival) but
+	// items with a nonce are still returned in nonce order
+	for _, expected := range []*TestItem{high, item1, item2, mid, low} {
+		next, ok := txm.PopNext(ctx)
+		require.True(ok)
+		require.Equal(expected.ID(), next.ID())
+	}
+	require.Zero(txm.Len(ctx))
+	require.Zero(txm.byPriority.Len())
+	require.Zero(txm.cheapest.Len())
+
+	// Removed items are no longer prioritized
+	txm.Add(ctx, []*TestItem{low, high, mid})
+	txm.Remove(ctx, []*TestItem{high})
+	require.Len(txm.SetMinTimestamp(ctx, 11), 1)
+	next, ok := txm.PeekNext(ctx)
+	require.True(ok)
+	require.Equal(mid.ID(), next.ID())
+	require.Equal(1, txm.byPriority.Len())
+	require.Equal(1, txm.cheapest.Len())
+}
+
+func TestMempoolPriorityEviction(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	exemptSponsor := codec.CreateAddress(99, ids.GenerateTestID())
+	txm := NewPriority[*TestItem](tracer, 3, 2, []codec.Address{exemptSponsor}, testPriority, nil)
+	items := []*TestItem{
+		GenerateTestItemWithPriorityFee(exemptSponsor, 10, 5),
+		GenerateTestItemWithPriorityFee(exemptSponsor, 10, 3),
+		GenerateTestItemWithPriorityFee(exemptSponsor, 10, 4),
+	}
+	txm.Add(ctx, items)
+	require.Equal(3, txm.Len(ctx))
+
+	// Items that pay less (or the same) as the cheapest item are dropped
+	cheap := GenerateTestItemWithPriorityFee(testSponsor, 10, 3)
+	txm.Add(ctx, []*TestItem{cheap})
+	require.False(txm.Has(ctx, cheap.ID()))
+	require.Equal(3, txm.Len(ctx))
+
+	// Items that pay more evict the cheapest item
+	expensive := GenerateTestItemWithPriorityFee(testSponsor, 10, 6)
+	txm.Add(ctx, []*TestItem{expensive})
+	require.True(txm.Has(ctx, expensive.ID()))
+	require.False(txm.Has(ctx, items[1].ID()))
+	require.Equal(3, txm.Len(ctx))
+	require.Equal(6, txm.Size(ctx))
+	require.Equal(2, txm.owned[exemptSponsor])
+
+	// Sponsor limits still apply
+	txm.Add(ctx, []*TestItem{GenerateTestItemWithPriorityFee(testSponsor, 10, 7)})
+	require.Equal(2, txm.owned[testSponsor])
+	overLimit := GenerateTestItemWithPriorityFee(testSponsor, 10, 8)
+	txm.Add(ctx, []*TestItem{overLimit})
+	require.False(txm.Has(ctx, overLimit.ID()))
+	require.Equal(3, txm.Len(ctx))
+}
+
+func TestMempoolAdmit(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	var evicted []*TestItem
+	txm := NewPriority[*TestItem](tracer, 2, 4, nil, testPriority, func(item *TestItem) {
+		evicted = append(evicted, item)
+	})
+	otherSponsor := codec.CreateAddress(1, ids.GenerateTestID())
+	low := GenerateTestItemWithPriorityFee(otherSponsor, 10, 1)
+	mid := GenerateTestItemWithPriorityFee(otherSponsor, 10, 2)
+	require.Equal([]*TestItem{low, mid}, txm.Admit(ctx, []*TestItem{low, mid}))
+	require.Empty(evicted)
+
+	// Items that are dropped or evicted by a later item are not admitted
+	cheap := GenerateTestItemWithPriorityFee(testSponsor, 10, 0)
+	high := GenerateTestItemWithPriorityFee(testSponsor, 10, 5)
+	higher := GenerateTestItemWithPriorityFee(testSponsor, 10, 6)
+	highest := GenerateTestItemWithPriorityFee(testSponsor, 10, 7)
+	require.Equal(
+		[]*TestItem{higher, highest},
+		txm.Admit(ctx, []*TestItem{cheap, high, higher, highest}),
+	)
+	require.Equal([]*TestItem{low, mid, high}, evicted)
+	require.Equal(2, txm.Len(ctx))
+
+	// Items already in the mempool are still admitted (and items restored
+	// after streaming can also evict)
+	require.Equal([]*TestItem{highest}, txm.Admit(ctx, []*TestItem{highest}))
+	txm.StartStreaming(ctx)
+	streamed := txm.Stream(ctx, 1)
+	require.Equal([]*TestItem{highest}, streamed)
+	txm.Add(ctx, []*TestItem{mid})
+	require.Equal(1, txm.FinishStreaming(ctx, streamed))
+	require.Equal([]*TestItem{low, mid, high, mid}, evicted)
+	require.True(txm.Has(ctx, highest.ID()))
+	require.True(txm.Has(ctx, higher.ID()))
+}
+
+func TestMempoolPriorityStream(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := NewPriority[*TestItem](tracer, 20, 20, nil, testPriority, nil)
+	var items []*TestItem
+	for i := uint64(0); i < 5; i++ {
+		items = append(items, GenerateTestItemWithPriorityFee(testSponsor, int64(i), i))
+	}
+	txm.Add(ctx, items)
+
+	// Stream in priority order
+	txm.StartStreaming(ctx)
+	streamed := txm.Stream(ctx, 2)
+	require.Equal([]*TestItem{items[4], items[3]}, streamed)
+	require.Equal(2, txm.FinishStreaming(ctx, streamed))
+	require.Equal(5, txm.Len(ctx))
+
+	// Top in priority order (restored items are re-prioritized)
+	var seen []*TestItem
+	require.NoError(txm.Top(ctx, time.Minute, func(_ context.Context, item *TestItem) (bool, bool, error) {
+		seen = append(seen, item)
+		return true, true, nil
+	}))
+	require.Equal([]*TestItem{items[4], items[3], items[2], items[1], items[0]}, seen)
+	next, ok := txm.PeekNext(ctx)
+	require.True(ok)
+	require.Equal(items[4].ID(), next.ID())
+}
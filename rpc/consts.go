@@ -9,6 +9,9 @@ const (
 	Name              = "hypersdk"
 	JSONRPCEndpoint   = "/coreapi"
 	WebSocketEndpoint = "/corews"
+	AdminEndpoint     = "/coreadmin"
+
+	MaxPendingTxsLimit = 1_000
 
 	DefaultHandshakeTimeout = 10 * time.Second
 )
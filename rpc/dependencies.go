@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 )
 
 type VM interface {
@@ -26,8 +27,21 @@ type VM interface {
 		verifySig bool,
 		txs []*chain.Transaction,
 	) (errs []error)
+	SubmitBundles(
+		ctx context.Context,
+		verifySig bool,
+		bundles []*chain.Bundle,
+	) (errs []error)
+	PendingTxs(context.Context) []*chain.Transaction
+	PendingSponsors(context.Context) map[codec.Address]int
+	EvictTxs(context.Context, []ids.ID) []*chain.Transaction
+	EvictSponsor(context.Context, codec.Address) []*chain.Transaction
+	Simulate(context.Context, *chain.Transaction, bool) (*chain.SimulationResult, error)
 	LastAcceptedBlock() *chain.StatelessBlock
 	UnitPrices(context.Context) (chain.Dimensions, error)
+	GetNonce(context.Context, codec.Address) (uint64, error)
+	GetReceipt(ids.ID) (*chain.Receipt, error)
+	Replay(context.Context, uint64) (*chain.ReplayResult, error)
 	GetOutgoingWarpMessage(ids.ID) (*warp.UnsignedMessage, error)
 	GetWarpSignatures(ids.ID) ([]*chain.WarpSignature, error)
 	CurrentValidators(
@@ -3,10 +3,31 @@
 
 package rpc
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/gorilla/rpc/v2/json2"
+)
 
 var (
 	ErrClosed         = errors.New("closed")
 	ErrExpired        = errors.New("expired")
 	ErrMessageMissing = errors.New("message missing")
+	ErrReceiptMissing = errors.New("receipt missing")
+	ErrUnauthorized   = errors.New("unauthorized")
 )
+
+// ErrCodeSponsorInsolvent is the JSON-RPC error code returned when a
+// transaction is rejected because its sponsor can't pay the fees of all of its
+// pending transactions ([chain.ErrSponsorInsolvent]).
+const ErrCodeSponsorInsolvent json2.ErrorCode = -32001
+
+// ErrorCode returns the JSON-RPC error code of an error returned by a
+// [JSONRPCClient] (if any).
+func ErrorCode(err error) (json2.ErrorCode, bool) {
+	var jsonErr *json2.Error
+	if !errors.As(err, &jsonErr) {
+		return 0, false
+	}
+	return jsonErr.Code, true
+}
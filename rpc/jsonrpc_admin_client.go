@@ -0,0 +1,71 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+type AdminJSONRPCClient struct {
+	requester *requester.EndpointRequester
+	token     string
+}
+
+// NewAdminJSONRPCClient creates a client for the [AdminJSONRPCServer] at
+// [uri] that authenticates with [token].
+func NewAdminJSONRPCClient(uri string, token string) *AdminJSONRPCClient {
+	uri = strings.TrimSuffix(uri, "/")
+	uri += AdminEndpoint
+	req := requester.New(uri, Name)
+	return &AdminJSONRPCClient{requester: req, token: token}
+}
+
+// EvictTxs removes [txIDs] from the mempool and returns the IDs of the
+// transactions that were removed.
+func (cli *AdminJSONRPCClient) EvictTxs(ctx context.Context, txIDs []ids.ID) ([]ids.ID, error) {
+	resp := new(EvictReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"evictTxs",
+		&EvictTxsArgs{TxIDs: txIDs},
+		resp,
+		requester.WithHeader("Authorization", "Bearer "+cli.token),
+	)
+	return resp.TxIDs, err
+}
+
+// EvictSponsor removes all transactions paid for by [sponsor] from the
+// mempool and returns the IDs of the transactions that were removed.
+func (cli *AdminJSONRPCClient) EvictSponsor(ctx context.Context, sponsor codec.Address) ([]ids.ID, error) {
+	resp := new(EvictReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"evictSponsor",
+		&EvictSponsorArgs{Sponsor: sponsor},
+		resp,
+		requester.WithHeader("Authorization", "Bearer "+cli.token),
+	)
+	return resp.TxIDs, err
+}
+
+// ReplayBlock re-executes the accepted block at [height] and returns how the
+// outcome compares to what was recorded.
+func (cli *AdminJSONRPCClient) ReplayBlock(ctx context.Context, height uint64) (*chain.ReplayResult, error) {
+	resp := new(ReplayBlockReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"replayBlock",
+		&ReplayBlockArgs{Height: height},
+		resp,
+		requester.WithHeader("Authorization", "Bearer "+cli.token),
+	)
+	return resp.Result, err
+}
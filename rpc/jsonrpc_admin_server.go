@@ -0,0 +1,124 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// AdminJSONRPCServer allows an operator to evict transactions from the
+// mempool and to replay accepted blocks. It is only served if an admin token is configured and every request
+// must provide the token in the "Authorization" header ("Bearer <token>").
+type AdminJSONRPCServer struct {
+	vm    VM
+	token []byte
+}
+
+func NewAdminJSONRPCServer(vm VM, token string) *AdminJSONRPCServer {
+	return &AdminJSONRPCServer{vm, []byte(token)}
+}
+
+func (a *AdminJSONRPCServer) authorize(req *http.Request) error {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), a.token) != 1 {
+		a.vm.Logger().Warn("unauthorized admin request", zap.String("remoteAddr", req.RemoteAddr))
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+type EvictTxsArgs struct {
+	TxIDs []ids.ID `json:"txIds"`
+}
+
+type EvictReply struct {
+	TxIDs []ids.ID `json:"txIds"` // transactions that were removed from the mempool
+}
+
+// EvictTxs removes [args.TxIDs] from the mempool. Transactions that are not
+// in the mempool are ignored.
+func (a *AdminJSONRPCServer) EvictTxs(
+	req *http.Request,
+	args *EvictTxsArgs,
+	reply *EvictReply,
+) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminJSONRPCServer.EvictTxs")
+	defer span.End()
+
+	if err := a.authorize(req); err != nil {
+		return err
+	}
+	reply.TxIDs = txIDs(a.vm.EvictTxs(ctx, args.TxIDs))
+	return nil
+}
+
+type EvictSponsorArgs struct {
+	Sponsor codec.Address `json:"sponsor"`
+}
+
+// EvictSponsor removes all transactions paid for by [args.Sponsor] from the
+// mempool.
+func (a *AdminJSONRPCServer) EvictSponsor(
+	req *http.Request,
+	args *EvictSponsorArgs,
+	reply *EvictReply,
+) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminJSONRPCServer.EvictSponsor")
+	defer span.End()
+
+	if err := a.authorize(req); err != nil {
+		return err
+	}
+	reply.TxIDs = txIDs(a.vm.EvictSponsor(ctx, args.Sponsor))
+	return nil
+}
+
+type ReplayBlockArgs struct {
+	Height uint64 `json:"height"`
+}
+
+type ReplayBlockReply struct {
+	Result *chain.ReplayResult `json:"result"`
+}
+
+// ReplayBlock re-executes the accepted block at [args.Height] and compares the
+// outcome with what was recorded when it was accepted. Only recent blocks (within
+// the state history) can be replayed.
+//
+// Replay re-executes the entire block (and reads historical state for every
+// key it touches), so it is only served to operators.
+func (a *AdminJSONRPCServer) ReplayBlock(
+	req *http.Request,
+	args *ReplayBlockArgs,
+	reply *ReplayBlockReply,
+) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminJSONRPCServer.ReplayBlock")
+	defer span.End()
+
+	if err := a.authorize(req); err != nil {
+		return err
+	}
+	result, err := a.vm.Replay(ctx, args.Height)
+	if err != nil {
+		return err
+	}
+	reply.Result = result
+	return nil
+}
+
+func txIDs(txs []*chain.Transaction) []ids.ID {
+	txIDs := make([]ids.ID, len(txs))
+	for i, tx := range txs {
+		txIDs[i] = tx.ID()
+	}
+	return txIDs
+}
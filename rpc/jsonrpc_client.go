@@ -19,6 +19,7 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/requester"
 	"github.com/ava-labs/hypersdk/utils"
 )
@@ -110,6 +111,55 @@ func (cli *JSONRPCClient) UnitPrices(ctx context.Context, useCache bool) (chain.
 	return resp.UnitPrices, nil
 }
 
+func (cli *JSONRPCClient) Nonce(ctx context.Context, addr codec.Address) (uint64, error) {
+	resp := new(NonceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nonce",
+		&NonceArgs{Address: addr},
+		resp,
+	)
+	return resp.Nonce, err
+}
+
+// Receipt returns the [chain.Receipt] of the accepted transaction [txID].
+func (cli *JSONRPCClient) Receipt(ctx context.Context, txID ids.ID) (*chain.Receipt, error) {
+	resp := new(ReceiptReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"receipt",
+		&ReceiptArgs{TxID: txID},
+		resp,
+	)
+	return resp.Receipt, err
+}
+
+// PendingTxs returns a page of the transactions in the mempool that match
+// [args] and the total number of transactions that match.
+func (cli *JSONRPCClient) PendingTxs(ctx context.Context, args *PendingTxsArgs) ([]*PendingTx, int, error) {
+	resp := new(PendingTxsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"pendingTxs",
+		args,
+		resp,
+	)
+	return resp.Txs, resp.Total, err
+}
+
+// PendingSponsors returns the number of transactions in the mempool paid for
+// by each sponsor.
+func (cli *JSONRPCClient) PendingSponsors(ctx context.Context) ([]*PendingSponsor, error) {
+	resp := new(PendingSponsorsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"pendingSponsors",
+		nil,
+		resp,
+	)
+	return resp.Sponsors, err
+}
+
 func (cli *JSONRPCClient) SubmitTx(ctx context.Context, d []byte) (ids.ID, error) {
 	resp := new(SubmitTxReply)
 	err := cli.requester.SendRequest(
@@ -121,6 +171,46 @@ func (cli *JSONRPCClient) SubmitTx(ctx context.Context, d []byte) (ids.ID, error
 	return resp.TxID, err
 }
 
+// SubmitBundle submits the transactions [txs] (in order) as a single bundle,
+// which is either included contiguously in a block or not at all.
+func (cli *JSONRPCClient) SubmitBundle(ctx context.Context, txs [][]byte) (ids.ID, error) {
+	resp := new(SubmitBundleReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"submitBundle",
+		&SubmitBundleArgs{Txs: txs},
+		resp,
+	)
+	return resp.BundleID, err
+}
+
+// SimulateTx executes the transaction [d] on top of the last accepted state
+// without submitting it.
+func (cli *JSONRPCClient) SimulateTx(ctx context.Context, d []byte) (*SimulateTxReply, error) {
+	resp := new(SimulateTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"simulateTx",
+		&SimulateTxArgs{Tx: d},
+		resp,
+	)
+	return resp, err
+}
+
+// DiscoverAccessList simulates the transaction [d] on top of the last accepted
+// state and returns the keys it accessed that it did not specify. These keys
+// should be included in the [chain.Transaction.AccessList] when issuing it.
+func (cli *JSONRPCClient) DiscoverAccessList(ctx context.Context, d []byte) ([][]byte, error) {
+	resp := new(SimulateTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"simulateTx",
+		&SimulateTxArgs{Tx: d, Discover: true},
+		resp,
+	)
+	return resp.AccessList, err
+}
+
 func (cli *JSONRPCClient) GetWarpSignatures(
 	ctx context.Context,
 	txID ids.ID,
@@ -160,11 +250,51 @@ type Modifier interface {
 	Base(*chain.Base)
 }
 
+type nonceModifier struct {
+	nonce uint64
+}
+
+func (n *nonceModifier) Base(b *chain.Base) {
+	b.Nonce = n.nonce
+}
+
+// WithNonce sets the [chain.Base.Nonce] of a generated transaction.
+func WithNonce(nonce uint64) Modifier {
+	return &nonceModifier{nonce}
+}
+
+type priorityFeeModifier struct {
+	fee uint64
+}
+
+func (p *priorityFeeModifier) Base(b *chain.Base) {
+	b.PriorityFee = p.fee
+}
+
+// WithPriorityFee sets the [chain.Base.PriorityFee] of a generated transaction.
+func WithPriorityFee(fee uint64) Modifier {
+	return &priorityFeeModifier{fee}
+}
+
+type replacesModifier struct {
+	txID ids.ID
+}
+
+func (r *replacesModifier) Base(b *chain.Base) {
+	b.Replaces = r.txID
+}
+
+// WithReplaces sets the [chain.Base.Replaces] of a generated transaction (the
+// transaction must pay a higher fee than [txID]).
+func WithReplaces(txID ids.ID) Modifier {
+	return &replacesModifier{txID}
+}
+
 func (cli *JSONRPCClient) GenerateTransaction(
 	ctx context.Context,
 	parser chain.Parser,
 	wm *warp.Message,
-	action chain.Action,
+	actions []chain.Action,
 	authFactory chain.AuthFactory,
 	modifiers ...Modifier,
 ) (func(context.Context) error, *chain.Transaction, uint64, error) {
@@ -174,7 +304,12 @@ func (cli *JSONRPCClient) GenerateTransaction(
 		return nil, nil, 0, err
 	}
 
-	maxUnits, err := chain.EstimateMaxUnits(parser.Rules(time.Now().UnixMilli()), action, authFactory, wm)
+	rules := parser.Rules(time.Now().UnixMilli())
+	maxUnits, err := chain.EstimateMaxUnits(rules, actions, authFactory, wm)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxUnits, err = addBaseUnits(rules, maxUnits, modifiers)
 	if err != nil {
 		return nil, nil, 0, err
 	}
@@ -182,7 +317,7 @@ func (cli *JSONRPCClient) GenerateTransaction(
 	if err != nil {
 		return nil, nil, 0, err
 	}
-	f, tx, err := cli.GenerateTransactionManual(parser, wm, action, authFactory, maxFee, modifiers...)
+	f, tx, err := cli.GenerateTransactionManual(parser, wm, actions, authFactory, maxFee, modifiers...)
 	if err != nil {
 		return nil, nil, 0, err
 	}
@@ -192,10 +327,208 @@ func (cli *JSONRPCClient) GenerateTransaction(
 func (cli *JSONRPCClient) GenerateTransactionManual(
 	parser chain.Parser,
 	wm *warp.Message,
-	action chain.Action,
+	actions []chain.Action,
 	authFactory chain.AuthFactory,
 	maxFee uint64,
 	modifiers ...Modifier,
+) (func(context.Context) error, *chain.Transaction, error) {
+	actionRegistry, authRegistry := parser.Registry()
+	return cli.generateTransaction(parser, wm, maxFee, modifiers, func(base *chain.Base) (*chain.Transaction, error) {
+		tx := chain.NewTx(base, wm, actions)
+		return tx.Sign(authFactory, actionRegistry, authRegistry)
+	})
+}
+
+// GenerateTransactionWithDiscovery generates a transaction that may access keys that
+// are not returned by [chain.Action.StateKeys]. The keys accessed by [actions] are
+// discovered by simulating the transaction against the last accepted state and are
+// attached to the transaction in its [chain.Transaction.AccessList].
+func (cli *JSONRPCClient) GenerateTransactionWithDiscovery(
+	ctx context.Context,
+	parser chain.Parser,
+	wm *warp.Message,
+	actions []chain.Action,
+	authFactory chain.AuthFactory,
+	modifiers ...Modifier,
+) (func(context.Context) error, *chain.Transaction, uint64, error) {
+	// Discover keys accessed by [actions]
+	_, tx, _, err := cli.GenerateTransaction(ctx, parser, wm, actions, authFactory, modifiers...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	accessList, err := cli.DiscoverAccessList(ctx, tx.Bytes())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Get latest fee info
+	unitPrices, err := cli.UnitPrices(ctx, true)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	rules := parser.Rules(time.Now().UnixMilli())
+	maxUnits, err := chain.EstimateMaxUnits(rules, actions, authFactory, wm)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	accessListUnits, err := chain.EstimateAccessListUnits(rules, accessList)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxUnits, err = chain.Add(maxUnits, accessListUnits)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxUnits, err = addBaseUnits(rules, maxUnits, modifiers)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxFee, err := chain.MulSum(unitPrices, maxUnits)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	actionRegistry, authRegistry := parser.Registry()
+	f, tx, err := cli.generateTransaction(parser, wm, maxFee, modifiers, func(base *chain.Base) (*chain.Transaction, error) {
+		tx := chain.NewTx(base, wm, actions)
+		tx.AccessList = accessList
+		return tx.Sign(authFactory, actionRegistry, authRegistry)
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return f, tx, maxFee, nil
+}
+
+// GenerateCancelTransaction generates a transaction (without any actions) that
+// cancels [pending] (which must not have been included yet).
+//
+// The generated transaction uses the same [chain.Base.Nonce] as [pending] (so
+// only one of them can be executed) and pays the minimum fee required to replace
+// [pending] (unless overridden by [modifiers]).
+func (cli *JSONRPCClient) GenerateCancelTransaction(
+	ctx context.Context,
+	parser chain.Parser,
+	pending *chain.Transaction,
+	authFactory chain.AuthFactory,
+	modifiers ...Modifier,
+) (func(context.Context) error, *chain.Transaction, uint64, error) {
+	// Get latest fee info
+	unitPrices, err := cli.UnitPrices(ctx, true)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	modifiers = append([]Modifier{
+		WithNonce(pending.Nonce()),
+		WithPriorityFee(pending.PriorityFee()),
+		WithReplaces(pending.ID()),
+	}, modifiers...)
+	rules := parser.Rules(time.Now().UnixMilli())
+	maxUnits, err := chain.EstimateMaxUnits(rules, nil, authFactory, nil)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxUnits, err = addBaseUnits(rules, maxUnits, modifiers)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxFee, err := chain.MulSum(unitPrices, maxUnits)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if maxFee <= pending.MaxFee() {
+		maxFee = pending.MaxFee() + 1
+	}
+	actionRegistry, authRegistry := parser.Registry()
+	f, tx, err := cli.generateTransaction(parser, nil, maxFee, modifiers, func(base *chain.Base) (*chain.Transaction, error) {
+		return chain.NewCancelTx(base, pending.ID()).Sign(authFactory, actionRegistry, authRegistry)
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return f, tx, maxFee, nil
+}
+
+// GenerateSponsoredTransaction generates a transaction whose fees are paid by
+// [sponsor] (which must be the sponsor of [sponsorFactory]).
+func (cli *JSONRPCClient) GenerateSponsoredTransaction(
+	ctx context.Context,
+	parser chain.Parser,
+	wm *warp.Message,
+	actions []chain.Action,
+	authFactory chain.AuthFactory,
+	sponsor codec.Address,
+	sponsorFactory chain.AuthFactory,
+	modifiers ...Modifier,
+) (func(context.Context) error, *chain.Transaction, uint64, error) {
+	// Get latest fee info
+	unitPrices, err := cli.UnitPrices(ctx, true)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	rules := parser.Rules(time.Now().UnixMilli())
+	maxUnits, err := chain.EstimateSponsoredMaxUnits(rules, actions, authFactory, sponsorFactory, wm)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxUnits, err = addBaseUnits(rules, maxUnits, modifiers)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxFee, err := chain.MulSum(unitPrices, maxUnits)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	f, tx, err := cli.GenerateSponsoredTransactionManual(parser, wm, actions, authFactory, sponsor, sponsorFactory, maxFee, modifiers...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return f, tx, maxFee, nil
+}
+
+func (cli *JSONRPCClient) GenerateSponsoredTransactionManual(
+	parser chain.Parser,
+	wm *warp.Message,
+	actions []chain.Action,
+	authFactory chain.AuthFactory,
+	sponsor codec.Address,
+	sponsorFactory chain.AuthFactory,
+	maxFee uint64,
+	modifiers ...Modifier,
+) (func(context.Context) error, *chain.Transaction, error) {
+	actionRegistry, authRegistry := parser.Registry()
+	return cli.generateTransaction(parser, wm, maxFee, modifiers, func(base *chain.Base) (*chain.Transaction, error) {
+		tx := chain.NewSponsoredTx(base, wm, actions, sponsor)
+		return tx.SignSponsored(authFactory, sponsorFactory, actionRegistry, authRegistry)
+	})
+}
+
+// addBaseUnits adds the cost of the optional [chain.Base] fields set by [modifiers]
+// (a [chain.Base.Nonce] or [chain.Base.Replaces]) to [maxUnits].
+func addBaseUnits(r chain.Rules, maxUnits chain.Dimensions, modifiers []Modifier) (chain.Dimensions, error) {
+	base := &chain.Base{}
+	for _, m := range modifiers {
+		m.Base(base)
+	}
+	maxUnits[chain.Bandwidth] += uint64(base.Size() - chain.BaseSize)
+	if base.Nonce == 0 {
+		return maxUnits, nil
+	}
+	nonceUnits, err := chain.EstimateNonceUnits(r)
+	if err != nil {
+		return chain.Dimensions{}, err
+	}
+	return chain.Add(maxUnits, nonceUnits)
+}
+
+func (cli *JSONRPCClient) generateTransaction(
+	parser chain.Parser,
+	wm *warp.Message,
+	maxFee uint64,
+	modifiers []Modifier,
+	sign func(*chain.Base) (*chain.Transaction, error),
 ) (func(context.Context) error, *chain.Transaction, error) {
 	// Construct transaction
 	now := time.Now().UnixMilli()
@@ -219,9 +552,7 @@ func (cli *JSONRPCClient) GenerateTransactionManual(
 	}
 
 	// Build transaction
-	actionRegistry, authRegistry := parser.Registry()
-	tx := chain.NewTx(base, wm, action)
-	tx, err := tx.Sign(authFactory, actionRegistry, authRegistry)
+	tx, err := sign(base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w: failed to sign transaction", err)
 	}
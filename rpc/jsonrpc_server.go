@@ -4,6 +4,7 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -15,7 +16,10 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/gorilla/rpc/v2/json2"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 type JSONRPCServer struct {
@@ -79,7 +83,246 @@ func (j *JSONRPCServer) SubmitTx(
 	}
 	txID := tx.ID()
 	reply.TxID = txID
-	return j.vm.Submit(ctx, false, []*chain.Transaction{tx})[0]
+	err = j.vm.Submit(ctx, false, []*chain.Transaction{tx})[0]
+	if errors.Is(err, chain.ErrSponsorInsolvent) {
+		return &json2.Error{Code: ErrCodeSponsorInsolvent, Message: err.Error()}
+	}
+	return err
+}
+
+type SubmitBundleArgs struct {
+	Txs [][]byte `json:"txs"`
+}
+
+type SubmitBundleReply struct {
+	BundleID ids.ID   `json:"bundleId"`
+	TxIDs    []ids.ID `json:"txIds"`
+}
+
+func (j *JSONRPCServer) SubmitBundle(
+	req *http.Request,
+	args *SubmitBundleArgs,
+	reply *SubmitBundleReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.SubmitBundle")
+	defer span.End()
+
+	if len(args.Txs) > chain.MaxBundleTxs {
+		return chain.ErrBundleTooLarge
+	}
+	actionRegistry, authRegistry := j.vm.Registry()
+	txs := make([]*chain.Transaction, len(args.Txs))
+	for i, raw := range args.Txs {
+		rtx := codec.NewReader(raw, consts.NetworkSizeLimit)
+		tx, err := chain.UnmarshalTx(rtx, actionRegistry, authRegistry)
+		if err != nil {
+			return fmt.Errorf("%w: unable to unmarshal on public service", err)
+		}
+		if !rtx.Empty() {
+			return errors.New("tx has extra bytes")
+		}
+		if err := tx.AuthAsyncVerify()(); err != nil {
+			return err
+		}
+		txs[i] = tx
+	}
+	bundle, err := chain.NewBundle(txs)
+	if err != nil {
+		return err
+	}
+	reply.BundleID = bundle.ID()
+	reply.TxIDs = make([]ids.ID, len(txs))
+	for i, tx := range txs {
+		reply.TxIDs[i] = tx.ID()
+	}
+	err = j.vm.SubmitBundles(ctx, false, []*chain.Bundle{bundle})[0]
+	if errors.Is(err, chain.ErrSponsorInsolvent) {
+		return &json2.Error{Code: ErrCodeSponsorInsolvent, Message: err.Error()}
+	}
+	return err
+}
+
+type SimulateTxArgs struct {
+	Tx []byte `json:"tx"`
+
+	// Discover allows the transaction to access keys it did not specify. These
+	// keys are returned in [SimulateTxReply.AccessList].
+	Discover bool `json:"discover"`
+}
+
+type SimulateTxReply struct {
+	Success  bool             `json:"success"`
+	Outputs  [][]byte         `json:"outputs"`
+	Consumed chain.Dimensions `json:"consumed"`
+	Fee      uint64           `json:"fee"`
+
+	// ReadKeys and WrittenKeys are the state keys actually accessed during
+	// execution (sorted). This may be a subset of the keys specified by
+	// the transaction.
+	ReadKeys    [][]byte `json:"readKeys"`
+	WrittenKeys [][]byte `json:"writtenKeys"`
+
+	// AccessList contains the keys accessed by the transaction that it did not
+	// specify (only populated if [SimulateTxArgs.Discover] is set).
+	AccessList [][]byte `json:"accessList"`
+}
+
+// SimulateTx executes a transaction on top of the last accepted state without
+// submitting it. Signatures are not verified.
+func (j *JSONRPCServer) SimulateTx(
+	req *http.Request,
+	args *SimulateTxArgs,
+	reply *SimulateTxReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.SimulateTx")
+	defer span.End()
+
+	actionRegistry, authRegistry := j.vm.Registry()
+	rtx := codec.NewReader(args.Tx, consts.NetworkSizeLimit) // will likely be much smaller than this
+	tx, err := chain.UnmarshalTx(rtx, actionRegistry, authRegistry)
+	if err != nil {
+		return fmt.Errorf("%w: unable to unmarshal on public service", err)
+	}
+	if !rtx.Empty() {
+		return errors.New("tx has extra bytes")
+	}
+	result, err := j.vm.Simulate(ctx, tx, args.Discover)
+	if err != nil {
+		return err
+	}
+	reply.Success = result.Success
+	reply.Outputs = result.Outputs
+	reply.Consumed = result.Consumed
+	reply.Fee = result.Fee
+	reply.ReadKeys = sortedKeys(result.Reads)
+	written := make(map[string]uint16, len(result.Allocates)+len(result.Writes))
+	maps.Copy(written, result.Allocates)
+	maps.Copy(written, result.Writes)
+	reply.WrittenKeys = sortedKeys(written)
+	reply.AccessList = result.AccessList
+	return nil
+}
+
+func sortedKeys(m map[string]uint16) [][]byte {
+	ks := maps.Keys(m)
+	slices.Sort(ks)
+	keys := make([][]byte, len(ks))
+	for i, k := range ks {
+		keys[i] = []byte(k)
+	}
+	return keys
+}
+
+type PendingTxsArgs struct {
+	// Sponsor only includes transactions paid for by [Sponsor] (if not empty).
+	Sponsor codec.Address `json:"sponsor"`
+	// ActionID only includes transactions with an action of type [ActionID]
+	// (if set).
+	ActionID *uint8 `json:"actionId"`
+
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"` // defaults to (and is capped at) [MaxPendingTxsLimit]
+}
+
+type PendingTx struct {
+	TxID ids.ID `json:"txId"`
+	// Position is the index of the transaction in the order the mempool
+	// considers transactions for inclusion (ignoring any filters).
+	Position    int           `json:"position"`
+	Sponsor     codec.Address `json:"sponsor"`
+	Expiry      int64         `json:"expiry"`
+	Nonce       uint64        `json:"nonce"`
+	MaxFee      uint64        `json:"maxFee"`
+	PriorityFee uint64        `json:"priorityFee"`
+	Tx          []byte        `json:"tx"`
+}
+
+type PendingTxsReply struct {
+	Txs   []*PendingTx `json:"txs"`
+	Total int          `json:"total"` // number of transactions that match the filters
+}
+
+// PendingTxs returns a page of the transactions in the mempool that match
+// [args]. Transactions streamed to the block builder are not included while
+// a block is being built.
+func (j *JSONRPCServer) PendingTxs(
+	req *http.Request,
+	args *PendingTxsArgs,
+	reply *PendingTxsReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.PendingTxs")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > MaxPendingTxsLimit {
+		limit = MaxPendingTxsLimit
+	}
+	reply.Txs = []*PendingTx{}
+	for i, tx := range j.vm.PendingTxs(ctx) {
+		if args.Sponsor != codec.EmptyAddress && tx.Sponsor() != args.Sponsor {
+			continue
+		}
+		if args.ActionID != nil && !hasAction(tx, *args.ActionID) {
+			continue
+		}
+		reply.Total++
+		if reply.Total <= args.Offset || len(reply.Txs) == limit {
+			continue
+		}
+		reply.Txs = append(reply.Txs, &PendingTx{
+			TxID:        tx.ID(),
+			Position:    i,
+			Sponsor:     tx.Sponsor(),
+			Expiry:      tx.Expiry(),
+			Nonce:       tx.Nonce(),
+			MaxFee:      tx.MaxFee(),
+			PriorityFee: tx.PriorityFee(),
+			Tx:          tx.Bytes(),
+		})
+	}
+	return nil
+}
+
+func hasAction(tx *chain.Transaction, actionID uint8) bool {
+	for _, action := range tx.Actions {
+		if action.GetTypeID() == actionID {
+			return true
+		}
+	}
+	return false
+}
+
+type PendingSponsor struct {
+	Sponsor codec.Address `json:"sponsor"`
+	Txs     int           `json:"txs"`
+}
+
+type PendingSponsorsReply struct {
+	Sponsors []*PendingSponsor `json:"sponsors"` // sorted by decreasing [Txs]
+}
+
+// PendingSponsors returns the number of transactions in the mempool paid for
+// by each sponsor.
+func (j *JSONRPCServer) PendingSponsors(
+	req *http.Request,
+	_ *struct{},
+	reply *PendingSponsorsReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.PendingSponsors")
+	defer span.End()
+
+	owned := j.vm.PendingSponsors(ctx)
+	reply.Sponsors = make([]*PendingSponsor, 0, len(owned))
+	for sponsor, txs := range owned {
+		reply.Sponsors = append(reply.Sponsors, &PendingSponsor{Sponsor: sponsor, Txs: txs})
+	}
+	slices.SortFunc(reply.Sponsors, func(a, b *PendingSponsor) bool {
+		if a.Txs != b.Txs {
+			return a.Txs > b.Txs
+		}
+		return bytes.Compare(a.Sponsor[:], b.Sponsor[:]) < 0
+	})
+	return nil
 }
 
 type LastAcceptedReply struct {
@@ -116,6 +359,61 @@ func (j *JSONRPCServer) UnitPrices(
 	return nil
 }
 
+type NonceArgs struct {
+	Address codec.Address `json:"address"`
+}
+
+type NonceReply struct {
+	Nonce uint64 `json:"nonce"`
+}
+
+// Nonce returns the nonce of the last transaction executed by [args.Address]
+// that specified a nonce (the next transaction should use [Nonce]+1).
+func (j *JSONRPCServer) Nonce(
+	req *http.Request,
+	args *NonceArgs,
+	reply *NonceReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.Nonce")
+	defer span.End()
+
+	nonce, err := j.vm.GetNonce(ctx, args.Address)
+	if err != nil {
+		return err
+	}
+	reply.Nonce = nonce
+	return nil
+}
+
+type ReceiptArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type ReceiptReply struct {
+	Receipt *chain.Receipt `json:"receipt"`
+}
+
+// Receipt returns the [chain.Receipt] of the accepted transaction [args.TxID]. Receipts
+// are only stored if enabled in the config and are deleted after the configured window.
+func (j *JSONRPCServer) Receipt(
+	req *http.Request,
+	args *ReceiptArgs,
+	reply *ReceiptReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.Receipt")
+	defer span.End()
+
+	receipt, err := j.vm.GetReceipt(args.TxID)
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		return ErrReceiptMissing
+	}
+	reply.Receipt = receipt
+	return nil
+}
+
 type GetWarpSignaturesArgs struct {
 	TxID ids.ID `json:"txID"`
 }
@@ -93,7 +93,7 @@ func UnpackTxMessage(msg []byte) (ids.ID, error, *chain.Result, error) {
 	p.UnpackID(true, &txID)
 	if p.UnpackBool() {
 		err := p.UnpackString(true)
-		return ids.Empty, errors.New(err), nil, p.Err()
+		return txID, errors.New(err), nil, p.Err()
 	}
 	result, err := chain.UnmarshalResult(p)
 	if err != nil {
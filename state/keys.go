@@ -0,0 +1,35 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+// Permissions specify how a key may be accessed during execution.
+type Permissions byte
+
+const (
+	// Read allows a key to be read.
+	Read Permissions = 1 << iota
+	// Write allows a key to be created, modified, or removed. Most keys
+	// that are written must also be read (see [All]).
+	Write
+
+	None = Permissions(0)
+	All  = Read | Write
+)
+
+// Has returns true if [p] includes all of [require].
+func (p Permissions) Has(require Permissions) bool {
+	return p&require == require
+}
+
+// Keys is a full enumeration of the keys that could be accessed during
+// execution and how they could be accessed.
+//
+// Keys that are only read can be accessed concurrently by many transactions,
+// whereas keys that are written must be accessed exclusively.
+type Keys map[string]Permissions
+
+// Add adds [permission] to [key] (retaining any permissions it already has).
+func (k Keys) Add(key string, permission Permissions) {
+	k[key] |= permission
+}
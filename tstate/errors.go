@@ -8,6 +8,7 @@ import "errors"
 var (
 	ErrNewKeysDisabled    = errors.New("new keys disabled")
 	ErrKeyNotSpecified    = errors.New("key not specified")
+	ErrMissingPermission  = errors.New("missing key permission")
 	ErrInvalidKeyValue    = errors.New("invalid key or value")
 	ErrAllocationDisabled = errors.New("allocation disabled")
 )
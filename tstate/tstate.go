@@ -20,6 +20,11 @@ type TState struct {
 	l           sync.RWMutex
 	ops         int
 	changedKeys map[string]maybe.Maybe[[]byte]
+
+	// Each time a [TStateView] is committed, the version of
+	// any key it modified is set to the number of commits.
+	commits  int
+	versions map[string]int
 }
 
 // New returns a new instance of TState. Initializes the storage and changedKeys
@@ -27,20 +32,23 @@ type TState struct {
 func New(changedSize int) *TState {
 	return &TState{
 		changedKeys: make(map[string]maybe.Maybe[[]byte], changedSize),
+		versions:    make(map[string]int, changedSize),
 	}
 }
 
-func (ts *TState) getChangedValue(_ context.Context, key string) ([]byte, bool, bool) {
+// getChangedValue returns the value of [key] (if changed) and its version (0 if
+// it has never been changed).
+func (ts *TState) getChangedValue(_ context.Context, key string) ([]byte, int, bool, bool) {
 	ts.l.RLock()
 	defer ts.l.RUnlock()
 
 	if v, ok := ts.changedKeys[key]; ok {
 		if v.IsNothing() {
-			return nil, true, false
+			return nil, ts.versions[key], true, false
 		}
-		return v.Value(), true, true
+		return v.Value(), ts.versions[key], true, true
 	}
-	return nil, false, false
+	return nil, 0, false, false
 }
 
 func (ts *TState) PendingChanges() int {
@@ -50,6 +58,19 @@ func (ts *TState) PendingChanges() int {
 	return len(ts.changedKeys)
 }
 
+// ChangedKeys returns a copy of all changes in [TState]. A key that was
+// removed is mapped to [maybe.Nothing].
+func (ts *TState) ChangedKeys() map[string]maybe.Maybe[[]byte] {
+	ts.l.RLock()
+	defer ts.l.RUnlock()
+
+	changes := make(map[string]maybe.Maybe[[]byte], len(ts.changedKeys))
+	for k, v := range ts.changedKeys {
+		changes[k] = v
+	}
+	return changes
+}
+
 // OpIndex returns the number of operations done on ts.
 func (ts *TState) OpIndex() int {
 	ts.l.RLock()
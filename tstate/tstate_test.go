@@ -11,10 +11,10 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/utils/maybe"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/trace"
 
 	"github.com/stretchr/testify/require"
@@ -66,7 +66,7 @@ func TestScope(t *testing.T) {
 	ts := New(10)
 
 	// No Scope
-	tsv := ts.NewView(set.Set[string]{}, map[string][]byte{})
+	tsv := ts.NewView(state.Keys{}, map[string][]byte{})
 	val, err := tsv.GetValue(ctx, testKey)
 	require.ErrorIs(ErrKeyNotSpecified, err)
 	require.Nil(val)
@@ -74,13 +74,34 @@ func TestScope(t *testing.T) {
 	require.ErrorIs(ErrKeyNotSpecified, tsv.Remove(ctx, testKey))
 }
 
+func TestPermissions(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+
+	// Read-only keys can't be modified
+	tsv := ts.NewView(state.Keys{key1str: state.Read, key2str: state.Write}, map[string][]byte{key1str: testVal, key2str: testVal})
+	val, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal(testVal, val)
+	require.ErrorIs(tsv.Insert(ctx, key1, []byte("value2")), ErrMissingPermission)
+	require.ErrorIs(tsv.Remove(ctx, key1), ErrMissingPermission)
+
+	// Write-only keys can't be read
+	_, err = tsv.GetValue(ctx, key2)
+	require.ErrorIs(err, ErrMissingPermission)
+	require.NoError(tsv.Insert(ctx, key2, []byte("value2")))
+	require.NoError(tsv.Remove(ctx, key2))
+	require.Equal(2, tsv.OpIndex())
+}
+
 func TestGetValue(t *testing.T) {
 	require := require.New(t)
 	ctx := context.TODO()
 	ts := New(10)
 
 	// Set Scope
-	tsv := ts.NewView(set.Of(string(testKey)), map[string][]byte{string(testKey): testVal})
+	tsv := ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{string(testKey): testVal})
 	val, err := tsv.GetValue(ctx, testKey)
 	require.NoError(err, "unable to get value")
 	require.Equal(testVal, val, "value was not saved correctly")
@@ -92,12 +113,12 @@ func TestDeleteCommitGet(t *testing.T) {
 	ts := New(10)
 
 	// Delete value
-	tsv := ts.NewView(set.Of(string(testKey)), map[string][]byte{string(testKey): testVal})
+	tsv := ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{string(testKey): testVal})
 	require.NoError(tsv.Remove(ctx, testKey))
 	tsv.Commit()
 
 	// Check deleted
-	tsv = ts.NewView(set.Of(string(testKey)), map[string][]byte{string(testKey): testVal})
+	tsv = ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{string(testKey): testVal})
 	val, err := tsv.GetValue(ctx, testKey)
 	require.ErrorIs(err, database.ErrNotFound)
 	require.Nil(val)
@@ -109,18 +130,148 @@ func TestGetValueNoStorage(t *testing.T) {
 	ts := New(10)
 
 	// SetScope but dont add to storage
-	tsv := ts.NewView(set.Of(string(testKey)), map[string][]byte{})
+	tsv := ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{})
 	_, err := tsv.GetValue(ctx, testKey)
 	require.ErrorIs(database.ErrNotFound, err, "data should not exist")
 }
 
+func TestReadTracking(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+
+	tsv := ts.NewView(state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}, map[string][]byte{key1str: testVal})
+
+	// Reads are not tracked by default
+	_, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Nil(tsv.Reads())
+
+	tsv.EnableReadTracking()
+	_, err = tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	_, err = tsv.GetValue(ctx, key2)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Writes are not reads
+	require.NoError(tsv.Insert(ctx, key3, testVal))
+	require.Equal(map[string]uint16{key1str: 1, key2str: 0}, tsv.Reads())
+
+	// Largest read is tracked
+	require.NoError(tsv.Insert(ctx, key2, testVal))
+	_, err = tsv.GetValue(ctx, key2)
+	require.NoError(err)
+	require.NoError(tsv.Remove(ctx, key2))
+	_, err = tsv.GetValue(ctx, key2)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Equal(map[string]uint16{key1str: 1, key2str: 1}, tsv.Reads())
+}
+
+func TestDiscoveryView(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+	db := NewTestDB()
+	require.NoError(db.Insert(ctx, key1, testVal))
+
+	tsv := ts.NewDiscoveryView(db)
+	val, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal(testVal, val)
+	_, err = tsv.GetValue(ctx, key2)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.NoError(tsv.Insert(ctx, key3, testVal))
+	require.Equal(state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}, tsv.Scope())
+	allocates, writes := tsv.KeyOperations()
+	require.Equal(map[string]uint16{key3str: 3}, allocates)
+	require.Equal(map[string]uint16{key3str: 1}, writes)
+
+	// Keys are only fetched once
+	require.NoError(db.Remove(ctx, key1))
+	val, err = tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal(testVal, val)
+}
+
+func TestChanges(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+
+	// Changes from the parent view are the initial values
+	tsv := ts.NewView(state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}, map[string][]byte{key1str: testVal, key2str: testVal})
+	require.NoError(tsv.Insert(ctx, key1, []byte("value2")))
+	tsv.Commit()
+
+	tsv = ts.NewView(state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}, map[string][]byte{key1str: testVal, key2str: testVal})
+	require.NoError(tsv.Insert(ctx, key1, []byte("value3")))
+	require.NoError(tsv.Remove(ctx, key2))
+	require.NoError(tsv.Insert(ctx, key3, testVal))
+	require.Equal(map[string]*Change{
+		key1str: {Before: []byte("value2"), After: []byte("value3")},
+		key2str: {Before: testVal, After: nil},
+		key3str: {Before: nil, After: testVal},
+	}, tsv.Changes(ctx))
+
+	// Reverted keys are not changes
+	require.NoError(tsv.Insert(ctx, key1, []byte("value2")))
+	require.NoError(tsv.Remove(ctx, key3))
+	require.Equal(map[string]*Change{
+		key2str: {Before: testVal, After: nil},
+	}, tsv.Changes(ctx))
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+	scope := state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}
+	storage := map[string][]byte{key1str: testVal, key2str: testVal}
+
+	// Views without validation are always valid
+	tsv := ts.NewView(scope, storage)
+	_, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+
+	// Reads and writes are validated
+	tsv1 := ts.NewView(scope, storage)
+	tsv1.EnableValidation()
+	_, err = tsv1.GetValue(ctx, key1)
+	require.NoError(err)
+	tsv2 := ts.NewView(scope, storage)
+	tsv2.EnableValidation()
+	require.NoError(tsv2.Insert(ctx, key2, []byte("value2")))
+	tsv3 := ts.NewView(scope, storage)
+	tsv3.EnableValidation()
+	require.NoError(tsv3.Insert(ctx, key1, []byte("value3")))
+	require.True(tsv1.Validate())
+	require.True(tsv2.Validate())
+	require.True(tsv3.Validate())
+
+	// Committing [tsv3] invalidates views that accessed [key1]
+	tsv3.Commit()
+	require.True(tsv.Validate())
+	require.False(tsv1.Validate())
+	require.True(tsv2.Validate())
+
+	// Views created after the commit observe the latest version
+	tsv4 := ts.NewView(scope, storage)
+	tsv4.EnableValidation()
+	v, err := tsv4.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal([]byte("value3"), v)
+	require.True(tsv4.Validate())
+	tsv2.Commit()
+	require.True(tsv4.Validate())
+}
+
 func TestInsertNew(t *testing.T) {
 	require := require.New(t)
 	ctx := context.TODO()
 	ts := New(10)
 
 	// SetScope
-	tsv := ts.NewView(set.Of(string(testKey)), map[string][]byte{})
+	tsv := ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{})
 
 	// Test Disable Allocate
 	tsv.DisableAllocation()
@@ -146,7 +297,7 @@ func TestInsertInvalid(t *testing.T) {
 
 	// SetScope
 	key := binary.BigEndian.AppendUint16([]byte("hello"), 0)
-	tsv := ts.NewView(set.Of(string(key)), map[string][]byte{})
+	tsv := ts.NewView(state.Keys{string(key): state.All}, map[string][]byte{})
 
 	// Insert key
 	require.ErrorIs(tsv.Insert(ctx, key, []byte("cool")), ErrInvalidKeyValue)
@@ -162,7 +313,7 @@ func TestInsertUpdate(t *testing.T) {
 	ts := New(10)
 
 	// SetScope and add
-	tsv := ts.NewView(set.Of(string(testKey)), map[string][]byte{string(testKey): testVal})
+	tsv := ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{string(testKey): testVal})
 	require.Equal(0, ts.OpIndex())
 
 	// Insert key
@@ -178,7 +329,7 @@ func TestInsertUpdate(t *testing.T) {
 
 	// Check value after commit
 	tsv.Commit()
-	tsv = ts.NewView(set.Of(string(testKey)), map[string][]byte{string(testKey): testVal})
+	tsv = ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{string(testKey): testVal})
 	val, err = tsv.GetValue(ctx, testKey)
 	require.NoError(err)
 	require.Equal(newVal, val, "value was not committed correctly")
@@ -190,7 +341,7 @@ func TestInsertRemoveInsert(t *testing.T) {
 	ts := New(10)
 
 	// SetScope and add
-	tsv := ts.NewView(set.Of(key2str), map[string][]byte{})
+	tsv := ts.NewView(state.Keys{key2str: state.All}, map[string][]byte{})
 	require.Equal(0, ts.OpIndex())
 
 	// Insert key for first time
@@ -262,7 +413,7 @@ func TestModifyRemoveInsert(t *testing.T) {
 	ts := New(10)
 
 	// SetScope and add
-	tsv := ts.NewView(set.Of(key2str), map[string][]byte{key2str: testVal})
+	tsv := ts.NewView(state.Keys{key2str: state.All}, map[string][]byte{key2str: testVal})
 	require.Equal(0, ts.OpIndex())
 
 	// Modify existing key
@@ -316,7 +467,7 @@ func TestModifyRevert(t *testing.T) {
 	ts := New(10)
 
 	// SetScope and add
-	tsv := ts.NewView(set.Of(key2str), map[string][]byte{key2str: testVal})
+	tsv := ts.NewView(state.Keys{key2str: state.All}, map[string][]byte{key2str: testVal})
 	require.Equal(0, ts.OpIndex())
 
 	// Modify existing key
@@ -356,7 +507,7 @@ func TestModifyModify(t *testing.T) {
 	ts := New(10)
 
 	// SetScope and add
-	tsv := ts.NewView(set.Of(key2str), map[string][]byte{key2str: testVal})
+	tsv := ts.NewView(state.Keys{key2str: state.All}, map[string][]byte{key2str: testVal})
 	require.Equal(0, ts.OpIndex())
 
 	// Modify existing key
@@ -403,7 +554,7 @@ func TestRemoveInsertRollback(t *testing.T) {
 	ctx := context.TODO()
 
 	// Insert
-	tsv := ts.NewView(set.Of(string(testKey)), map[string][]byte{})
+	tsv := ts.NewView(state.Keys{string(testKey): state.All}, map[string][]byte{})
 	require.NoError(tsv.Insert(ctx, testKey, testVal))
 	v, err := tsv.GetValue(ctx, testKey)
 	require.NoError(err)
@@ -441,7 +592,7 @@ func TestRestoreInsert(t *testing.T) {
 	ts := New(10)
 	ctx := context.TODO()
 	keys := [][]byte{key1, key2, key3}
-	keySet := set.Of(key1str, key2str, key3str)
+	keySet := state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}
 	vals := [][]byte{[]byte("val1"), []byte("val2"), []byte("val3")}
 
 	// Store keys
@@ -494,7 +645,7 @@ func TestRestoreDelete(t *testing.T) {
 	ts := New(10)
 	ctx := context.TODO()
 	keys := [][]byte{key1, key2, key3}
-	keySet := set.Of(key1str, key2str, key3str)
+	keySet := state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}
 	vals := [][]byte{[]byte("val1"), []byte("val2"), []byte("val3")}
 	tsv := ts.NewView(keySet, map[string][]byte{
 		string(keys[0]): vals[0],
@@ -547,7 +698,7 @@ func TestCreateView(t *testing.T) {
 		t.Fatal(err)
 	}
 	keys := [][]byte{key1, key2, key3}
-	keySet := set.Of(key1str, key2str, key3str)
+	keySet := state.Keys{key1str: state.All, key2str: state.All, key3str: state.All}
 	vals := [][]byte{[]byte("val1"), []byte("val2"), []byte("val3")}
 
 	// Add
@@ -6,11 +6,12 @@ package tstate
 import (
 	"bytes"
 	"context"
+	"errors"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/maybe"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
 )
 
 const defaultOps = 4
@@ -39,17 +40,27 @@ type TStateView struct {
 	// operations allows for reverting state to a certain point-in-time.
 	ops []*op
 
-	// We don't differentiate between read and write scope.
-	scope        set.Set[string] // stores a list of managed keys in the TState struct
+	// Keys that can only be read (not written) are specified with [state.Read].
+	scope        state.Keys // stores a list of managed keys in the TState struct
 	scopeStorage map[string][]byte
 
+	// If [discoveryState] is set, any key can be accessed. Keys are fetched from
+	// [discoveryState] and added to [scope] the first time they are accessed.
+	discoveryState state.Immutable
+
 	// Store which keys are modified and how large their values were.
 	canAllocate bool
 	allocates   map[string]uint16
 	writes      map[string]uint16
+
+	// Store which keys are read (if enabled) and how large their values were.
+	reads map[string]uint16
+
+	// Store the version of each key read from [ts] (if enabled).
+	versions map[string]int
 }
 
-func (ts *TState) NewView(scope set.Set[string], storage map[string][]byte) *TStateView {
+func (ts *TState) NewView(scope state.Keys, storage map[string][]byte) *TStateView {
 	return &TStateView{
 		ts:                 ts,
 		pendingChangedKeys: make(map[string]maybe.Maybe[[]byte], len(scope)),
@@ -65,6 +76,19 @@ func (ts *TState) NewView(scope set.Set[string], storage map[string][]byte) *TSt
 	}
 }
 
+// NewDiscoveryView returns a [TStateView] that allows any key to be accessed. Keys
+// are fetched from [im] the first time they are accessed and then added to the scope
+// of the view with [state.All] permissions (see [Scope]).
+//
+// This is useful for determining which keys are accessed during execution when they
+// can't be specified upfront. It should never be used during block execution (where
+// all accessed keys must be specified).
+func (ts *TState) NewDiscoveryView(im state.Immutable) *TStateView {
+	tsv := ts.NewView(make(state.Keys, defaultOps), make(map[string][]byte, defaultOps))
+	tsv.discoveryState = im
+	return tsv
+}
+
 // Rollback restores the TState to the ts.op[restorePoint] operation.
 func (ts *TStateView) Rollback(_ context.Context, restorePoint int) {
 	for i := len(ts.ops) - 1; i >= restorePoint; i-- {
@@ -147,6 +171,53 @@ func (ts *TStateView) EnableAllocation() {
 	ts.canAllocate = true
 }
 
+// EnableReadTracking causes [GetValue] to record each key that is read
+// (and the number of chunks read). This is not enabled by default
+// because it is only useful when simulating execution.
+func (ts *TStateView) EnableReadTracking() {
+	if ts.reads == nil {
+		ts.reads = make(map[string]uint16, len(ts.scope))
+	}
+}
+
+// Reads returns the keys read with [GetValue] since [EnableReadTracking]
+// was called and the number of chunks read (0 if the key did not exist).
+//
+// If a key is read more than once, the largest read is returned.
+func (ts *TStateView) Reads() map[string]uint16 {
+	return ts.reads
+}
+
+// EnableValidation causes the view to record the version of each key read
+// from the parent [TState] (the first time it is read) so that [Validate]
+// can determine if execution observed stale state.
+//
+// This is only useful when views of the same [TState] are executed
+// optimistically (and may be committed in a different order than they
+// were created).
+func (ts *TStateView) EnableValidation() {
+	if ts.versions == nil {
+		ts.versions = make(map[string]int, len(ts.scope))
+	}
+}
+
+// Validate returns false if any key read from the parent [TState] has been
+// modified (by committing another view) since it was read. If this is the case,
+// the view should be discarded (and execution should be repeated).
+//
+// Validate always returns true if [EnableValidation] was not called.
+func (ts *TStateView) Validate() bool {
+	ts.ts.l.RLock()
+	defer ts.ts.l.RUnlock()
+
+	for k, version := range ts.versions {
+		if ts.ts.versions[k] != version {
+			return false
+		}
+	}
+	return true
+}
+
 // KeyOperations returns the number of operations performed since the scope
 // was last set.
 //
@@ -157,20 +228,74 @@ func (ts *TStateView) KeyOperations() (map[string]uint16, map[string]uint16) {
 	return ts.allocates, ts.writes
 }
 
-// checkScope returns whether [k] is in ts.readScope.
-func (ts *TStateView) checkScope(_ context.Context, k []byte) bool {
-	return ts.scope.Contains(string(k))
+// Change is the value of a key before and after it was modified in a [TStateView].
+// A nil value means the key did not exist.
+type Change struct {
+	Before []byte
+	After  []byte
+}
+
+// Changes returns the value of each key modified in the view before it was modified
+// (in the parent view or scope) and its current value. Keys that were returned to
+// their original value are not included.
+func (ts *TStateView) Changes(ctx context.Context) map[string]*Change {
+	changes := make(map[string]*Change, len(ts.pendingChangedKeys))
+	for k, v := range ts.pendingChangedKeys {
+		before, _ := ts.getParentValue(ctx, k)
+		changes[k] = &Change{Before: before, After: v.Value()}
+	}
+	return changes
+}
+
+// Scope returns the keys that can be accessed by the view. If the view was
+// created with [NewDiscoveryView], these are the keys that have been accessed.
+func (ts *TStateView) Scope() state.Keys {
+	return ts.scope
+}
+
+// checkScope returns an error if [k] is not in ts.scope or if it does not
+// have [permission].
+//
+// If the view is in discovery mode, [k] is fetched and added to the scope if
+// it has not been accessed before.
+func (ts *TStateView) checkScope(ctx context.Context, k []byte, permission state.Permissions) error {
+	key := string(k)
+	if p, ok := ts.scope[key]; ok {
+		if !p.Has(permission) {
+			return ErrMissingPermission
+		}
+		return nil
+	}
+	if ts.discoveryState == nil {
+		return ErrKeyNotSpecified
+	}
+	v, err := ts.discoveryState.GetValue(ctx, k)
+	switch {
+	case err == nil:
+		ts.scopeStorage[key] = v
+	case errors.Is(err, database.ErrNotFound):
+	default:
+		return err
+	}
+	ts.scope.Add(key, state.All)
+	return nil
 }
 
 // GetValue returns the value associated from tempStorage with the
-// associated [key]. If [key] does not exist in readScope or if it is not found
+// associated [key]. If [key] does not exist in scope or if it is not found
 // in storage an error is returned.
 func (ts *TStateView) GetValue(ctx context.Context, key []byte) ([]byte, error) {
-	if !ts.checkScope(ctx, key) {
-		return nil, ErrKeyNotSpecified
+	if err := ts.checkScope(ctx, key, state.Read); err != nil {
+		return nil, err
 	}
 	k := string(key)
 	v, exists := ts.getValue(ctx, k)
+	if ts.reads != nil {
+		valueChunks, _ := keys.NumChunks(v) // not possible to fail
+		if past, ok := ts.reads[k]; !ok || valueChunks > past {
+			ts.reads[k] = valueChunks
+		}
+	}
 	if !exists {
 		return nil, database.ErrNotFound
 	}
@@ -184,7 +309,7 @@ func (ts *TStateView) getValue(ctx context.Context, key string) ([]byte, bool) {
 		}
 		return v.Value(), true
 	}
-	if v, changed, exists := ts.ts.getChangedValue(ctx, key); changed {
+	if v, changed, exists := ts.getChangedValue(ctx, key); changed {
 		return v, exists
 	}
 	if v, ok := ts.scopeStorage[key]; ok {
@@ -193,23 +318,40 @@ func (ts *TStateView) getValue(ctx context.Context, key string) ([]byte, bool) {
 	return nil, false
 }
 
+// getChangedValue returns the value of [key] in the parent [TState] (if changed)
+// and records the version read (if validation is enabled).
+func (ts *TStateView) getChangedValue(ctx context.Context, key string) ([]byte, bool, bool) {
+	v, version, changed, exists := ts.ts.getChangedValue(ctx, key)
+	if ts.versions != nil {
+		if _, ok := ts.versions[key]; !ok {
+			ts.versions[key] = version
+		}
+	}
+	return v, changed, exists
+}
+
+// getParentValue returns the value of [key] in the parent view (or scope if
+// the parent is unchanged).
+func (ts *TStateView) getParentValue(ctx context.Context, key string) ([]byte, bool) {
+	if v, changed, exists := ts.getChangedValue(ctx, key); changed {
+		return v, exists
+	}
+	v, ok := ts.scopeStorage[key]
+	return v, ok
+}
+
 // isUnchanged determines if a [key] is unchanged from the parent view (or
 // scope if the parent is unchanged).
 func (ts *TStateView) isUnchanged(ctx context.Context, key string, nval []byte, nexists bool) bool {
-	if v, changed, exists := ts.ts.getChangedValue(ctx, key); changed {
-		return !exists && !nexists || exists && nexists && bytes.Equal(v, nval)
-	}
-	if v, ok := ts.scopeStorage[key]; ok {
-		return nexists && bytes.Equal(v, nval)
-	}
-	return !nexists
+	v, exists := ts.getParentValue(ctx, key)
+	return !exists && !nexists || exists && nexists && bytes.Equal(v, nval)
 }
 
 // Insert allocates and writes (or just writes) a new key to [tstate]. If this
 // action returns the value of [key] to the parent view, it reverts any pending changes.
 func (ts *TStateView) Insert(ctx context.Context, key []byte, value []byte) error {
-	if !ts.checkScope(ctx, key) {
-		return ErrKeyNotSpecified
+	if err := ts.checkScope(ctx, key, state.Write); err != nil {
+		return err
 	}
 	if !keys.VerifyValue(key, value) {
 		return ErrInvalidKeyValue
@@ -252,8 +394,8 @@ func (ts *TStateView) Insert(ctx context.Context, key []byte, value []byte) erro
 // Remove deletes a key from [tstate]. If this action returns the
 // value of [key] to the parent view, it reverts any pending changes.
 func (ts *TStateView) Remove(ctx context.Context, key []byte) error {
-	if !ts.checkScope(ctx, key) {
-		return ErrKeyNotSpecified
+	if err := ts.checkScope(ctx, key, state.Write); err != nil {
+		return err
 	}
 	k := string(key)
 	past, exists := ts.getValue(ctx, k)
@@ -298,8 +440,10 @@ func (ts *TStateView) Commit() {
 	ts.ts.l.Lock()
 	defer ts.ts.l.Unlock()
 
+	ts.ts.commits++
 	for k, v := range ts.pendingChangedKeys {
 		ts.ts.changedKeys[k] = v
+		ts.ts.versions[k] = ts.ts.commits
 	}
 	ts.ts.ops += len(ts.ops)
 }
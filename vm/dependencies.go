@@ -31,8 +31,12 @@ type Config interface {
 	GetSignatureVerificationCores() int
 	GetRootGenerationCores() int
 	GetTransactionExecutionCores() int
+	GetOptimisticExecution() bool
 	GetMempoolSponsorSize() int
 	GetMempoolExemptSponsors() []codec.Address
+	GetMempoolFeePriority() bool               // order mempool by fee per unit instead of arrival (FIFO)
+	GetMempoolPersistFrequency() time.Duration // how often to persist mempool changes to disk (0 disables persistence)
+	GetMempoolAdminToken() string              // bearer token required by the admin API (empty disables it)
 	GetVerifySignatures() bool
 	GetStreamingBacklogSize() int
 	GetStateHistoryLength() int        // how many roots back of data to keep to serve state queries
@@ -46,6 +50,7 @@ type Config interface {
 	GetParsedBlockCacheSize() int
 	GetAcceptedBlockWindow() int
 	GetAcceptedBlockWindowCache() int
+	GetReceiptWindow() int // how many blocks of receipts to keep (0 disables receipts)
 	GetContinuousProfilerConfig() *profiler.Config
 	GetTargetBuildDuration() time.Duration
 	GetProcessingBuildSkip() int
@@ -95,6 +100,15 @@ type Controller interface {
 	// storing).
 	StateManager() chain.StateManager
 
+	// FeeDistributor is used by the VM to distribute the fees paid by all
+	// transactions in a block (once per block).
+	FeeDistributor() chain.FeeDistributor
+
+	// BlockBuildPolicy is used by the VM to determine which transactions to
+	// include in blocks it builds (and in what order). Most VMs should use
+	// [chain.DefaultBlockBuildPolicy].
+	BlockBuildPolicy() chain.BlockBuildPolicy
+
 	// Anything that the VM wishes to store outside of state or blocks must be
 	// recorded here
 	Accepted(ctx context.Context, blk *chain.StatelessBlock) error
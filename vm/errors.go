@@ -15,4 +15,11 @@ var (
 	ErrStateSyncing        = errors.New("state still syncing")
 	ErrUnexpectedStateRoot = errors.New("unexpected state root")
 	ErrTooManyProcessing   = errors.New("too many processing")
+	ErrReplayUnavailable   = errors.New("replay unavailable")
+	ErrCorruptTx           = errors.New("corrupt tx")
+	ErrReplaced            = errors.New("replaced")
+	ErrCanceled            = errors.New("canceled")
+	ErrEvicted             = errors.New("evicted")
+	ErrReplacementSponsor  = errors.New("replacement has different sponsor")
+	ErrReplacementFee      = errors.New("replacement fee too low")
 )
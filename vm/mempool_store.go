@@ -0,0 +1,277 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// mempoolStore persists the transactions added to the [mempool.Mempool] so
+// that they can be restored when the node restarts.
+//
+// Changes are buffered in memory and written to disk in a single batch every
+// [flushFrequency] (write-behind), so persistence never slows down
+// [Submit]. Changes that are buffered when a node crashes are lost, so
+// persisted transactions may have already been included (or removed from the
+// mempool) and all transactions must be re-verified when they are restored.
+//
+// Only transactions that the mempool actually holds are persisted and they
+// are deleted whenever they are removed from the mempool (included, expired,
+// replaced, evicted by a transaction that pays more, or evicted by an admin).
+//
+// Only transactions submitted individually are persisted ([chain.Bundle]s
+// are not).
+type mempoolStore struct {
+	db             database.Database
+	log            logging.Logger
+	flushFrequency time.Duration
+
+	l            sync.Mutex
+	puts         map[ids.ID]*chain.Transaction
+	deletes      map[ids.ID]int64 // txID -> expiry
+	minTimestamp int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newMempoolStore(db database.Database, log logging.Logger, flushFrequency time.Duration) *mempoolStore {
+	return &mempoolStore{
+		db:             db,
+		log:            log,
+		flushFrequency: flushFrequency,
+		puts:           map[ids.ID]*chain.Transaction{},
+		deletes:        map[ids.ID]int64{},
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// [mempoolPrefix] + [expiry] + [txID]
+//
+// Keys are sorted by [expiry] so that expired transactions can be deleted
+// without reading every persisted transaction.
+func PrefixMempoolKey(expiry int64, txID ids.ID) []byte {
+	k := make([]byte, 1+consts.Uint64Len+consts.IDLen)
+	k[0] = mempoolPrefix
+	binary.BigEndian.PutUint64(k[1:], uint64(expiry))
+	copy(k[1+consts.Uint64Len:], txID[:])
+	return k
+}
+
+// Add persists [txs] on the next flush.
+func (s *mempoolStore) Add(txs []*chain.Transaction) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for _, tx := range txs {
+		txID := tx.ID()
+		delete(s.deletes, txID)
+		s.puts[txID] = tx
+	}
+}
+
+// Remove deletes [txs] on the next flush.
+func (s *mempoolStore) Remove(txs []*chain.Transaction) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for _, tx := range txs {
+		txID := tx.ID()
+		delete(s.puts, txID)
+		s.deletes[txID] = tx.Expiry()
+	}
+}
+
+// SetMinTimestamp deletes all transactions with an expiry less than [t] on the
+// next flush (mirroring [mempool.Mempool.SetMinTimestamp]).
+func (s *mempoolStore) SetMinTimestamp(t int64) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if t > s.minTimestamp {
+		s.minTimestamp = t
+	}
+}
+
+// Flush writes all buffered changes to disk.
+func (s *mempoolStore) Flush() error {
+	s.l.Lock()
+	puts, deletes, minTimestamp := s.puts, s.deletes, s.minTimestamp
+	s.puts = map[ids.ID]*chain.Transaction{}
+	s.deletes = map[ids.ID]int64{}
+	s.l.Unlock()
+
+	batch := s.db.NewBatch()
+	for txID, tx := range puts {
+		if tx.Expiry() < minTimestamp {
+			continue
+		}
+		if err := batch.Put(PrefixMempoolKey(tx.Expiry(), txID), tx.Bytes()); err != nil {
+			return err
+		}
+	}
+	for txID, expiry := range deletes {
+		if err := batch.Delete(PrefixMempoolKey(expiry, txID)); err != nil {
+			return err
+		}
+	}
+
+	// Delete expired transactions
+	iter := s.db.NewIteratorWithPrefix([]byte{mempoolPrefix})
+	defer iter.Release()
+	for iter.Next() {
+		k := iter.Key()
+		if int64(binary.BigEndian.Uint64(k[1:])) >= minTimestamp {
+			break
+		}
+		if err := batch.Delete(k); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Load returns all persisted transactions (and their keys).
+func (s *mempoolStore) Load() ([][]byte, [][]byte, error) {
+	iter := s.db.NewIteratorWithPrefix([]byte{mempoolPrefix})
+	defer iter.Release()
+
+	keys := [][]byte{}
+	values := [][]byte{}
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		values = append(values, iter.Value())
+	}
+	return keys, values, iter.Error()
+}
+
+// Delete immediately removes [keys] from disk (used to clean up transactions
+// that can't be restored).
+func (s *mempoolStore) Delete(keys [][]byte) error {
+	batch := s.db.NewBatch()
+	for _, k := range keys {
+		if err := batch.Delete(k); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// Run flushes buffered changes every [flushFrequency] until [Done] is called.
+func (s *mempoolStore) Run() {
+	defer close(s.done)
+
+	t := time.NewTicker(s.flushFrequency)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := s.Flush(); err != nil {
+				s.log.Warn("unable to persist mempool", zap.Error(err))
+			}
+		case <-s.stop:
+			// Persist anything that is still buffered before shutdown
+			if err := s.Flush(); err != nil {
+				s.log.Warn("unable to persist mempool", zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+// Done stops [Run] and waits for the final flush to complete.
+func (s *mempoolStore) Done() {
+	close(s.stop)
+	<-s.done
+}
+
+// restoreMempool re-submits all persisted transactions to the
+// [mempool.Mempool]. [Submit] ensures that restored transactions are not
+// repeats, have valid signatures, and can still be executed, so any
+// transaction included (or invalidated) while the node was offline is dropped.
+//
+// Restored transactions are re-gossiped like any other transaction added to
+// the [mempool.Mempool].
+func (vm *VM) restoreMempool(ctx context.Context) {
+	ctx, span := vm.tracer.Start(ctx, "VM.restoreMempool")
+	defer span.End()
+
+	start := time.Now()
+	keys, values, err := vm.mempoolStore.Load()
+	if err != nil {
+		vm.Logger().Warn("unable to load persisted mempool", zap.Error(err))
+		return
+	}
+	var (
+		txs   = make([]*chain.Transaction, 0, len(values))
+		stale = [][]byte{}
+	)
+	for i, v := range values {
+		p := codec.NewReader(v, consts.NetworkSizeLimit)
+		tx, err := chain.UnmarshalTx(p, vm.actionRegistry, vm.authRegistry)
+		if err == nil && !p.Empty() {
+			err = ErrCorruptTx
+		}
+		if err != nil {
+			// This may occur if the registries changed during an upgrade
+			vm.Logger().Debug("unable to parse persisted tx", zap.Error(err))
+			stale = append(stale, keys[i])
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	if err := vm.mempoolStore.Delete(stale); err != nil {
+		vm.Logger().Warn("unable to delete persisted txs", zap.Error(err))
+	}
+	if len(txs) == 0 {
+		return
+	}
+
+	// Any transaction that is not re-added is removed from disk on the next
+	// flush.
+	errs := vm.Submit(ctx, true, txs)
+	if len(errs) != len(txs) {
+		// [Submit] failed before checking any transaction, so we keep all of
+		// them on disk to retry on the next restart.
+		vm.Logger().Warn("unable to restore mempool", zap.Error(errs[0]))
+		return
+	}
+	dropped := []*chain.Transaction{}
+	for i, err := range errs {
+		// A valid transaction may still be dropped by the mempool (if it is
+		// full or the sponsor has too many pending transactions).
+		if err != nil || !vm.mempool.Has(ctx, txs[i].ID()) {
+			dropped = append(dropped, txs[i])
+		}
+	}
+	vm.mempoolStore.Remove(dropped)
+
+	// Remove transactions that expired while the node was offline
+	removed := vm.mempool.SetMinTimestamp(ctx, vm.lastAccepted.Tmstmp)
+	vm.mempoolStore.Remove(removed)
+	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
+	vm.Logger().Info(
+		"restored mempool",
+		zap.Int("persisted", len(values)),
+		zap.Int("restored", len(txs)-len(dropped)-len(removed)),
+		zap.Int("unparsable", len(stale)),
+		zap.Duration("t", time.Since(start)),
+	)
+}
@@ -0,0 +1,284 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/trace"
+)
+
+const testAuthTypeID uint8 = 1
+
+var testChainID = ids.GenerateTestID()
+
+var _ chain.Auth = (*testAuth)(nil)
+
+// testAuth is a minimal [chain.Auth] that can be marshaled and unmarshaled.
+type testAuth struct {
+	actor codec.Address
+}
+
+func (*testAuth) GetTypeID() uint8                                         { return testAuthTypeID }
+func (*testAuth) ValidRange(chain.Rules) (int64, int64)                    { return -1, -1 }
+func (*testAuth) MaxComputeUnits(chain.Rules) uint64                       { return 1 }
+func (*testAuth) StateKeys() []string                                      { return nil }
+func (*testAuth) AsyncVerify([]byte) error                                 { return nil }
+func (a *testAuth) Actor() codec.Address                                   { return a.actor }
+func (a *testAuth) Sponsor() codec.Address                                 { return a.actor }
+func (a *testAuth) Marshal(p *codec.Packer)                                { p.PackAddress(a.actor) }
+func (*testAuth) Size() int                                                { return codec.AddressLen }
+func (*testAuth) CanDeduct(context.Context, state.Immutable, uint64) error { return nil }
+func (*testAuth) Deduct(context.Context, state.Mutable, uint64) error      { return nil }
+func (*testAuth) Refund(context.Context, state.Mutable, uint64) error      { return nil }
+
+func (*testAuth) Verify(context.Context, chain.Rules, state.Immutable, []chain.Action) (uint64, error) {
+	return 1, nil
+}
+
+func testRegistries(t *testing.T) (chain.ActionRegistry, chain.AuthRegistry) {
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message, bool]()
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message, bool]()
+	require.NoError(t, authRegistry.Register(testAuthTypeID, func(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+		var a testAuth
+		p.UnpackAddress(&a.actor)
+		return &a, p.Err()
+	}, false))
+	return actionRegistry, authRegistry
+}
+
+// testTx returns a signed [chain.Transaction] from [sponsor] (that cancels a
+// random transaction) that expires at [expiry].
+func testTx(t *testing.T, sponsor codec.Address, expiry int64, priorityFee uint64) *chain.Transaction {
+	ctrl := gomock.NewController(t)
+	actionRegistry, authRegistry := testRegistries(t)
+
+	factory := chain.NewMockAuthFactory(ctrl)
+	factory.EXPECT().Sign(gomock.Any(), gomock.Any()).Return(&testAuth{actor: sponsor}, nil)
+	tx, err := chain.NewCancelTx(&chain.Base{
+		Timestamp:   expiry,
+		ChainID:     testChainID,
+		MaxFee:      100,
+		PriorityFee: priorityFee,
+	}, ids.GenerateTestID()).Sign(factory, actionRegistry, authRegistry)
+	require.NoError(t, err)
+	return tx
+}
+
+// loadTxs returns the IDs of all transactions persisted in [s].
+func loadTxs(t *testing.T, s *mempoolStore) []ids.ID {
+	require := require.New(t)
+	actionRegistry, authRegistry := testRegistries(t)
+
+	keys, values, err := s.Load()
+	require.NoError(err)
+	require.Len(values, len(keys))
+	txIDs := make([]ids.ID, 0, len(values))
+	for i, v := range values {
+		tx, err := chain.UnmarshalTx(codec.NewReader(v, consts.NetworkSizeLimit), actionRegistry, authRegistry)
+		require.NoError(err)
+		require.Equal(PrefixMempoolKey(tx.Expiry(), tx.ID()), keys[i])
+		txIDs = append(txIDs, tx.ID())
+	}
+	return txIDs
+}
+
+func TestMempoolStoreFlush(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		s       = newMempoolStore(memdb.New(), logging.NoLog{}, time.Hour)
+		sponsor = codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())
+		tx1     = testTx(t, sponsor, 2_000, 0)
+		tx2     = testTx(t, sponsor, 1_000, 0)
+		tx3     = testTx(t, sponsor, 3_000, 0)
+	)
+
+	// Nothing is written until flushed
+	s.Add([]*chain.Transaction{tx1, tx2})
+	require.Empty(loadTxs(t, s))
+	require.NoError(s.Flush())
+	require.Empty(s.puts)
+
+	// Transactions are loaded in order of expiry
+	require.Equal([]ids.ID{tx2.ID(), tx1.ID()}, loadTxs(t, s))
+
+	s.Remove([]*chain.Transaction{tx2})
+	s.Add([]*chain.Transaction{tx3})
+	require.NoError(s.Flush())
+	require.Empty(s.deletes)
+	require.Equal([]ids.ID{tx1.ID(), tx3.ID()}, loadTxs(t, s))
+
+	// A transaction removed before it is flushed is never written
+	tx4 := testTx(t, sponsor, 1_000, 0)
+	s.Add([]*chain.Transaction{tx4})
+	s.Remove([]*chain.Transaction{tx4})
+	require.NoError(s.Flush())
+	require.Equal([]ids.ID{tx1.ID(), tx3.ID()}, loadTxs(t, s))
+
+	// A removed transaction can be added again
+	s.Remove([]*chain.Transaction{tx1})
+	s.Add([]*chain.Transaction{tx1})
+	require.NoError(s.Flush())
+	require.Equal([]ids.ID{tx1.ID(), tx3.ID()}, loadTxs(t, s))
+}
+
+func TestMempoolStoreExpiry(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		s       = newMempoolStore(memdb.New(), logging.NoLog{}, time.Hour)
+		sponsor = codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())
+		tx1     = testTx(t, sponsor, 1_000, 0)
+		tx2     = testTx(t, sponsor, 2_000, 0)
+		tx3     = testTx(t, sponsor, 3_000, 0)
+	)
+	s.Add([]*chain.Transaction{tx1, tx2, tx3})
+	require.NoError(s.Flush())
+	require.Equal([]ids.ID{tx1.ID(), tx2.ID(), tx3.ID()}, loadTxs(t, s))
+
+	// Persisted and buffered transactions that expire before the min
+	// timestamp are deleted on the next flush
+	s.SetMinTimestamp(2_000)
+	s.Add([]*chain.Transaction{testTx(t, sponsor, 1_000, 0)})
+	require.NoError(s.Flush())
+	require.Equal([]ids.ID{tx2.ID(), tx3.ID()}, loadTxs(t, s))
+
+	// The min timestamp never decreases
+	s.SetMinTimestamp(1_000)
+	s.Add([]*chain.Transaction{testTx(t, sponsor, 1_000, 0)})
+	require.NoError(s.Flush())
+	require.Equal([]ids.ID{tx2.ID(), tx3.ID()}, loadTxs(t, s))
+
+	s.SetMinTimestamp(3_001)
+	require.NoError(s.Flush())
+	require.Empty(loadTxs(t, s))
+}
+
+func TestMempoolStoreLoadDelete(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		s       = newMempoolStore(memdb.New(), logging.NoLog{}, time.Hour)
+		sponsor = codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())
+		tx1     = testTx(t, sponsor, 1_000, 0)
+		tx2     = testTx(t, sponsor, 2_000, 0)
+	)
+	require.NoError(s.db.Put([]byte{0x0}, []byte("other")))
+	s.Add([]*chain.Transaction{tx1, tx2})
+	require.NoError(s.Flush())
+
+	// Only persisted transactions are loaded
+	keys, values, err := s.Load()
+	require.NoError(err)
+	require.Equal([][]byte{PrefixMempoolKey(1_000, tx1.ID()), PrefixMempoolKey(2_000, tx2.ID())}, keys)
+	require.Equal([][]byte{tx1.Bytes(), tx2.Bytes()}, values)
+
+	// Deletes are not buffered
+	require.NoError(s.Delete(keys[:1]))
+	require.Equal([]ids.ID{tx2.ID()}, loadTxs(t, s))
+	v, err := s.db.Get([]byte{0x0})
+	require.NoError(err)
+	require.Equal([]byte("other"), v)
+}
+
+func TestMempoolStoreRun(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		s       = newMempoolStore(memdb.New(), logging.NoLog{}, time.Hour)
+		sponsor = codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())
+		tx      = testTx(t, sponsor, 1_000, 0)
+	)
+	go s.Run()
+
+	// Buffered changes are flushed on shutdown
+	s.Add([]*chain.Transaction{tx})
+	s.Done()
+	require.Equal([]ids.ID{tx.ID()}, loadTxs(t, s))
+}
+
+func TestMempoolStoreRestart(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	var (
+		db  = memdb.New()
+		s   = newMempoolStore(db, logging.NoLog{}, time.Hour)
+		vm  = &VM{mempoolStore: s}
+		txm = mempool.NewPriority[*chain.Transaction](
+			tracer,
+			4,
+			3,
+			nil,
+			func(tx *chain.Transaction) uint64 { return tx.PriorityFee() },
+			vm.txEvicted,
+		)
+		sponsor1 = codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())
+		sponsor2 = codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())
+		sponsor3 = codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())
+	)
+	vm.mempool = txm
+	admit := func(txs ...*chain.Transaction) {
+		s.Add(txm.Admit(ctx, txs))
+	}
+
+	// Transactions dropped because a sponsor has too many pending transactions
+	// are not persisted
+	admit(
+		testTx(t, sponsor1, 5_000, 1),
+		testTx(t, sponsor1, 6_000, 2),
+		testTx(t, sponsor1, 7_000, 3),
+		testTx(t, sponsor1, 8_000, 4),
+	)
+	require.Equal(3, txm.Len(ctx))
+	require.NoError(s.Flush())
+
+	// Transactions evicted by transactions that pay more are deleted (even if
+	// they were never flushed)
+	admit(testTx(t, sponsor2, 1_000, 5))
+	admit(testTx(t, sponsor2, 6_000, 6), testTx(t, sponsor2, 6_000, 0))
+	require.Equal(4, txm.Len(ctx))
+
+	// Replaced transactions are deleted
+	replaced, ok := txm.PeekNext(ctx)
+	require.True(ok)
+	txm.Remove(ctx, []*chain.Transaction{replaced})
+	s.Remove([]*chain.Transaction{replaced})
+
+	// Transactions removed by sponsor are deleted
+	admit(testTx(t, sponsor3, 6_000, 7))
+	admit(testTx(t, sponsor3, 7_000, 8))
+	s.Remove(txm.RemoveSponsor(ctx, sponsor3))
+	admit(testTx(t, sponsor2, 9_000, 9))
+
+	// Expired transactions are deleted
+	require.Len(txm.SetMinTimestamp(ctx, 2_000), 1)
+	s.SetMinTimestamp(2_000)
+	require.NoError(s.Flush())
+
+	// Exactly the transactions in the mempool are persisted
+	live := []ids.ID{}
+	for _, tx := range txm.Items(ctx) {
+		live = append(live, tx.ID())
+	}
+	require.Len(live, 2)
+	restarted := newMempoolStore(db, logging.NoLog{}, time.Hour)
+	require.ElementsMatch(live, loadTxs(t, restarted))
+}
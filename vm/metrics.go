@@ -30,6 +30,9 @@ type Metrics struct {
 	txsGossiped              prometheus.Counter
 	txsVerified              prometheus.Counter
 	txsAccepted              prometheus.Counter
+	txsReplaced              prometheus.Counter
+	txsEvicted               prometheus.Counter
+	txsInsolvent             prometheus.Counter
 	stateChanges             prometheus.Counter
 	stateOperations          prometheus.Counter
 	buildCapped              prometheus.Counter
@@ -43,6 +46,7 @@ type Metrics struct {
 	executorVerifyBlocked    prometheus.Counter
 	executorVerifyExecutable prometheus.Counter
 	mempoolSize              prometheus.Gauge
+	bundleMempoolSize        prometheus.Gauge
 	bandwidthPrice           prometheus.Gauge
 	computePrice             prometheus.Gauge
 	storageReadPrice         prometheus.Gauge
@@ -158,6 +162,21 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "txs_gossiped",
 			Help:      "number of txs gossiped by vm",
 		}),
+		txsReplaced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_replaced",
+			Help:      "number of txs replaced (or canceled) in mempool",
+		}),
+		txsEvicted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_evicted",
+			Help:      "number of txs evicted from mempool by an admin",
+		}),
+		txsInsolvent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_insolvent",
+			Help:      "number of txs rejected because their sponsor can't pay pending fees",
+		}),
 		txsVerified: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "txs_verified",
@@ -233,6 +252,11 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "mempool_size",
 			Help:      "number of transactions in the mempool",
 		}),
+		bundleMempoolSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "bundle_mempool_size",
+			Help:      "number of bundles in the bundle mempool",
+		}),
 		bandwidthPrice: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: "chain",
 			Name:      "bandwidth_price",
@@ -278,9 +302,13 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		r.Register(m.txsGossiped),
 		r.Register(m.txsVerified),
 		r.Register(m.txsAccepted),
+		r.Register(m.txsReplaced),
+		r.Register(m.txsEvicted),
+		r.Register(m.txsInsolvent),
 		r.Register(m.stateChanges),
 		r.Register(m.stateOperations),
 		r.Register(m.mempoolSize),
+		r.Register(m.bundleMempoolSize),
 		r.Register(m.buildCapped),
 		r.Register(m.emptyBlockBuilt),
 		r.Register(m.clearedMempool),
@@ -57,6 +57,34 @@ func (mr *MockControllerMockRecorder) Accepted(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accepted", reflect.TypeOf((*MockController)(nil).Accepted), arg0, arg1)
 }
 
+// BlockBuildPolicy mocks base method.
+func (m *MockController) BlockBuildPolicy() chain.BlockBuildPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockBuildPolicy")
+	ret0, _ := ret[0].(chain.BlockBuildPolicy)
+	return ret0
+}
+
+// BlockBuildPolicy indicates an expected call of BlockBuildPolicy.
+func (mr *MockControllerMockRecorder) BlockBuildPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockBuildPolicy", reflect.TypeOf((*MockController)(nil).BlockBuildPolicy))
+}
+
+// FeeDistributor mocks base method.
+func (m *MockController) FeeDistributor() chain.FeeDistributor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeeDistributor")
+	ret0, _ := ret[0].(chain.FeeDistributor)
+	return ret0
+}
+
+// FeeDistributor indicates an expected call of FeeDistributor.
+func (mr *MockControllerMockRecorder) FeeDistributor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeDistributor", reflect.TypeOf((*MockController)(nil).FeeDistributor))
+}
+
 // Initialize mocks base method.
 func (m *MockController) Initialize(arg0 *VM, arg1 *snow.Context, arg2 metrics.MultiGatherer, arg3, arg4, arg5 []byte) (Config, Genesis, builder.Builder, gossiper.Gossiper, database.Database, database.Database, Handlers, chain.ActionRegistry, chain.AuthRegistry, map[byte]AuthEngine, error) {
 	m.ctrl.T.Helper()
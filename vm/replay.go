@@ -0,0 +1,151 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// replayPageSize is the maximum number of changes fetched from [merkledb] at
+// once when reconstructing the changes made by a block.
+const replayPageSize = 1024
+
+// historicalState provides read-only access to the state at [root], which
+// must be in the [merkledb] history.
+type historicalState struct {
+	db   merkledb.MerkleDB
+	root ids.ID
+
+	l     sync.Mutex
+	cache map[string]maybe.Maybe[[]byte]
+}
+
+func newHistoricalState(db merkledb.MerkleDB, root ids.ID) *historicalState {
+	return &historicalState{
+		db:    db,
+		root:  root,
+		cache: map[string]maybe.Maybe[[]byte]{},
+	}
+}
+
+func (h *historicalState) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	v, ok := h.cache[string(key)]
+	if !ok {
+		// There is no way to read a single value at a historical root, so we
+		// fetch a range proof that can only include [key].
+		proof, err := h.db.GetRangeProofAtRoot(ctx, h.root, maybe.Some(key), maybe.Some(key), 1)
+		if err != nil {
+			return nil, err
+		}
+		v = maybe.Nothing[[]byte]()
+		if len(proof.KeyValues) > 0 && bytes.Equal(proof.KeyValues[0].Key, key) {
+			v = maybe.Some(proof.KeyValues[0].Value)
+		}
+		h.cache[string(key)] = v
+	}
+	if v.IsNothing() {
+		return nil, database.ErrNotFound
+	}
+	return v.Value(), nil
+}
+
+// changes returns all changes made to state between [start] and [end].
+func (vm *VM) changes(ctx context.Context, start ids.ID, end ids.ID) (map[string]maybe.Maybe[[]byte], error) {
+	changes := map[string]maybe.Maybe[[]byte]{}
+	if start == end {
+		return changes, nil
+	}
+	next := maybe.Nothing[[]byte]()
+	for {
+		proof, err := vm.stateDB.GetChangeProof(ctx, start, end, next, maybe.Nothing[[]byte](), replayPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range proof.KeyChanges {
+			changes[string(change.Key)] = change.Value
+		}
+		if len(proof.KeyChanges) < replayPageSize {
+			return changes, nil
+		}
+		// The smallest key greater than the last key returned
+		next = maybe.Some(append(proof.KeyChanges[len(proof.KeyChanges)-1].Key, 0))
+	}
+}
+
+// Replay re-executes the accepted block at [height] on top of its parent state
+// and compares the outcome with what was recorded when it was accepted (see
+// [chain.StatelessBlock.Replay]).
+//
+// The parent state is reconstructed from [merkledb] history, so only blocks
+// within [GetStateHistoryLength] of the last accepted block can be replayed.
+func (vm *VM) Replay(ctx context.Context, height uint64) (*chain.ReplayResult, error) {
+	ctx, span := vm.tracer.Start(ctx, "VM.Replay")
+	defer span.End()
+
+	if !vm.isReady() {
+		return nil, ErrNotReady
+	}
+	if height == 0 {
+		return nil, fmt.Errorf("%w: cannot replay genesis", ErrReplayUnavailable)
+	}
+	lastAccepted := vm.LastAcceptedBlock()
+	if height > lastAccepted.Hght {
+		return nil, fmt.Errorf("%w: height %d is not accepted", ErrReplayUnavailable, height)
+	}
+	if lastAccepted.Hght-height >= uint64(vm.config.GetStateHistoryLength()) {
+		return nil, fmt.Errorf("%w: height %d is outside of state history", ErrReplayUnavailable, height)
+	}
+	blk, err := vm.GetDiskBlock(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to load block %d", err, height)
+	}
+
+	// A block's state root is the root after executing its parent, so the root
+	// after executing [blk] is recorded in the next block (if it exists).
+	root, err := vm.stateDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	next, err := vm.GetDiskBlock(ctx, height+1)
+	switch {
+	case err == nil:
+		root = next.StateRoot
+	case !errors.Is(err, database.ErrNotFound):
+		return nil, err
+	}
+	recorded, err := vm.changes(ctx, blk.StateRoot, root)
+	if errors.Is(err, merkledb.ErrInsufficientHistory) {
+		return nil, fmt.Errorf("%w: %w", ErrReplayUnavailable, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch any receipts stored when [blk] was accepted
+	receipts := make(map[ids.ID]*chain.Receipt, len(blk.Txs))
+	for _, tx := range blk.Txs {
+		receipt, err := vm.GetReceipt(tx.ID())
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			receipts[tx.ID()] = receipt
+		}
+	}
+	return blk.Replay(ctx, newHistoricalState(vm.stateDB, blk.StateRoot), recorded, receipts)
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/trace"
+)
+
+func newReplayStateDB(t *testing.T) merkledb.MerkleDB {
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	db, err := merkledb.New(context.Background(), memdb.New(), merkledb.Config{
+		BranchFactor:              merkledb.BranchFactor16,
+		HistoryLength:             100,
+		EvictionBatchSize:         units.MiB,
+		IntermediateNodeCacheSize: units.MiB,
+		ValueNodeCacheSize:        units.MiB,
+		Tracer:                    tracer,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+// commitChanges applies [changes] to [db] and returns the new root.
+func commitChanges(t *testing.T, db merkledb.MerkleDB, changes map[string]maybe.Maybe[[]byte]) ids.ID {
+	require := require.New(t)
+	ctx := context.Background()
+
+	batch := db.NewBatch()
+	for k, v := range changes {
+		if v.IsNothing() {
+			require.NoError(batch.Delete([]byte(k)))
+			continue
+		}
+		require.NoError(batch.Put([]byte(k), v.Value()))
+	}
+	require.NoError(batch.Write())
+	root, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	return root
+}
+
+func TestHistoricalState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db := newReplayStateDB(t)
+	parentRoot := commitChanges(t, db, map[string]maybe.Maybe[[]byte]{
+		"a": maybe.Some([]byte("1")),
+		"b": maybe.Some([]byte("2")),
+	})
+	commitChanges(t, db, map[string]maybe.Maybe[[]byte]{
+		"a": maybe.Some([]byte("10")),
+		"b": maybe.Nothing[[]byte](),
+		"c": maybe.Some([]byte("3")),
+	})
+
+	// Values are read at [parentRoot] (not the current root)
+	parent := newHistoricalState(db, parentRoot)
+	v, err := parent.GetValue(ctx, []byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("1"), v)
+	v, err = parent.GetValue(ctx, []byte("b"))
+	require.NoError(err)
+	require.Equal([]byte("2"), v)
+	_, err = parent.GetValue(ctx, []byte("c"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Missing keys are cached
+	_, err = parent.GetValue(ctx, []byte("c"))
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Contains(parent.cache, "c")
+}
+
+func TestReplayChanges(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db := newReplayStateDB(t)
+	vm := &VM{stateDB: db}
+	parentRoot := commitChanges(t, db, map[string]maybe.Maybe[[]byte]{
+		"a": maybe.Some([]byte("1")),
+		"b": maybe.Some([]byte("2")),
+	})
+
+	// More changes than fit in a single page
+	blockChanges := map[string]maybe.Maybe[[]byte]{
+		"a": maybe.Some([]byte("10")),
+		"b": maybe.Nothing[[]byte](),
+	}
+	for i := 0; i < replayPageSize+10; i++ {
+		blockChanges[fmt.Sprintf("k%05d", i)] = maybe.Some([]byte{byte(i)})
+	}
+	root := commitChanges(t, db, blockChanges)
+
+	changes, err := vm.changes(ctx, parentRoot, root)
+	require.NoError(err)
+	require.Len(changes, len(blockChanges))
+	for k, v := range blockChanges {
+		require.Contains(changes, k)
+		require.Equal(v.IsNothing(), changes[k].IsNothing(), k)
+		require.Equal(v.Value(), changes[k].Value(), k)
+	}
+
+	// No changes between the same root
+	changes, err = vm.changes(ctx, root, root)
+	require.NoError(err)
+	require.Empty(changes)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/workers"
@@ -90,6 +91,22 @@ func (vm *VM) Mempool() chain.Mempool {
 	return vm.mempool
 }
 
+// PendingTxs returns all transactions in the mempool in the order they are
+// considered for inclusion.
+func (vm *VM) PendingTxs(ctx context.Context) []*chain.Transaction {
+	return vm.mempool.Items(ctx)
+}
+
+// PendingSponsors returns the number of transactions in the mempool
+// sponsored by each account.
+func (vm *VM) PendingSponsors(ctx context.Context) map[codec.Address]int {
+	return vm.mempool.Owned(ctx)
+}
+
+func (vm *VM) BundleMempool() chain.BundleMempool {
+	return vm.bundles
+}
+
 func (vm *VM) IsRepeat(ctx context.Context, txs []*chain.Transaction, marker set.Bits, stop bool) set.Bits {
 	_, span := vm.tracer.Start(ctx, "VM.IsRepeat")
 	defer span.End()
@@ -107,6 +124,8 @@ func (vm *VM) Verified(ctx context.Context, b *chain.StatelessBlock) {
 	vm.verifiedL.Unlock()
 	vm.parsedBlocks.Evict(b.ID())
 	vm.mempool.Remove(ctx, b.Txs)
+	_, bundles := vm.splitBundles(b.Txs)
+	vm.bundles.Remove(ctx, bundles)
 	vm.gossiper.BlockVerified(b.Tmstmp)
 	vm.checkActivity(ctx)
 
@@ -143,7 +162,15 @@ func (vm *VM) Rejected(ctx context.Context, b *chain.StatelessBlock) {
 	vm.verifiedL.Lock()
 	delete(vm.verifiedBlocks, b.ID())
 	vm.verifiedL.Unlock()
-	vm.mempool.Add(ctx, b.Txs)
+
+	// Any transactions included as part of a [chain.Bundle] are restored
+	// with the rest of their [chain.Bundle].
+	txs, bundles := vm.splitBundles(b.Txs)
+	added := vm.mempool.Admit(ctx, txs)
+	vm.bundles.Add(ctx, bundles)
+	if vm.mempoolStore != nil {
+		vm.mempoolStore.Add(added)
+	}
 
 	if err := vm.c.Rejected(ctx, b); err != nil {
 		vm.Fatal("rejected processing failed", zap.Error(err))
@@ -179,7 +206,9 @@ func (vm *VM) processAcceptedBlock(b *chain.StatelessBlock) {
 	results := b.Results()
 	for i, tx := range b.Txs {
 		// Only cache auth for accepted blocks to prevent cache manipulation from RPC submissions
-		vm.cacheAuth(tx.Auth)
+		for _, auth := range tx.Auths() {
+			vm.cacheAuth(auth)
+		}
 
 		result := results[i]
 		if result.WarpMessage == nil {
@@ -303,6 +332,13 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 	// transactions instead of the mempool because we won't need to iterate
 	// through as many transactions.
 	removed := vm.mempool.SetMinTimestamp(ctx, blkTime)
+	vm.bundles.SetMinTimestamp(ctx, blkTime)
+	if vm.mempoolStore != nil {
+		// Transactions are only removed from disk once they are accepted (not
+		// when they are verified) in case the block they are in is rejected.
+		vm.mempoolStore.Remove(b.Txs)
+		vm.mempoolStore.SetMinTimestamp(blkTime)
+	}
 
 	// Enqueue block for processing
 	vm.acceptedQueue <- b
@@ -393,6 +429,14 @@ func (vm *VM) StateManager() chain.StateManager {
 	return vm.c.StateManager()
 }
 
+func (vm *VM) FeeDistributor() chain.FeeDistributor {
+	return vm.c.FeeDistributor()
+}
+
+func (vm *VM) BlockBuildPolicy() chain.BlockBuildPolicy {
+	return vm.c.BlockBuildPolicy()
+}
+
 func (vm *VM) RecordRootCalculated(t time.Duration) {
 	vm.metrics.rootCalculated.Observe(float64(t))
 }
@@ -485,6 +529,14 @@ func (vm *VM) GetTransactionExecutionCores() int {
 	return vm.config.GetTransactionExecutionCores()
 }
 
+func (vm *VM) GetOptimisticExecution() bool {
+	return vm.config.GetOptimisticExecution()
+}
+
+func (vm *VM) GetStoreReceipts() bool {
+	return vm.config.GetReceiptWindow() > 0
+}
+
 func (vm *VM) GetExecutorBuildRecorder() executor.Metrics {
 	return vm.metrics.executorBuildRecorder
 }
@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/keys"
 )
@@ -40,6 +41,9 @@ const (
 	blockHeightIDPrefix = 0x2 // Height -> ID (don't always need full block from disk)
 	warpSignaturePrefix = 0x3
 	warpFetchPrefix     = 0x4
+	receiptPrefix       = 0x5 // txID -> receipt
+	receiptHeightPrefix = 0x6 // height -> txIDs (used to delete expired receipts)
+	mempoolPrefix       = 0x7 // expiry|txID -> tx
 )
 
 var (
@@ -148,6 +152,9 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 		vm.metrics.deletedBlocks.Inc()
 		vm.Logger().Info("deleted block", zap.Uint64("height", expiryHeight))
 	}
+	if err := vm.storeReceipts(batch, blk); err != nil {
+		return fmt.Errorf("%w: unable to store receipts", err)
+	}
 	if err := batch.Write(); err != nil {
 		return fmt.Errorf("%w: unable to update last accepted", err)
 	}
@@ -167,6 +174,76 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 	return nil
 }
 
+func PrefixReceiptKey(txID ids.ID) []byte {
+	k := make([]byte, 1+consts.IDLen)
+	k[0] = receiptPrefix
+	copy(k[1:], txID[:])
+	return k
+}
+
+func PrefixReceiptHeightKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = receiptHeightPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+// storeReceipts adds the receipts of [blk] to [batch] and deletes the receipts of
+// the block that is no longer in the [GetReceiptWindow].
+func (vm *VM) storeReceipts(batch database.Batch, blk *chain.StatelessBlock) error {
+	receiptWindow := uint64(vm.config.GetReceiptWindow())
+	if receiptWindow == 0 {
+		return nil
+	}
+	receipts := blk.Receipts()
+	if len(receipts) > 0 {
+		txIDs := make([]byte, 0, len(receipts)*consts.IDLen)
+		for _, receipt := range receipts {
+			p := codec.NewWriter(receipt.Size(), consts.MaxInt)
+			receipt.Marshal(p)
+			if err := p.Err(); err != nil {
+				return err
+			}
+			if err := batch.Put(PrefixReceiptKey(receipt.TxID), p.Bytes()); err != nil {
+				return err
+			}
+			txIDs = append(txIDs, receipt.TxID[:]...)
+		}
+		if err := batch.Put(PrefixReceiptHeightKey(blk.Height()), txIDs); err != nil {
+			return err
+		}
+	}
+	if blk.Height() <= receiptWindow {
+		return nil
+	}
+	expiryHeight := blk.Height() - receiptWindow
+	expired, err := vm.vmDB.Get(PrefixReceiptHeightKey(expiryHeight))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for i := 0; i+consts.IDLen <= len(expired); i += consts.IDLen {
+		if err := batch.Delete(PrefixReceiptKey(ids.ID(expired[i : i+consts.IDLen]))); err != nil {
+			return err
+		}
+	}
+	return batch.Delete(PrefixReceiptHeightKey(expiryHeight))
+}
+
+// GetReceipt returns the [chain.Receipt] of [txID] (or nil if it is not stored).
+func (vm *VM) GetReceipt(txID ids.ID) (*chain.Receipt, error) {
+	v, err := vm.vmDB.Get(PrefixReceiptKey(txID))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return chain.UnmarshalReceipt(v)
+}
+
 func (vm *VM) GetDiskBlock(ctx context.Context, height uint64) (*chain.StatelessBlock, error) {
 	b, err := vm.vmDB.Get(PrefixBlockKey(height))
 	if err != nil {
@@ -235,6 +312,15 @@ func (vm *VM) GetOutgoingWarpMessage(txID ids.ID) (*warp.UnsignedMessage, error)
 	return warp.ParseUnsignedMessage(v)
 }
 
+// GetNonce returns the [chain.Base.Nonce] of the last transaction executed by [addr]
+// that specified a nonce.
+func (vm *VM) GetNonce(ctx context.Context, addr codec.Address) (uint64, error) {
+	if !vm.isReady() {
+		return 0, ErrNotReady
+	}
+	return chain.GetNonce(ctx, vm.stateDB, vm.c.StateManager(), addr)
+}
+
 func PrefixWarpSignatureKey(txID ids.ID, signer *bls.PublicKey) []byte {
 	k := make([]byte, 1+consts.IDLen+bls.PublicKeyLen)
 	k[0] = warpSignaturePrefix
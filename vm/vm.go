@@ -6,6 +6,7 @@ package vm
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -23,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	smath "github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
@@ -35,6 +37,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/emap"
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/mempool"
@@ -69,6 +72,23 @@ type VM struct {
 
 	tracer  trace.Tracer
 	mempool *mempool.Mempool[*chain.Transaction]
+	bundles *mempool.Mempool[*chain.Bundle]
+
+	// mempoolStore persists [mempool] across restarts (nil if disabled)
+	mempoolStore *mempoolStore
+
+	// bundled tracks the [chain.Bundle] each recently submitted bundled
+	// transaction was submitted in, so that bundles can be restored (and
+	// removed) as a unit.
+	bundled *hcache.FIFO[ids.ID, *chain.Bundle]
+
+	// replaced tracks recently replaced (or canceled) transactions, so that
+	// they are not re-added to the mempool if they are gossiped to us again.
+	replaced *hcache.FIFO[ids.ID, any]
+
+	// evicted tracks transactions recently evicted by an admin, so that they
+	// are not re-added to the mempool if they are gossiped to us again.
+	evicted *hcache.FIFO[ids.ID, any]
 
 	// track all accepted but still valid txs (replay protection)
 	seen                   *emap.EMap[*chain.Transaction]
@@ -243,12 +263,53 @@ func (vm *VM) Initialize(
 	vm.acceptedQueue = make(chan *chain.StatelessBlock, vm.config.GetAcceptorSize())
 	vm.acceptorDone = make(chan struct{})
 
-	vm.mempool = mempool.New[*chain.Transaction](
-		vm.tracer,
-		vm.config.GetMempoolSize(),
-		vm.config.GetMempoolSponsorSize(),
-		vm.config.GetMempoolExemptSponsors(),
-	)
+	if vm.config.GetMempoolFeePriority() {
+		vm.mempool = mempool.NewPriority[*chain.Transaction](
+			vm.tracer,
+			vm.config.GetMempoolSize(),
+			vm.config.GetMempoolSponsorSize(),
+			vm.config.GetMempoolExemptSponsors(),
+			vm.txFeePerUnit,
+			vm.txEvicted,
+		)
+		vm.bundles = mempool.NewPriority[*chain.Bundle](
+			vm.tracer,
+			vm.config.GetMempoolSize(),
+			vm.config.GetMempoolSponsorSize(),
+			vm.config.GetMempoolExemptSponsors(),
+			vm.bundleFeePerUnit,
+			nil,
+		)
+	} else {
+		vm.mempool = mempool.New[*chain.Transaction](
+			vm.tracer,
+			vm.config.GetMempoolSize(),
+			vm.config.GetMempoolSponsorSize(),
+			vm.config.GetMempoolExemptSponsors(),
+		)
+		vm.bundles = mempool.New[*chain.Bundle](
+			vm.tracer,
+			vm.config.GetMempoolSize(),
+			vm.config.GetMempoolSponsorSize(),
+			vm.config.GetMempoolExemptSponsors(),
+		)
+	}
+	vm.bundled, err = hcache.NewFIFO[ids.ID, *chain.Bundle](vm.config.GetMempoolSize())
+	if err != nil {
+		return err
+	}
+	vm.replaced, err = hcache.NewFIFO[ids.ID, any](vm.config.GetMempoolSize())
+	if err != nil {
+		return err
+	}
+	vm.evicted, err = hcache.NewFIFO[ids.ID, any](vm.config.GetMempoolSize())
+	if err != nil {
+		return err
+	}
+	if freq := vm.config.GetMempoolPersistFrequency(); freq > 0 {
+		vm.mempoolStore = newMempoolStore(vm.vmDB, vm.Logger(), freq)
+		go vm.mempoolStore.Run()
+	}
 
 	// Try to load last accepted
 	has, err := vm.HasLastAccepted()
@@ -397,6 +458,16 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("duplicate JSONRPC handler found: %s", rpc.JSONRPCEndpoint)
 	}
 	vm.handlers[rpc.JSONRPCEndpoint] = jsonRPCHandler
+	if token := vm.config.GetMempoolAdminToken(); len(token) > 0 {
+		adminHandler, err := rpc.NewJSONRPCHandler(rpc.Name, rpc.NewAdminJSONRPCServer(vm, token))
+		if err != nil {
+			return fmt.Errorf("unable to create admin handler: %w", err)
+		}
+		if _, ok := vm.handlers[rpc.AdminEndpoint]; ok {
+			return fmt.Errorf("duplicate admin handler found: %s", rpc.AdminEndpoint)
+		}
+		vm.handlers[rpc.AdminEndpoint] = adminHandler
+	}
 	if _, ok := vm.handlers[rpc.WebSocketEndpoint]; ok {
 		return fmt.Errorf("duplicate WebSocket handler found: %s", rpc.WebSocketEndpoint)
 	}
@@ -437,6 +508,12 @@ func (vm *VM) markReady() {
 	}
 	close(vm.ready)
 
+	// Restore any transactions that were in the mempool before the node
+	// restarted (we can only check for repeats once ready).
+	if vm.mempoolStore != nil {
+		vm.restoreMempool(context.TODO())
+	}
+
 	// Mark node ready and attempt to build a block.
 	vm.snowCtx.Log.Info(
 		"node is now ready",
@@ -562,6 +639,9 @@ func (vm *VM) Shutdown(ctx context.Context) error {
 	vm.builder.Done()
 	vm.gossiper.Done()
 	vm.sigWorkers.Stop()
+	if vm.mempoolStore != nil {
+		vm.mempoolStore.Done()
+	}
 	if vm.profiler != nil {
 		vm.profiler.Shutdown()
 	}
@@ -810,7 +890,10 @@ func (vm *VM) Submit(
 	}
 	feeManager := chain.NewFeeManager(feeRaw)
 	now := time.Now().UnixMilli()
-	r := vm.c.Rules(now)
+	r, err := chain.LoadRules(ctx, vm.c.Rules(now), view)
+	if err != nil {
+		return []error{err}
+	}
 	nextFeeManager, err := feeManager.ComputeNext(blk.Tmstmp, now, r)
 	if err != nil {
 		return []error{err}
@@ -823,13 +906,26 @@ func (vm *VM) Submit(
 		return []error{err}
 	}
 
-	validTxs := []*chain.Transaction{}
+	var (
+		validTxs = []*chain.Transaction{}
+		replaced = []*chain.Transaction{}
+		reasons  = []error{}
+		admitted = map[codec.Address]uint64{} // fees of [validTxs] by sponsor
+	)
 	for i, tx := range txs {
 		// Check if transaction is a repeat before doing any extra work
 		if repeats.Contains(i) {
 			errs = append(errs, chain.ErrDuplicateTx)
 			continue
 		}
+		if _, ok := vm.replaced.Get(tx.ID()); ok {
+			errs = append(errs, ErrReplaced)
+			continue
+		}
+		if _, ok := vm.evicted.Get(tx.ID()); ok {
+			errs = append(errs, ErrEvicted)
+			continue
+		}
 
 		// Avoid any sig verification or state lookup if we already have tx in mempool
 		txID := tx.ID()
@@ -871,19 +967,375 @@ func (vm *VM) Submit(
 		// Note, [PreExecute] ensures that the pending transaction does not have
 		// an expiry time further ahead than [ValidityWindow]. This ensures anything
 		// added to the [Mempool] is immediately executable.
-		if _, err := tx.PreExecute(ctx, nextFeeManager, vm.c.StateManager(), r, view, now); err != nil {
+		//
+		// Transactions with a nonce gap are still added to the [Mempool], where they
+		// are held until the gap is filled (or they expire).
+		if _, err := tx.PreExecute(ctx, nextFeeManager, vm.c.StateManager(), r, view, now); err != nil && !errors.Is(err, chain.ErrNonceTooHigh) {
 			errs = append(errs, err)
 			continue
 		}
+
+		// If the transaction [tx] replaces is no longer pending (it may have
+		// already been included), [tx] is added like any other transaction.
+		var pending *chain.Transaction
+		if replaces := tx.Base.Replaces; replaces != ids.Empty {
+			if p, ok := vm.mempool.Get(ctx, replaces); ok {
+				if err := canReplace(p, tx); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				pending = p
+			}
+		}
+
+		// Ensure the sponsor can pay for all of its pending transactions, so
+		// that an insolvent sponsor can't fill the mempool (and waste gossip
+		// bandwidth).
+		sponsor := tx.Sponsor()
+		fee, err := vm.checkSolvency(ctx, view, tx, admitted[sponsor], pending)
+		if err != nil {
+			vm.metrics.txsInsolvent.Inc()
+			errs = append(errs, err)
+			continue
+		}
+		admitted[sponsor] += fee
+		if pending != nil {
+			replaced = append(replaced, pending)
+			if tx.Cancels() {
+				reasons = append(reasons, ErrCanceled)
+			} else {
+				reasons = append(reasons, ErrReplaced)
+			}
+		}
 		errs = append(errs, nil)
 		validTxs = append(validTxs, tx)
 	}
-	vm.mempool.Add(ctx, validTxs)
+	vm.evictReplaced(ctx, replaced, reasons)
+	added := vm.mempool.Admit(ctx, validTxs)
+	if vm.mempoolStore != nil {
+		vm.mempoolStore.Add(added)
+	}
 	vm.checkActivity(ctx)
 	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
 	return errs
 }
 
+// canReplace returns an error if [tx] can't replace [pending] in the mempool.
+func canReplace(pending *chain.Transaction, tx *chain.Transaction) error {
+	if pending.Sponsor() != tx.Sponsor() {
+		return ErrReplacementSponsor
+	}
+	if tx.MaxFee() <= pending.MaxFee() || tx.PriorityFee() < pending.PriorityFee() {
+		return fmt.Errorf(
+			"%w: must have maxFee > %d and priorityFee >= %d",
+			ErrReplacementFee,
+			pending.MaxFee(),
+			pending.PriorityFee(),
+		)
+	}
+	return nil
+}
+
+// checkSolvency returns an error if the sponsor of [tx] can't pay the fees of
+// [tx] and all of its other pending transactions (those in either mempool and
+// [admitted] fees from the same batch) from [view]. [pending] is not counted
+// if [tx] replaces it.
+//
+// Each pending transaction counts its [MaxFee] and [PriorityFee] (the most it
+// may be charged). Transactions in pending bundles count towards their own
+// sponsor.
+// The fee of [tx] is returned if its sponsor is solvent.
+func (vm *VM) checkSolvency(
+	ctx context.Context,
+	view state.Immutable,
+	tx *chain.Transaction,
+	admitted uint64,
+	pending *chain.Transaction,
+) (uint64, error) {
+	sponsor := tx.Sponsor()
+	owed, err := smath.Add64(vm.mempool.PendingFees(ctx, sponsor), vm.bundles.PendingFees(ctx, sponsor))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", chain.ErrSponsorInsolvent, err) //nolint:errorlint
+	}
+	if pending != nil {
+		// [pending] may have been removed from the mempool since it was fetched
+		owed -= smath.Min(owed, pending.MaxFee()+pending.PriorityFee())
+	}
+	total := owed
+	for _, amount := range []uint64{admitted, tx.MaxFee(), tx.PriorityFee()} {
+		var err error
+		total, err = smath.Add64(total, amount)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", chain.ErrSponsorInsolvent, err) //nolint:errorlint
+		}
+	}
+	if err := tx.FeeAuth().CanDeduct(ctx, view, total); err != nil {
+		return 0, fmt.Errorf("%w: pending=%d: %v", chain.ErrSponsorInsolvent, total, err) //nolint:errorlint
+	}
+	return tx.MaxFee() + tx.PriorityFee(), nil
+}
+
+// evictReplaced removes [txs] from the mempool (and any caches) and notifies
+// listeners that they were removed because of [reasons].
+func (vm *VM) evictReplaced(ctx context.Context, txs []*chain.Transaction, reasons []error) {
+	if len(txs) == 0 {
+		return
+	}
+	vm.mempool.Remove(ctx, txs)
+	if vm.mempoolStore != nil {
+		vm.mempoolStore.Remove(txs)
+	}
+	txIDs := make([]ids.ID, len(txs))
+	for i, tx := range txs {
+		txID := tx.ID()
+		txIDs[i] = txID
+		vm.replaced.Put(txID, nil)
+		if err := vm.webSocketServer.RemoveTx(txID, reasons[i]); err != nil {
+			vm.snowCtx.Log.Warn("unable to remove tx from webSocketServer", zap.Error(err))
+		}
+	}
+	vm.gossiper.Evict(txIDs)
+	vm.metrics.txsReplaced.Add(float64(len(txs)))
+	vm.snowCtx.Log.Debug("replaced txs in mempool", zap.Int("txs", len(txs)))
+}
+
+// EvictTxs removes [txIDs] from the mempool and returns the transactions
+// that were removed. Evicted transactions are not re-added if they are
+// submitted (or gossiped to us) again.
+func (vm *VM) EvictTxs(ctx context.Context, txIDs []ids.ID) []*chain.Transaction {
+	ctx, span := vm.tracer.Start(ctx, "VM.EvictTxs")
+	defer span.End()
+
+	txs := make([]*chain.Transaction, 0, len(txIDs))
+	for _, txID := range txIDs {
+		if tx, ok := vm.mempool.Get(ctx, txID); ok {
+			txs = append(txs, tx)
+		}
+	}
+	vm.mempool.Remove(ctx, txs)
+	vm.recordEvicted(ctx, txs)
+	return txs
+}
+
+// EvictSponsor removes all transactions sponsored by [sponsor] from the
+// mempool and returns them.
+func (vm *VM) EvictSponsor(ctx context.Context, sponsor codec.Address) []*chain.Transaction {
+	ctx, span := vm.tracer.Start(ctx, "VM.EvictSponsor")
+	defer span.End()
+
+	txs := vm.mempool.RemoveSponsor(ctx, sponsor)
+	vm.recordEvicted(ctx, txs)
+	return txs
+}
+
+// recordEvicted cleans up after an admin removed [txs] from the mempool.
+func (vm *VM) recordEvicted(ctx context.Context, txs []*chain.Transaction) {
+	if len(txs) == 0 {
+		return
+	}
+	if vm.mempoolStore != nil {
+		vm.mempoolStore.Remove(txs)
+	}
+	txIDs := make([]ids.ID, len(txs))
+	for i, tx := range txs {
+		txID := tx.ID()
+		txIDs[i] = txID
+		vm.evicted.Put(txID, nil)
+		if err := vm.webSocketServer.RemoveTx(txID, ErrEvicted); err != nil {
+			vm.snowCtx.Log.Warn("unable to remove tx from webSocketServer", zap.Error(err))
+		}
+	}
+	vm.gossiper.Evict(txIDs)
+	vm.metrics.txsEvicted.Add(float64(len(txs)))
+	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
+	vm.snowCtx.Log.Info("admin evicted txs from mempool", zap.Int("txs", len(txs)))
+}
+
+// SubmitBundles verifies [bundles] and adds them to the [chain.BundleMempool].
+// A [chain.Bundle] is only added if all of its transactions are valid.
+func (vm *VM) SubmitBundles(
+	ctx context.Context,
+	verifySig bool,
+	bundles []*chain.Bundle,
+) (errs []error) {
+	ctx, span := vm.tracer.Start(ctx, "VM.SubmitBundles")
+	defer span.End()
+
+	if !vm.isReady() {
+		return []error{ErrNotReady}
+	}
+
+	// Create temporary execution context
+	blk, err := vm.GetStatelessBlock(ctx, vm.preferred)
+	if err != nil {
+		return []error{err}
+	}
+	view, err := blk.View(ctx, false)
+	if err != nil {
+		// This will error if a block does not yet have processed state.
+		return []error{err}
+	}
+	feeRaw, err := view.GetValue(ctx, chain.FeeKey(vm.StateManager().FeeKey()))
+	if err != nil {
+		return []error{err}
+	}
+	feeManager := chain.NewFeeManager(feeRaw)
+	now := time.Now().UnixMilli()
+	r, err := chain.LoadRules(ctx, vm.c.Rules(now), view)
+	if err != nil {
+		return []error{err}
+	}
+	nextFeeManager, err := feeManager.ComputeNext(blk.Tmstmp, now, r)
+	if err != nil {
+		return []error{err}
+	}
+
+	// Find repeats
+	txs := []*chain.Transaction{}
+	for _, bundle := range bundles {
+		txs = append(txs, bundle.Txs...)
+	}
+	vm.metrics.txsSubmitted.Add(float64(len(txs)))
+	oldestAllowed := now - r.GetValidityWindow()
+	repeats, err := blk.IsRepeat(ctx, oldestAllowed, txs, set.NewBits(), false)
+	if err != nil {
+		return []error{err}
+	}
+
+	var (
+		validBundles = []*chain.Bundle{}
+		admitted     = map[codec.Address]uint64{} // fees of [validBundles] by sponsor
+		offset       = 0
+	)
+	for _, bundle := range bundles {
+		start := offset
+		offset += len(bundle.Txs)
+
+		// Avoid any sig verification or state lookup if we already have bundle in mempool
+		if vm.bundles.Has(ctx, bundle.ID()) {
+			errs = append(errs, ErrNotAdded)
+			continue
+		}
+
+		var (
+			bundleErr error
+			fees      = map[codec.Address]uint64{} // fees of [bundle] by sponsor
+		)
+		for i, tx := range bundle.Txs {
+			// Check if transaction is a repeat before doing any extra work
+			if repeats.Contains(start + i) {
+				bundleErr = chain.ErrDuplicateTx
+				break
+			}
+
+			// Ensure state keys are valid
+			if _, err := tx.StateKeys(vm.c.StateManager()); err != nil {
+				bundleErr = ErrNotAdded
+				break
+			}
+
+			// Verify signature if not already verified by caller
+			if verifySig && vm.config.GetVerifySignatures() {
+				sigVerify := tx.AuthAsyncVerify()
+				if err := sigVerify(); err != nil {
+					if err := vm.webSocketServer.RemoveTx(tx.ID(), err); err != nil {
+						vm.snowCtx.Log.Warn("unable to remove tx from webSocketServer", zap.Error(err))
+					}
+					bundleErr = err
+					break
+				}
+			}
+
+			// Transactions in a bundle are checked independently against the
+			// same state, so later transactions may have a nonce gap.
+			if _, err := tx.PreExecute(ctx, nextFeeManager, vm.c.StateManager(), r, view, now); err != nil && !errors.Is(err, chain.ErrNonceTooHigh) {
+				bundleErr = err
+				break
+			}
+
+			// Ensure the sponsor can pay for this transaction, all of its
+			// pending transactions, and those earlier in [bundle].
+			sponsor := tx.Sponsor()
+			fee, err := vm.checkSolvency(ctx, view, tx, admitted[sponsor]+fees[sponsor], nil)
+			if err != nil {
+				vm.metrics.txsInsolvent.Inc()
+				bundleErr = err
+				break
+			}
+			fees[sponsor] += fee
+		}
+		errs = append(errs, bundleErr)
+		if bundleErr != nil {
+			continue
+		}
+		for sponsor, fee := range fees {
+			admitted[sponsor] += fee
+		}
+		for _, tx := range bundle.Txs {
+			vm.bundled.Put(tx.ID(), bundle)
+		}
+		validBundles = append(validBundles, bundle)
+	}
+	vm.bundles.Add(ctx, validBundles)
+	vm.checkActivity(ctx)
+	vm.metrics.bundleMempoolSize.Set(float64(vm.bundles.Len(ctx)))
+	return errs
+}
+
+// splitBundles separates [txs] into those that were submitted individually
+// and the [chain.Bundle]s the rest were submitted in.
+func (vm *VM) splitBundles(txs []*chain.Transaction) ([]*chain.Transaction, []*chain.Bundle) {
+	var (
+		singles = make([]*chain.Transaction, 0, len(txs))
+		bundles = []*chain.Bundle{}
+		seen    = set.Set[ids.ID]{}
+	)
+	for _, tx := range txs {
+		bundle, ok := vm.bundled.Get(tx.ID())
+		if !ok {
+			singles = append(singles, tx)
+			continue
+		}
+		if seen.Contains(bundle.ID()) {
+			continue
+		}
+		seen.Add(bundle.ID())
+		bundles = append(bundles, bundle)
+	}
+	return singles, bundles
+}
+
+// Simulate executes [tx] on top of the last accepted state as if it were
+// included in a block built now, without persisting any changes.
+//
+// If [discover] is set, [tx] may access keys it did not specify (see [chain.Simulate]).
+func (vm *VM) Simulate(ctx context.Context, tx *chain.Transaction, discover bool) (*chain.SimulationResult, error) {
+	ctx, span := vm.tracer.Start(ctx, "VM.Simulate")
+	defer span.End()
+
+	if !vm.isReady() {
+		return nil, ErrNotReady
+	}
+
+	// Create temporary execution context
+	blk := vm.LastAcceptedBlock()
+	feeRaw, err := vm.stateDB.GetValue(ctx, chain.FeeKey(vm.StateManager().FeeKey()))
+	if err != nil {
+		return nil, err
+	}
+	feeManager := chain.NewFeeManager(feeRaw)
+	now := time.Now().UnixMilli()
+	r, err := chain.LoadRules(ctx, vm.c.Rules(now), vm.stateDB)
+	if err != nil {
+		return nil, err
+	}
+	nextFeeManager, err := feeManager.ComputeNext(blk.Tmstmp, now, r)
+	if err != nil {
+		return nil, err
+	}
+	return chain.Simulate(ctx, tx, nextFeeManager, vm.c.StateManager(), r, vm.stateDB, now, discover)
+}
+
 // "SetPreference" implements "block.ChainVM"
 // replaces "core.SnowmanVM.SetPreference"
 func (vm *VM) SetPreference(_ context.Context, id ids.ID) error {
@@ -1022,6 +1474,67 @@ func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, erro
 	return vm.GetBlockHeightID(height)
 }
 
+// feePerUnit returns the max fee offered by [txs] per unit they could consume
+// under [r] (0 if the units can't be computed).
+func (vm *VM) feePerUnit(r chain.Rules, txs ...*chain.Transaction) uint64 {
+	var (
+		maxFee   uint64
+		maxUnits uint64
+		err      error
+	)
+	for _, tx := range txs {
+		units, uerr := tx.MaxUnits(vm.c.StateManager(), r)
+		if uerr != nil {
+			return 0
+		}
+		for d := chain.Dimension(0); d < chain.FeeDimensions; d++ {
+			if maxUnits, err = smath.Add64(maxUnits, units[d]); err != nil {
+				return 0
+			}
+		}
+		if maxFee, err = smath.Add64(maxFee, tx.MaxFee()); err != nil {
+			return 0
+		}
+	}
+	if maxUnits == 0 {
+		return 0
+	}
+	return maxFee / maxUnits
+}
+
+// feeRules returns the [chain.Rules] used to prioritize the mempool. Like
+// [Submit], these are loaded from the last accepted state (so they include
+// any parameters changed by governance).
+func (vm *VM) feeRules() (chain.Rules, error) {
+	return chain.LoadRules(context.TODO(), vm.c.Rules(time.Now().UnixMilli()), vm.stateDB)
+}
+
+func (vm *VM) txFeePerUnit(tx *chain.Transaction) uint64 {
+	r, err := vm.feeRules()
+	if err != nil {
+		vm.snowCtx.Log.Warn("unable to load rules", zap.Error(err))
+		return 0
+	}
+	return vm.feePerUnit(r, tx)
+}
+
+func (vm *VM) bundleFeePerUnit(bundle *chain.Bundle) uint64 {
+	r, err := vm.feeRules()
+	if err != nil {
+		vm.snowCtx.Log.Warn("unable to load rules", zap.Error(err))
+		return 0
+	}
+	return vm.feePerUnit(r, bundle.Txs...)
+}
+
+// txEvicted is called when [tx] is evicted from the mempool by a transaction
+// that pays more.
+func (vm *VM) txEvicted(tx *chain.Transaction) {
+	if vm.mempoolStore != nil {
+		vm.mempoolStore.Remove([]*chain.Transaction{tx})
+	}
+}
+
 // backfillSeenTransactions makes a best effort to populate [vm.seen]
 // with whatever transactions we already have on-disk. This will lead
 // a node to becoming ready faster during a restart.
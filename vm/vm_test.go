@@ -17,9 +17,11 @@ import (
 
 	hcache "github.com/ava-labs/hypersdk/cache"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/config"
 	"github.com/ava-labs/hypersdk/emap"
 	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/trace"
 )
 
@@ -54,6 +56,7 @@ func TestBlockCache(t *testing.T) {
 		verifiedBlocks: make(map[ids.ID]*chain.StatelessBlock),
 		seen:           emap.NewEMap[*chain.Transaction](),
 		mempool:        mempool.New[*chain.Transaction](tracer, 100, 32, nil),
+		bundles:        mempool.New[*chain.Bundle](tracer, 100, 32, nil),
 		acceptedQueue:  make(chan *chain.StatelessBlock, 1024), // don't block on queue
 		c:              controller,
 	}
@@ -80,3 +83,48 @@ func TestBlockCache(t *testing.T) {
 	require.NoError(err)
 	require.Equal(blk, blk2)
 }
+
+// testStateRules are [chain.StateRules] that load [loaded] from state.
+type testStateRules struct {
+	chain.Rules
+
+	loaded chain.Rules
+}
+
+func (r *testStateRules) Load(context.Context, state.Immutable) (chain.Rules, error) {
+	return r.loaded, nil
+}
+
+func TestFeePerUnit(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	static := chain.NewMockRules(ctrl)
+	static.EXPECT().GetBaseComputeUnits().Return(uint64(1)).AnyTimes()
+	loaded := chain.NewMockRules(ctrl)
+	loaded.EXPECT().GetBaseComputeUnits().Return(uint64(10_000)).AnyTimes()
+	controller := NewMockController(ctrl)
+	controller.EXPECT().Rules(gomock.Any()).Return(&testStateRules{Rules: static, loaded: loaded}).AnyTimes()
+	controller.EXPECT().StateManager().Return(nil).AnyTimes()
+	vm := &VM{
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		c:       controller,
+	}
+
+	actionRegistry, authRegistry := testRegistries(t)
+	factory := chain.NewMockAuthFactory(ctrl)
+	factory.EXPECT().Sign(gomock.Any(), gomock.Any()).Return(&testAuth{actor: codec.CreateAddress(testAuthTypeID, ids.GenerateTestID())}, nil)
+	tx, err := chain.NewCancelTx(&chain.Base{
+		Timestamp: 1_000,
+		ChainID:   testChainID,
+		MaxFee:    1_000_000,
+	}, ids.GenerateTestID()).Sign(factory, actionRegistry, authRegistry)
+	require.NoError(err)
+
+	// Priority is computed with the rules loaded from state (the base compute
+	// units of [loaded] and the compute units of [testAuth])
+	expected := tx.MaxFee() / (uint64(tx.Size()) + 10_000 + 1)
+	require.Equal(expected, vm.txFeePerUnit(tx))
+	require.Equal(expected, vm.bundleFeePerUnit(&chain.Bundle{Txs: []*chain.Transaction{tx}}))
+	require.Greater(vm.feePerUnit(static, tx), expected)
+}
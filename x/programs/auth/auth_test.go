@@ -0,0 +1,258 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/bytecodealliance/wasmtime-go/v14"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+var _ BalanceHandler = (*testHandler)(nil)
+
+// passwordProgram stores the data provided during initialization at key "k"
+// and authorizes any transaction that provides the same data.
+const passwordProgram = `
+(module
+  (import "state" "put" (func $put (param i64 i64 i64) (result i64)))
+  (import "state" "get" (func $get (param i64 i64) (result i64)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "k")
+  (global $next (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $len i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $next))
+    (global.set $next (i32.add (global.get $next) (local.get $len)))
+    (local.get $ptr))
+  (func (export "init_guest") (param $id i64) (param $data i64) (result i64)
+    (call $put (local.get $id) (i64.const 4294967296) (local.get $data)))
+  (func (export "verify_guest") (param $id i64) (param $digest i64) (param $data i64) (result i64)
+    (local $stored i64)
+    (local $len i32)
+    (local $a i32)
+    (local $b i32)
+    (local $i i32)
+    (local.set $stored (call $get (local.get $id) (i64.const 4294967296)))
+    (if (i64.eq (local.get $stored) (i64.const -1)) (then (return (i64.const 0))))
+    (if (i64.ne (i64.shr_u (local.get $stored) (i64.const 32)) (i64.shr_u (local.get $data) (i64.const 32)))
+      (then (return (i64.const 0))))
+    (local.set $len (i32.wrap_i64 (i64.shr_u (local.get $data) (i64.const 32))))
+    (local.set $a (i32.wrap_i64 (local.get $stored)))
+    (local.set $b (i32.wrap_i64 (local.get $data)))
+    (block $done
+      (loop $next
+        (br_if $done (i32.ge_u (local.get $i) (local.get $len)))
+        (if (i32.ne
+              (i32.load8_u (i32.add (local.get $a) (local.get $i)))
+              (i32.load8_u (i32.add (local.get $b) (local.get $i))))
+          (then (return (i64.const 0))))
+        (local.set $i (i32.add (local.get $i) (i32.const 1)))
+        (br $next)))
+    (i64.const 1)))
+`
+
+// loopProgram never returns from [VerifyFunction].
+const loopProgram = `
+(module
+  (memory (export "memory") 1)
+  (func (export "alloc") (param $len i32) (result i32)
+    (i32.const 1024))
+  (func (export "init_guest") (param $id i64) (param $data i64) (result i64)
+    (i64.const 0))
+  (func (export "verify_guest") (param $id i64) (param $digest i64) (param $data i64) (result i64)
+    (loop $forever (br $forever))
+    (i64.const 1)))
+`
+
+type testHandler struct{}
+
+func (*testHandler) StateKeys(codec.Address) []string {
+	return nil
+}
+
+func (*testHandler) StateKeysMaxChunks() []uint16 {
+	return nil
+}
+
+func (*testHandler) CanDeduct(context.Context, codec.Address, state.Immutable, uint64) error {
+	return nil
+}
+
+func (*testHandler) Deduct(context.Context, codec.Address, state.Mutable, uint64) error {
+	return nil
+}
+
+func (*testHandler) Refund(context.Context, codec.Address, state.Mutable, uint64) error {
+	return nil
+}
+
+func newTestModule(t *testing.T) *Module {
+	m, err := NewModule(logging.NoLog{}, Config{
+		AuthID:              5,
+		DeployID:            6,
+		Prefix:              []byte{0xf},
+		MaxProgramSize:      4096,
+		MaxDataSize:         64,
+		MaxUnits:            100_000,
+		UnitsPerComputeUnit: 1_000,
+	}, &testHandler{})
+	require.NoError(t, err)
+	return m
+}
+
+func compile(t *testing.T, wat string) []byte {
+	programBytes, err := wasmtime.Wat2Wasm(wat)
+	require.NoError(t, err)
+	return programBytes
+}
+
+// deploy executes [Deploy] for [programBytes] and returns the ID of the
+// program.
+func deploy(t *testing.T, ts *tstate.TState, m *Module, programBytes []byte, keys [][]byte, data []byte) ids.ID {
+	require := require.New(t)
+	programID := ids.GenerateTestID()
+	action := m.NewDeploy(programBytes, keys, data)
+	tsv := ts.NewView(action.StateKeys(nil, programID), map[string][]byte{})
+	success, units, output, _, err := action.Execute(context.Background(), nil, tsv, 0, nil, programID, false)
+	require.NoError(err)
+	require.True(success, string(output))
+	require.LessOrEqual(units, action.MaxComputeUnits(nil))
+	tsv.Commit()
+	return programID
+}
+
+func verify(ts *tstate.TState, auth chain.Auth, digest []byte) (uint64, error) {
+	scope := state.Keys{}
+	for _, k := range auth.StateKeys() {
+		scope.Add(k, state.Read)
+	}
+	tsv := ts.NewView(scope, map[string][]byte{})
+	return auth.(chain.DigestAuth).VerifyDigest(context.Background(), nil, tsv, digest, nil)
+}
+
+func TestNewModule(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewModule(logging.NoLog{}, Config{MaxProgramSize: 10, MaxUnits: 10}, nil)
+	require.ErrorIs(err, ErrInvalidConfig)
+
+	_, err = NewModule(logging.NoLog{}, Config{
+		MaxProgramSize:      64 * int(consts.MaxUint16),
+		MaxUnits:            10,
+		UnitsPerComputeUnit: 1,
+	}, nil)
+	require.ErrorIs(err, ErrInvalidConfig)
+}
+
+func TestProgramAuth(t *testing.T) {
+	require := require.New(t)
+	m := newTestModule(t)
+	ts := tstate.New(10)
+	keys := [][]byte{[]byte("k")}
+	programID := deploy(t, ts, m, compile(t, passwordProgram), keys, []byte("password"))
+	digest := []byte("digest")
+
+	// Authorized
+	factory := m.NewFactory(programID, keys, func([]byte, []chain.Action) ([]byte, error) {
+		return []byte("password"), nil
+	})
+	auth, err := factory.Sign(digest, nil)
+	require.NoError(err)
+	require.Equal(m.Address(programID), auth.Actor())
+	units, err := verify(ts, auth, digest)
+	require.NoError(err)
+	require.Greater(units, uint64(BaseComputeUnits))
+	require.LessOrEqual(units, auth.MaxComputeUnits(nil))
+
+	// Not authorized
+	auth, err = m.NewFactory(programID, keys, func([]byte, []chain.Action) ([]byte, error) {
+		return []byte("wrong"), nil
+	}).Sign(digest, nil)
+	require.NoError(err)
+	_, err = verify(ts, auth, digest)
+	require.ErrorIs(err, ErrUnauthorized)
+
+	// Verify requires the digest
+	_, err = auth.Verify(context.Background(), nil, nil, nil)
+	require.ErrorIs(err, ErrDigestRequired)
+
+	// Storage must be declared
+	auth, err = m.NewFactory(programID, nil, func([]byte, []chain.Action) ([]byte, error) {
+		return []byte("password"), nil
+	}).Sign(digest, nil)
+	require.NoError(err)
+	_, err = verify(ts, auth, digest)
+	require.Error(err)
+
+	// Program must exist
+	auth, err = m.NewFactory(ids.GenerateTestID(), keys, func([]byte, []chain.Action) ([]byte, error) {
+		return []byte("password"), nil
+	}).Sign(digest, nil)
+	require.NoError(err)
+	_, err = verify(ts, auth, digest)
+	require.ErrorIs(err, ErrProgramMissing)
+}
+
+func TestProgramAuthMetered(t *testing.T) {
+	require := require.New(t)
+	m := newTestModule(t)
+	ts := tstate.New(10)
+	programID := deploy(t, ts, m, compile(t, loopProgram), nil, nil)
+
+	auth, err := m.NewFactory(programID, nil, func([]byte, []chain.Action) ([]byte, error) {
+		return nil, nil
+	}).Sign([]byte("digest"), nil)
+	require.NoError(err)
+	// The program is interrupted once it runs out of units
+	_, err = verify(ts, auth, []byte("digest"))
+	require.Error(err)
+}
+
+func TestMarshal(t *testing.T) {
+	require := require.New(t)
+	m := newTestModule(t)
+
+	auth := &Program{
+		ProgramID: ids.GenerateTestID(),
+		Keys:      [][]byte{[]byte("a"), []byte("b")},
+		Data:      []byte("data"),
+		module:    m,
+	}
+	p := codec.NewWriter(auth.Size(), consts.MaxInt)
+	auth.Marshal(p)
+	require.NoError(p.Err())
+	require.Len(p.Bytes(), auth.Size())
+	bandwidth, _, _ := m.MaxUnits(len(auth.Keys))
+	require.LessOrEqual(uint64(auth.Size()), bandwidth)
+	parsed, err := m.Unmarshal(codec.NewReader(p.Bytes(), consts.MaxInt), nil)
+	require.NoError(err)
+	require.Equal(auth, parsed)
+
+	action := m.NewDeploy([]byte{1, 2, 3}, [][]byte{[]byte("a")}, nil)
+	p = codec.NewWriter(action.Size(), consts.MaxInt)
+	action.Marshal(p)
+	require.NoError(p.Err())
+	parsedAction, err := m.UnmarshalDeploy(codec.NewReader(p.Bytes(), consts.MaxInt), nil)
+	require.NoError(err)
+	require.Equal(action.Program, parsedAction.(*Deploy).Program)
+	require.Equal(action.Keys, parsedAction.(*Deploy).Keys)
+	require.Empty(parsedAction.(*Deploy).Data)
+
+	// Too many keys
+	p = codec.NewWriter(consts.IDLen+consts.ByteLen, consts.MaxInt)
+	p.PackID(ids.GenerateTestID())
+	p.PackByte(MaxKeys + 1)
+	_, err = m.Unmarshal(codec.NewReader(p.Bytes(), consts.MaxInt), nil)
+	require.ErrorIs(err, ErrTooManyKeys)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Deploy)(nil)
+
+// Deploy stores [Program] at the ID of the action and calls its
+// [InitFunction] with [Data]. [Keys] are the keys in program storage the
+// program may read or write during initialization.
+//
+// The deployed program controls the account at [Module.Address].
+type Deploy struct {
+	Program []byte   `json:"program"`
+	Keys    [][]byte `json:"keys"`
+	Data    []byte   `json:"data"`
+
+	module *Module
+}
+
+// NewDeploy returns a [Deploy] action for [module].
+func (m *Module) NewDeploy(program []byte, keys [][]byte, data []byte) *Deploy {
+	return &Deploy{Program: program, Keys: keys, Data: data, module: m}
+}
+
+func (d *Deploy) GetTypeID() uint8 {
+	return d.module.config.DeployID
+}
+
+func (d *Deploy) StateKeys(_ chain.Auth, actionID ids.ID) state.Keys {
+	stateKeys := state.Keys{string(d.module.ProgramKey(actionID)): state.Write}
+	for _, k := range d.Keys {
+		stateKeys.Add(string(d.module.StorageKey(actionID, k)), state.All)
+	}
+	return stateKeys
+}
+
+func (d *Deploy) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{d.module.programChunks}
+	for range d.Keys {
+		chunks = append(chunks, StorageChunks)
+	}
+	return chunks
+}
+
+func (*Deploy) OutputsWarpMessage() bool {
+	return false
+}
+
+func (d *Deploy) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	_ chain.Auth,
+	actionID ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if err := mu.Insert(ctx, d.module.ProgramKey(actionID), d.Program); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	_, units, err := d.module.call(
+		ctx,
+		&programStorage{module: d.module, im: mu, mu: mu},
+		d.Program,
+		InitFunction,
+		actionID[:],
+		d.Data,
+	)
+	if err != nil {
+		return false, d.module.MaxComputeUnits(), utils.ErrBytes(err), nil, nil
+	}
+	return true, units, nil, nil, nil
+}
+
+func (d *Deploy) MaxComputeUnits(chain.Rules) uint64 {
+	return d.module.MaxComputeUnits()
+}
+
+func (d *Deploy) Size() int {
+	return codec.BytesLen(d.Program) + keysSize(d.Keys) + codec.BytesLen(d.Data)
+}
+
+func (d *Deploy) Marshal(p *codec.Packer) {
+	p.PackBytes(d.Program)
+	marshalKeys(p, d.Keys)
+	p.PackBytes(d.Data)
+}
+
+// UnmarshalDeploy should be registered in the [chain.ActionRegistry] of a VM
+// with [DeployID].
+func (m *Module) UnmarshalDeploy(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	d := Deploy{module: m}
+	p.UnpackBytes(m.config.MaxProgramSize, true, &d.Program)
+	var err error
+	d.Keys, err = unmarshalKeys(p)
+	if err != nil {
+		return nil, err
+	}
+	p.UnpackBytes(m.config.MaxDataSize, false, &d.Data)
+	return &d, p.Err()
+}
+
+func (*Deploy) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,16 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import "errors"
+
+var (
+	ErrInvalidConfig   = errors.New("invalid config")
+	ErrTooManyKeys     = errors.New("too many keys")
+	ErrProgramMissing  = errors.New("program missing")
+	ErrNoResult        = errors.New("program returned no result")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrDigestRequired  = errors.New("digest required")
+	ErrReadOnlyStorage = errors.New("program storage is read-only")
+)
@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+var _ chain.AuthFactory = (*Factory)(nil)
+
+// Witness produces the data passed to a program to authorize the transaction
+// with [digest] (i.e. signatures over [digest]).
+type Witness func(digest []byte, actions []chain.Action) ([]byte, error)
+
+// Factory produces a [Program] for the account controlled by a program.
+type Factory struct {
+	module    *Module
+	programID ids.ID
+	keys      [][]byte
+	witness   Witness
+}
+
+// NewFactory returns a [Factory] for the program deployed at [programID] that
+// declares [keys] and authorizes transactions with the data produced by
+// [witness].
+func (m *Module) NewFactory(programID ids.ID, keys [][]byte, witness Witness) *Factory {
+	return &Factory{
+		module:    m,
+		programID: programID,
+		keys:      keys,
+		witness:   witness,
+	}
+}
+
+// Address is the address of the account controlled by the program.
+func (f *Factory) Address() codec.Address {
+	return f.module.Address(f.programID)
+}
+
+func (f *Factory) Sign(msg []byte, actions []chain.Action) (chain.Auth, error) {
+	data, err := f.witness(msg, actions)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{
+		ProgramID: f.programID,
+		Keys:      f.keys,
+		Data:      data,
+		module:    f.module,
+	}, nil
+}
+
+func (f *Factory) MaxUnits() (uint64, uint64, []uint16) {
+	return f.module.MaxUnits(len(f.keys))
+}
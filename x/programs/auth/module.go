@@ -0,0 +1,235 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package auth lets the authorization of an account be decided by a WASM
+// program (i.e. account abstraction).
+//
+// A program is deployed with [Deploy] and controls the account at
+// [Module.Address]. Each [Program] auth runs the [VerifyFunction] exported by
+// the program with the digest of the transaction and arbitrary data provided
+// by the signer (i.e. signatures). Programs can read the storage they
+// initialized during [Deploy] with the pstate import, so rules like social
+// recovery, spending limits or session keys can be implemented without
+// modifying the VM.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/programs/engine"
+	"github.com/ava-labs/hypersdk/x/programs/examples/imports/pstate"
+	"github.com/ava-labs/hypersdk/x/programs/examples/storage"
+	"github.com/ava-labs/hypersdk/x/programs/host"
+	"github.com/ava-labs/hypersdk/x/programs/program"
+	"github.com/ava-labs/hypersdk/x/programs/runtime"
+)
+
+const (
+	// VerifyFunction is called with the ID of the program, the digest of the
+	// transaction and the data provided by the signer. The [Program] is only
+	// valid if it returns 1.
+	VerifyFunction = "verify"
+	// InitFunction is called with the ID of the program and the data provided
+	// to [Deploy] when the program is deployed (its result is ignored).
+	InitFunction = "init"
+
+	// MaxKeys is the maximum number of storage keys that can be accessed by a
+	// program during a single call.
+	MaxKeys = 16
+	// MaxKeySize is the maximum size of each storage key.
+	MaxKeySize = 64
+	// StorageChunks is the max chunks of each value in program storage.
+	StorageChunks uint16 = 4
+
+	BaseComputeUnits = 5
+)
+
+const (
+	programPrefix = 0x0
+	storagePrefix = 0x1
+)
+
+// BalanceHandler is implemented by each VM that registers [Program] to pay
+// fees from the balance of an account controlled by a program.
+type BalanceHandler interface {
+	// StateKeys are the keys touched by [CanDeduct], [Deduct], and [Refund].
+	StateKeys(addr codec.Address) []string
+	// StateKeysMaxChunks are the max chunks of each key in [StateKeys].
+	StateKeysMaxChunks() []uint16
+
+	CanDeduct(ctx context.Context, addr codec.Address, im state.Immutable, amount uint64) error
+	Deduct(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+	Refund(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+}
+
+// Config is the configuration of a [Module].
+type Config struct {
+	// AuthID is the type ID of [Program] in the [chain.AuthRegistry] and
+	// DeployID is the type ID of [Deploy] in the [chain.ActionRegistry].
+	AuthID   uint8
+	DeployID uint8
+
+	// Prefix of all keys used by the [Module] (must not be a prefix of any other
+	// key used by the VM).
+	Prefix []byte
+
+	// MaxProgramSize is the maximum size of a program (in bytes).
+	MaxProgramSize int
+	// MaxDataSize is the maximum size of the data passed to a program.
+	MaxDataSize int
+
+	// MaxUnits is the maximum number of units (as metered by [engine.Meter])
+	// a program can consume in a single call. Each [UnitsPerComputeUnit] units
+	// consumed are charged as 1 compute unit.
+	MaxUnits            uint64
+	UnitsPerComputeUnit uint64
+
+	Runtime *runtime.Config
+}
+
+// Module binds [Program] and [Deploy] to the [Config] and [BalanceHandler]
+// used by a specific VM.
+type Module struct {
+	log     logging.Logger
+	config  Config
+	handler BalanceHandler
+
+	programChunks uint16
+}
+
+func NewModule(log logging.Logger, config Config, handler BalanceHandler) (*Module, error) {
+	if config.MaxUnits == 0 || config.UnitsPerComputeUnit == 0 {
+		return nil, fmt.Errorf("%w: units must be non-zero", ErrInvalidConfig)
+	}
+	if config.MaxProgramSize <= 0 || config.MaxDataSize < 0 {
+		return nil, fmt.Errorf("%w: invalid size", ErrInvalidConfig)
+	}
+	programChunks, ok := keys.NumChunks(make([]byte, config.MaxProgramSize))
+	if !ok {
+		return nil, fmt.Errorf("%w: max program size is too large", ErrInvalidConfig)
+	}
+	if config.Runtime == nil {
+		config.Runtime = runtime.NewConfig()
+	}
+	return &Module{
+		log:           log,
+		config:        config,
+		handler:       handler,
+		programChunks: programChunks,
+	}, nil
+}
+
+func (m *Module) AuthID() uint8   { return m.config.AuthID }
+func (m *Module) DeployID() uint8 { return m.config.DeployID }
+
+// Address returns the address of the account controlled by [programID].
+func (m *Module) Address(programID ids.ID) codec.Address {
+	return codec.CreateAddress(m.config.AuthID, programID)
+}
+
+// ProgramChunks are the max chunks of a program (derived from
+// [Config.MaxProgramSize]).
+func (m *Module) ProgramChunks() uint16 {
+	return m.programChunks
+}
+
+// MaxComputeUnits is the maximum compute a single program call can use.
+func (m *Module) MaxComputeUnits() uint64 {
+	return m.computeUnits(m.config.MaxUnits)
+}
+
+func (m *Module) computeUnits(units uint64) uint64 {
+	return BaseComputeUnits + (units+m.config.UnitsPerComputeUnit-1)/m.config.UnitsPerComputeUnit
+}
+
+// [prefix] + [programPrefix] + [programID]
+func (m *Module) ProgramKey(programID ids.ID) []byte {
+	k := make([]byte, 0, len(m.config.Prefix)+1+consts.IDLen+consts.Uint16Len)
+	k = append(k, m.config.Prefix...)
+	k = append(k, programPrefix)
+	k = append(k, programID[:]...)
+	return keys.EncodeChunks(k, m.programChunks)
+}
+
+// StorageKey is the key of [key] in the storage of [programID] (as accessed
+// by the pstate import).
+func (m *Module) StorageKey(programID ids.ID, key []byte) []byte {
+	return m.storageKey(storage.ProgramPrefixKey(programID[:], key))
+}
+
+// [prefix] + [storagePrefix] + [pstate key]
+func (m *Module) storageKey(key []byte) []byte {
+	k := make([]byte, 0, len(m.config.Prefix)+1+len(key)+consts.Uint16Len)
+	k = append(k, m.config.Prefix...)
+	k = append(k, storagePrefix)
+	k = append(k, key...)
+	return keys.EncodeChunks(k, StorageChunks)
+}
+
+// GetProgram returns the program deployed at [programID] (nil if it does not
+// exist).
+func (m *Module) GetProgram(ctx context.Context, im state.Immutable, programID ids.ID) ([]byte, error) {
+	v, err := im.GetValue(ctx, m.ProgramKey(programID))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	return v, err
+}
+
+// call runs [name] exported by [programBytes] with [args] and returns its
+// result and the compute units it consumed. Storage accessed with the pstate
+// import is read from (and written to) [mu].
+func (m *Module) call(
+	ctx context.Context,
+	mu state.Mutable,
+	programBytes []byte,
+	name string,
+	args ...[]byte,
+) (int64, uint64, error) {
+	// Stopping a runtime interrupts all stores created by the same engine, so
+	// each call uses its own engine to avoid interfering with programs run
+	// concurrently.
+	eng := engine.New(engine.NewConfig())
+	imports := host.NewImportsBuilder().
+		Register(pstate.Name, func() host.Import { return pstate.New(m.log, mu) }).
+		Build()
+	rt := runtime.New(m.log, eng, imports, m.config.Runtime)
+	if err := rt.Initialize(ctx, programBytes, m.config.MaxUnits); err != nil {
+		return 0, 0, err
+	}
+	defer rt.Stop()
+
+	mem, err := rt.Memory()
+	if err != nil {
+		return 0, 0, err
+	}
+	ptrs := make([]program.SmartPtr, len(args))
+	for i, arg := range args {
+		ptrs[i], err = program.BytesToSmartPtr(arg, mem)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	result, err := rt.Call(ctx, name, ptrs...)
+	if err != nil {
+		return 0, 0, err
+	}
+	balance, err := rt.Meter().GetBalance()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(result) == 0 {
+		return 0, 0, ErrNoResult
+	}
+	return result[0], m.computeUnits(m.config.MaxUnits - balance), nil
+}
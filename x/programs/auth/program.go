@@ -0,0 +1,190 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.DigestAuth = (*Program)(nil)
+
+// Program is a [chain.Auth] that is valid if the [VerifyFunction] of the
+// program deployed at [ProgramID] returns 1 for the digest of the transaction
+// and [Data].
+//
+// [Keys] are the keys in program storage the program may read (any other
+// read fails).
+type Program struct {
+	ProgramID ids.ID   `json:"programID"`
+	Keys      [][]byte `json:"keys"`
+	Data      []byte   `json:"data"`
+
+	module *Module
+}
+
+func (p *Program) GetTypeID() uint8 {
+	return p.module.config.AuthID
+}
+
+func (p *Program) MaxComputeUnits(chain.Rules) uint64 {
+	return p.module.MaxComputeUnits()
+}
+
+func (*Program) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (p *Program) StateKeys() []string {
+	stateKeys := []string{string(p.module.ProgramKey(p.ProgramID))}
+	for _, k := range p.Keys {
+		stateKeys = append(stateKeys, string(p.module.StorageKey(p.ProgramID, k)))
+	}
+	return append(stateKeys, p.module.handler.StateKeys(p.Actor())...)
+}
+
+func (*Program) AsyncVerify([]byte) error {
+	// All verification requires state
+	return nil
+}
+
+// Verify always fails because the program must be called with the digest of
+// the transaction (see [VerifyDigest]).
+func (*Program) Verify(context.Context, chain.Rules, state.Immutable, []chain.Action) (uint64, error) {
+	return 0, ErrDigestRequired
+}
+
+func (p *Program) VerifyDigest(
+	ctx context.Context,
+	_ chain.Rules,
+	im state.Immutable,
+	digest []byte,
+	_ []chain.Action,
+) (uint64, error) {
+	programBytes, err := p.module.GetProgram(ctx, im, p.ProgramID)
+	if err != nil {
+		return 0, err
+	}
+	if programBytes == nil {
+		return 0, ErrProgramMissing
+	}
+	result, units, err := p.module.call(
+		ctx,
+		&programStorage{module: p.module, im: im},
+		programBytes,
+		VerifyFunction,
+		p.ProgramID[:],
+		digest,
+		p.Data,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if result != 1 {
+		return 0, fmt.Errorf("%w: program returned %d", ErrUnauthorized, result)
+	}
+	return units, nil
+}
+
+func (p *Program) Actor() codec.Address {
+	return p.module.Address(p.ProgramID)
+}
+
+func (p *Program) Sponsor() codec.Address {
+	return p.module.Address(p.ProgramID)
+}
+
+func (p *Program) Size() int {
+	return consts.IDLen + keysSize(p.Keys) + codec.BytesLen(p.Data)
+}
+
+func (p *Program) Marshal(pk *codec.Packer) {
+	pk.PackID(p.ProgramID)
+	marshalKeys(pk, p.Keys)
+	pk.PackBytes(p.Data)
+}
+
+func (p *Program) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	return p.module.handler.CanDeduct(ctx, p.Actor(), im, amount)
+}
+
+func (p *Program) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return p.module.handler.Deduct(ctx, p.Actor(), mu, amount)
+}
+
+func (p *Program) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return p.module.handler.Refund(ctx, p.Actor(), mu, amount)
+}
+
+// Unmarshal should be registered in the [chain.AuthRegistry] of a VM with
+// [AuthID].
+func (m *Module) Unmarshal(pk *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	p := Program{module: m}
+	pk.UnpackID(true, &p.ProgramID)
+	var err error
+	p.Keys, err = unmarshalKeys(pk)
+	if err != nil {
+		return nil, err
+	}
+	pk.UnpackBytes(m.config.MaxDataSize, false, &p.Data)
+	return &p, pk.Err()
+}
+
+// MaxUnits returns the max units a [Program] that declares [numKeys] storage
+// keys could use.
+func (m *Module) MaxUnits(numKeys int) (uint64, uint64, []uint16) {
+	bandwidth := consts.IDLen + consts.ByteLen + numKeys*codec.BytesLenSize(MaxKeySize) + codec.BytesLenSize(m.config.MaxDataSize)
+	stateKeysMaxChunks := []uint16{m.programChunks}
+	for i := 0; i < numKeys; i++ {
+		stateKeysMaxChunks = append(stateKeysMaxChunks, StorageChunks)
+	}
+	return uint64(bandwidth), m.MaxComputeUnits(), append(stateKeysMaxChunks, m.handler.StateKeysMaxChunks()...)
+}
+
+func keysSize(keys [][]byte) int {
+	size := consts.ByteLen
+	for _, k := range keys {
+		size += codec.BytesLen(k)
+	}
+	return size
+}
+
+func marshalKeys(p *codec.Packer, keys [][]byte) {
+	p.PackByte(uint8(len(keys)))
+	for _, k := range keys {
+		p.PackBytes(k)
+	}
+}
+
+func unmarshalKeys(p *codec.Packer) ([][]byte, error) {
+	numKeys := p.UnpackByte()
+	if numKeys > MaxKeys {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyKeys, numKeys, MaxKeys)
+	}
+	keys := make([][]byte, numKeys)
+	for i := range keys {
+		p.UnpackBytes(MaxKeySize, true, &keys[i])
+	}
+	return keys, p.Err()
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ state.Mutable = (*programStorage)(nil)
+
+// programStorage maps the keys used by the pstate import to keys in the
+// namespace of the [Module] (see [Module.StorageKey]). If [mu] is nil,
+// storage is read-only.
+type programStorage struct {
+	module *Module
+	im     state.Immutable
+	mu     state.Mutable
+}
+
+func (s *programStorage) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	return s.im.GetValue(ctx, s.module.storageKey(key))
+}
+
+func (s *programStorage) Insert(ctx context.Context, key []byte, value []byte) error {
+	if s.mu == nil {
+		return ErrReadOnlyStorage
+	}
+	return s.mu.Insert(ctx, s.module.storageKey(key), value)
+}
+
+func (s *programStorage) Remove(ctx context.Context, key []byte) error {
+	if s.mu == nil {
+		return ErrReadOnlyStorage
+	}
+	return s.mu.Remove(ctx, s.module.storageKey(key))
+}
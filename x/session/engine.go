@@ -0,0 +1,69 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+import (
+	"github.com/ava-labs/avalanchego/utils/math"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+var _ vm.AuthEngine = (*AuthEngine)(nil)
+
+type AuthEngine struct{}
+
+func (*AuthEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
+	batchSize := math.Max(count/cores, ed25519.MinBatchSize)
+	return &Batch{
+		batchSize: batchSize,
+		total:     count,
+	}
+}
+
+func (*AuthEngine) Cache(chain.Auth) {}
+
+// Batch verifies the signatures of a set of [Session] (whether signed by the
+// owner key or a session key) in ed25519 batches.
+type Batch struct {
+	batchSize int
+	total     int
+
+	counter      int
+	totalCounter int
+	batch        *ed25519.Batch
+}
+
+func (b *Batch) Add(msg []byte, rauth chain.Auth) func() error {
+	auth := rauth.(*Session)
+	if b.batch == nil {
+		b.batch = ed25519.NewBatch()
+	}
+	b.batch.Add(msg, auth.Signer, auth.Signature)
+	b.counter++
+	b.totalCounter++
+	if b.counter == b.batchSize {
+		last := b.batch
+		b.counter = 0
+		if b.totalCounter < b.total {
+			// don't create a new batch if we are done
+			b.batch = ed25519.NewBatch()
+		}
+		return last.VerifyAsync()
+	}
+	return nil
+}
+
+func (b *Batch) Done() []func() error {
+	if b.batch == nil {
+		return nil
+	}
+	return []func() error{b.batch.VerifyAsync()}
+}
+
+// Engine returns the [vm.AuthEngine] used to batch verify [Session].
+func (*Module) Engine() *AuthEngine {
+	return &AuthEngine{}
+}
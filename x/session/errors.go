@@ -0,0 +1,22 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+import "errors"
+
+var (
+	ErrDuplicateTypeID   = errors.New("duplicate type ID")
+	ErrTooManyActions    = errors.New("too many actions")
+	ErrTooManyCaps       = errors.New("too many caps")
+	ErrDuplicateAction   = errors.New("duplicate action")
+	ErrDuplicateCap      = errors.New("duplicate cap")
+	ErrInvalidExpiry     = errors.New("invalid expiry")
+	ErrCorruptRecord     = errors.New("corrupt record")
+	ErrUnknownSession    = errors.New("unknown session")
+	ErrExpiryMismatch    = errors.New("expiry does not match session")
+	ErrActionNotAllowed  = errors.New("action not allowed")
+	ErrSpendCapExceeded  = errors.New("spend cap exceeded")
+	ErrSpendOverflow     = errors.New("spend overflow")
+	ErrSessionKeyIsOwner = errors.New("session key is owner")
+)
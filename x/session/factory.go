@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+import (
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+var _ chain.AuthFactory = (*Factory)(nil)
+
+// Factory signs transactions on behalf of a session account with either its
+// owner key or one of its session keys.
+type Factory struct {
+	module *Module
+	owner  ed25519.PublicKey
+	priv   ed25519.PrivateKey
+	expiry int64
+}
+
+// NewOwnerFactory returns a [Factory] that signs with the owner key of a
+// session account (without any restrictions).
+func (m *Module) NewOwnerFactory(priv ed25519.PrivateKey) *Factory {
+	return &Factory{module: m, owner: priv.PublicKey(), priv: priv}
+}
+
+// NewSessionFactory returns a [Factory] that signs with a session key
+// (registered by [owner] with [expiry]).
+func (m *Module) NewSessionFactory(owner ed25519.PublicKey, priv ed25519.PrivateKey, expiry int64) *Factory {
+	return &Factory{module: m, owner: owner, priv: priv, expiry: expiry}
+}
+
+// Address is the address of the session account.
+func (f *Factory) Address() codec.Address {
+	return f.module.Address(f.owner)
+}
+
+func (f *Factory) isOwner() bool {
+	return f.priv.PublicKey() == f.owner
+}
+
+func (f *Factory) Sign(msg []byte, _ []chain.Action) (chain.Auth, error) {
+	s := &Session{
+		Owner:     f.owner,
+		Signer:    f.priv.PublicKey(),
+		Signature: ed25519.Sign(msg, f.priv),
+		module:    f.module,
+	}
+	if !f.isOwner() {
+		s.Expiry = f.expiry
+	}
+	return s, nil
+}
+
+func (f *Factory) MaxUnits() (uint64, uint64, []uint16) {
+	return f.module.MaxUnits(f.isOwner())
+}
@@ -0,0 +1,9 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+var (
+	OutputInvalidActor   = []byte("actor is not a session account")
+	OutputSessionMissing = []byte("session is missing")
+)
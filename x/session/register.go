@@ -0,0 +1,124 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Register)(nil)
+
+// Register allows [SessionKey] to sign transactions on behalf of the actor
+// (which must be a session account) with [Permissions].
+//
+// If [SessionKey] is already registered, its [Permissions] are replaced (and
+// the amount it has spent is reset). [Register] can only be authorized by the
+// owner key of the session account.
+type Register struct {
+	SessionKey  ed25519.PublicKey `json:"sessionKey"`
+	Permissions *Permissions      `json:"permissions"`
+
+	module *Module
+}
+
+// NewRegister returns a [Register] action for [module].
+func (m *Module) NewRegister(sessionKey ed25519.PublicKey, permissions *Permissions) *Register {
+	return &Register{SessionKey: sessionKey, Permissions: permissions, module: m}
+}
+
+func (r *Register) GetTypeID() uint8 {
+	return r.module.config.RegisterID
+}
+
+func (r *Register) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(r.module.PermissionsKey(auth.Actor(), r.SessionKey)): state.All,
+	}
+}
+
+func (*Register) StateKeysMaxChunks() []uint16 {
+	return []uint16{PermissionsChunks}
+}
+
+func (*Register) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *Register) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	actor := auth.Actor()
+	if actor[0] != r.module.config.AuthID {
+		return false, BaseComputeUnits, OutputInvalidActor, nil, nil
+	}
+	if r.module.Address(r.SessionKey) == actor {
+		return false, BaseComputeUnits, utils.ErrBytes(ErrSessionKeyIsOwner), nil, nil
+	}
+	if r.Permissions.Expiry <= timestamp {
+		return false, BaseComputeUnits, utils.ErrBytes(fmt.Errorf("%w: %d <= %d", ErrInvalidExpiry, r.Permissions.Expiry, timestamp)), nil, nil
+	}
+	if err := r.Permissions.verify(); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	permissions := &Permissions{
+		Expiry:  r.Permissions.Expiry,
+		Actions: r.Permissions.Actions,
+		Caps:    make([]Cap, len(r.Permissions.Caps)),
+	}
+	for i, c := range r.Permissions.Caps {
+		permissions.Caps[i] = Cap{Asset: c.Asset, Amount: c.Amount}
+	}
+	if err := r.module.setPermissions(ctx, mu, actor, r.SessionKey, permissions); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, BaseComputeUnits, nil, nil, nil
+}
+
+func (*Register) MaxComputeUnits(chain.Rules) uint64 {
+	return BaseComputeUnits
+}
+
+func (r *Register) Size() int {
+	return ed25519.PublicKeyLen + r.Permissions.size(false)
+}
+
+func (r *Register) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(r.SessionKey[:])
+	r.Permissions.marshal(p, false)
+}
+
+// UnmarshalRegister should be registered in the [chain.ActionRegistry] of a VM
+// with [RegisterID].
+func (m *Module) UnmarshalRegister(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	r := Register{module: m}
+	sessionKey := r.SessionKey[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &sessionKey)
+	permissions, err := unmarshalPermissions(p, false)
+	if err != nil {
+		return nil, err
+	}
+	r.Permissions = permissions
+	return &r, nil
+}
+
+func (*Register) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Revoke)(nil)
+
+// Revoke removes [SessionKey] from the actor (which must be a session
+// account). Any pending transaction signed by [SessionKey] will fail
+// verification.
+//
+// [Revoke] can only be authorized by the owner key of the session account.
+type Revoke struct {
+	SessionKey ed25519.PublicKey `json:"sessionKey"`
+
+	module *Module
+}
+
+// NewRevoke returns a [Revoke] action for [module].
+func (m *Module) NewRevoke(sessionKey ed25519.PublicKey) *Revoke {
+	return &Revoke{SessionKey: sessionKey, module: m}
+}
+
+func (r *Revoke) GetTypeID() uint8 {
+	return r.module.config.RevokeID
+}
+
+func (r *Revoke) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(r.module.PermissionsKey(auth.Actor(), r.SessionKey)): state.All,
+	}
+}
+
+func (*Revoke) StateKeysMaxChunks() []uint16 {
+	return []uint16{PermissionsChunks}
+}
+
+func (*Revoke) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *Revoke) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	actor := auth.Actor()
+	if actor[0] != r.module.config.AuthID {
+		return false, BaseComputeUnits, OutputInvalidActor, nil, nil
+	}
+	permissions, err := r.module.getPermissions(ctx, mu, actor, r.SessionKey)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if permissions == nil {
+		return false, BaseComputeUnits, OutputSessionMissing, nil, nil
+	}
+	if err := r.module.deletePermissions(ctx, mu, actor, r.SessionKey); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, BaseComputeUnits, nil, nil, nil
+}
+
+func (*Revoke) MaxComputeUnits(chain.Rules) uint64 {
+	return BaseComputeUnits
+}
+
+func (*Revoke) Size() int {
+	return ed25519.PublicKeyLen
+}
+
+func (r *Revoke) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(r.SessionKey[:])
+}
+
+// UnmarshalRevoke should be registered in the [chain.ActionRegistry] of a VM
+// with [RevokeID].
+func (m *Module) UnmarshalRevoke(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	r := Revoke{module: m}
+	sessionKey := r.SessionKey[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &sessionKey)
+	return &r, p.Err()
+}
+
+func (*Revoke) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,408 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package session provides accounts controlled by an ed25519 owner key that
+// can delegate scoped permissions to short-lived session keys.
+//
+// The owner key registers a session key (with [Register]) that is only valid
+// until an expiry, can only authorize a set of action type IDs, and can only
+// spend up to a cap of each asset (including fees) until it expires. Session keys can be
+// revoked by the owner key at any time (with [Revoke]). This allows frontends
+// to sign many transactions on behalf of an account without prompting the
+// owner every time.
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.RecordingAuth = (*Session)(nil)
+
+const (
+	BaseComputeUnits    = 1
+	ED25519ComputeUnits = 5
+	// SessionComputeUnits are charged in addition to [ED25519ComputeUnits]
+	// when a session key signs (to check its [Permissions]).
+	SessionComputeUnits = 5
+
+	// MaxActions is the maximum number of action type IDs that a session key
+	// can be allowed to authorize.
+	MaxActions = 32
+	// MaxCaps is the maximum number of assets that a session key can be
+	// allowed to spend.
+	MaxCaps = 8
+
+	// SessionSize is the size of a [Session] signed by a session key (a
+	// [Session] signed by the owner key doesn't include [Session.Signer] or
+	// [Session.Expiry]).
+	SessionSize = consts.BoolLen + ed25519.PublicKeyLen*2 + consts.Int64Len + ed25519.SignatureLen
+)
+
+// BalanceHandler is implemented by each VM that registers the [Module] to pay
+// fees from the balance of a session account and to determine how much of
+// each asset an action spends.
+type BalanceHandler interface {
+	// StateKeys are the keys touched by [CanDeduct], [Deduct], and [Refund].
+	StateKeys(addr codec.Address) []string
+	// StateKeysMaxChunks are the max chunks of each key in [StateKeys].
+	StateKeysMaxChunks() []uint16
+
+	CanDeduct(ctx context.Context, addr codec.Address, im state.Immutable, amount uint64) error
+	Deduct(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+	Refund(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+
+	// Spend returns the amount of each asset that [action] could remove from
+	// the balance of its actor. This is counted against the [Cap]s of a
+	// session key, so it must never underestimate what [action] spends.
+	Spend(action chain.Action) (map[ids.ID]uint64, error)
+}
+
+// Config is the configuration of a [Module].
+type Config struct {
+	// AuthID is the type ID of [Session] (must be unique in the
+	// [chain.AuthRegistry] of the VM).
+	AuthID uint8
+
+	// Type IDs of the actions provided by the [Module] (must be unique in the
+	// [chain.ActionRegistry] of the VM)
+	RegisterID uint8
+	RevokeID   uint8
+
+	// Prefix of all keys used by the [Module] (must not be a prefix of any other
+	// key used by the VM).
+	Prefix []byte
+
+	// FeeAsset is the asset that [BalanceHandler] pays fees with. Fees paid by
+	// a session key are counted against its [Cap] of [FeeAsset].
+	FeeAsset ids.ID
+}
+
+// Module binds [Session] and its actions to the [Config] and [BalanceHandler]
+// used by a specific VM.
+type Module struct {
+	config  Config
+	handler BalanceHandler
+}
+
+func NewModule(config Config, handler BalanceHandler) (*Module, error) {
+	if config.RegisterID == config.RevokeID {
+		return nil, fmt.Errorf("%w: %d", ErrDuplicateTypeID, config.RegisterID)
+	}
+	return &Module{config, handler}, nil
+}
+
+func (m *Module) AuthID() uint8     { return m.config.AuthID }
+func (m *Module) RegisterID() uint8 { return m.config.RegisterID }
+func (m *Module) RevokeID() uint8   { return m.config.RevokeID }
+
+// Address returns the address of the session account controlled by [owner].
+func (m *Module) Address(owner ed25519.PublicKey) codec.Address {
+	return codec.CreateAddress(m.config.AuthID, utils.ToID(owner[:]))
+}
+
+// Session is a [chain.Auth] signed by the owner key of a session account or by
+// one of its session keys.
+//
+// Transactions signed by the owner key are not restricted. Transactions
+// signed by a session key must be executed before [Expiry] (which must match
+// the expiry of the session key in state) and can only include actions that
+// are allowed by the [Permissions] of the session key.
+//
+// The fee paid by a session key (in [Config.FeeAsset]) and the amount spent by
+// the actions it authorizes are added to the [Cap.Spent] of the session key
+// (even if the actions fail).
+type Session struct {
+	Owner     ed25519.PublicKey `json:"owner"`
+	Signer    ed25519.PublicKey `json:"signer"`
+	Expiry    int64             `json:"expiry"`
+	Signature ed25519.Signature `json:"signature"`
+
+	module *Module
+	addr   codec.Address
+
+	// spend is the amount of each asset spent by the actions authorized by
+	// the last call to [Verify] (which is always called before [CanDeduct],
+	// [Deduct], and [RecordActions] when a transaction is executed).
+	spend map[ids.ID]uint64
+}
+
+func (s *Session) address() codec.Address {
+	if s.addr == codec.EmptyAddress {
+		s.addr = s.module.Address(s.Owner)
+	}
+	return s.addr
+}
+
+// IsOwner returns true if [Session] was signed by the owner key.
+func (s *Session) IsOwner() bool {
+	return s.Signer == s.Owner
+}
+
+func (s *Session) GetTypeID() uint8 {
+	return s.module.config.AuthID
+}
+
+func (s *Session) MaxComputeUnits(chain.Rules) uint64 {
+	if s.IsOwner() {
+		return BaseComputeUnits + ED25519ComputeUnits
+	}
+	return BaseComputeUnits + ED25519ComputeUnits + SessionComputeUnits
+}
+
+func (s *Session) ValidRange(chain.Rules) (int64, int64) {
+	if s.IsOwner() {
+		return -1, -1
+	}
+	// Session keys can't be used after they expire
+	return -1, s.Expiry
+}
+
+func (s *Session) StateKeys() []string {
+	keys := s.module.handler.StateKeys(s.address())
+	if !s.IsOwner() {
+		keys = append(keys, string(s.module.PermissionsKey(s.address(), s.Signer)))
+	}
+	return keys
+}
+
+func (s *Session) AsyncVerify(msg []byte) error {
+	if !ed25519.Verify(msg, s.Signer, s.Signature) {
+		return crypto.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *Session) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	im state.Immutable,
+	actions []chain.Action,
+) (uint64, error) {
+	if s.IsOwner() {
+		return s.MaxComputeUnits(r), nil
+	}
+	permissions, err := s.permissions(ctx, im)
+	if err != nil {
+		return 0, err
+	}
+	spend, err := s.module.authorize(permissions, actions)
+	if err != nil {
+		return 0, err
+	}
+	s.spend = spend
+	return s.MaxComputeUnits(r), nil
+}
+
+// permissions returns the [Permissions] of the session key that signed
+// [Session].
+func (s *Session) permissions(ctx context.Context, im state.Immutable) (*Permissions, error) {
+	permissions, err := s.module.getPermissions(ctx, im, s.address(), s.Signer)
+	if err != nil {
+		return nil, err
+	}
+	if permissions == nil {
+		return nil, ErrUnknownSession
+	}
+	if permissions.Expiry != s.Expiry {
+		// The session key may have been re-registered with a different expiry
+		return nil, fmt.Errorf("%w: expected=%d found=%d", ErrExpiryMismatch, permissions.Expiry, s.Expiry)
+	}
+	return permissions, nil
+}
+
+// charge adds the fee [amount] and the amount spent by the actions authorized
+// by [Verify] to [permissions].
+func (s *Session) charge(permissions *Permissions, amount uint64) error {
+	if err := permissions.spend(s.module.config.FeeAsset, amount); err != nil {
+		return err
+	}
+	for asset, spent := range s.spend {
+		if err := permissions.spend(asset, spent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordActions adds the amount spent by the actions authorized by [Verify]
+// to the [Cap]s of the session key.
+func (s *Session) RecordActions(ctx context.Context, mu state.Mutable) error {
+	if s.IsOwner() || len(s.spend) == 0 {
+		return nil
+	}
+	permissions, err := s.permissions(ctx, mu)
+	if err != nil {
+		return err
+	}
+	if err := s.charge(permissions, 0); err != nil {
+		return err
+	}
+	return s.module.setPermissions(ctx, mu, s.address(), s.Signer, permissions)
+}
+
+func (s *Session) Actor() codec.Address {
+	return s.address()
+}
+
+func (s *Session) Sponsor() codec.Address {
+	return s.address()
+}
+
+func (s *Session) Size() int {
+	if s.IsOwner() {
+		return consts.BoolLen + ed25519.PublicKeyLen + ed25519.SignatureLen
+	}
+	return SessionSize
+}
+
+func (s *Session) Marshal(p *codec.Packer) {
+	p.PackBool(s.IsOwner())
+	p.PackFixedBytes(s.Owner[:])
+	if !s.IsOwner() {
+		p.PackFixedBytes(s.Signer[:])
+		p.PackInt64(s.Expiry)
+	}
+	p.PackFixedBytes(s.Signature[:])
+}
+
+func (s *Session) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	if err := s.module.handler.CanDeduct(ctx, s.address(), im, amount); err != nil {
+		return err
+	}
+	if s.IsOwner() {
+		return nil
+	}
+	permissions, err := s.permissions(ctx, im)
+	if err != nil {
+		return err
+	}
+	// The fee and the actions must fit under the same [Cap]s (this is
+	// conservative if the session key only sponsors the transaction).
+	return s.charge(permissions, amount)
+}
+
+func (s *Session) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	if err := s.module.handler.Deduct(ctx, s.address(), mu, amount); err != nil {
+		return err
+	}
+	if s.IsOwner() {
+		return nil
+	}
+	permissions, err := s.permissions(ctx, mu)
+	if err != nil {
+		return err
+	}
+	if err := permissions.spend(s.module.config.FeeAsset, amount); err != nil {
+		return err
+	}
+	return s.module.setPermissions(ctx, mu, s.address(), s.Signer, permissions)
+}
+
+func (s *Session) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	if err := s.module.handler.Refund(ctx, s.address(), mu, amount); err != nil {
+		return err
+	}
+	if s.IsOwner() {
+		return nil
+	}
+	permissions, err := s.permissions(ctx, mu)
+	if err != nil {
+		return err
+	}
+	permissions.refund(s.module.config.FeeAsset, amount)
+	return s.module.setPermissions(ctx, mu, s.address(), s.Signer, permissions)
+}
+
+// Unmarshal should be registered in the [chain.AuthRegistry] of a VM with
+// [AuthID].
+func (m *Module) Unmarshal(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	s := Session{module: m}
+	isOwner := p.UnpackBool()
+	owner := s.Owner[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &owner)
+	if isOwner {
+		s.Signer = s.Owner
+	} else {
+		signer := s.Signer[:]
+		p.UnpackFixedBytes(ed25519.PublicKeyLen, &signer)
+		s.Expiry = p.UnpackInt64(true)
+		if s.Signer == s.Owner {
+			// There must be a single encoding of each [Session]
+			return nil, ErrSessionKeyIsOwner
+		}
+	}
+	signature := s.Signature[:]
+	p.UnpackFixedBytes(ed25519.SignatureLen, &signature)
+	return &s, p.Err()
+}
+
+// authorize returns the amount of each asset spent by [actions] or an error
+// if [actions] are not allowed by [permissions] (or would exceed its [Cap]s).
+func (m *Module) authorize(permissions *Permissions, actions []chain.Action) (map[ids.ID]uint64, error) {
+	spent := map[ids.ID]uint64{}
+	for _, action := range actions {
+		typeID := action.GetTypeID()
+		// Session keys can never modify session keys (even if allowed to)
+		if typeID == m.config.RegisterID || typeID == m.config.RevokeID || !permissions.Allows(typeID) {
+			return nil, fmt.Errorf("%w: %d", ErrActionNotAllowed, typeID)
+		}
+		spend, err := m.handler.Spend(action)
+		if err != nil {
+			return nil, err
+		}
+		for asset, amount := range spend {
+			total := spent[asset] + amount
+			if total < amount {
+				return nil, ErrSpendOverflow
+			}
+			spent[asset] = total
+		}
+	}
+	// Check [spent] against a copy of [permissions] (so [Cap.Spent] is not
+	// modified)
+	remaining := &Permissions{Caps: slices.Clone(permissions.Caps)}
+	for asset, amount := range spent {
+		if err := remaining.spend(asset, amount); err != nil {
+			return nil, err
+		}
+	}
+	return spent, nil
+}
+
+// MaxUnits returns the max units a [Session] could use (signed by the owner
+// key if [owner] is true).
+func (m *Module) MaxUnits(owner bool) (uint64, uint64, []uint16) {
+	chunks := m.handler.StateKeysMaxChunks()
+	if owner {
+		return consts.BoolLen + ed25519.PublicKeyLen + ed25519.SignatureLen, BaseComputeUnits + ED25519ComputeUnits, chunks
+	}
+	sessionChunks := make([]uint16, 0, len(chunks)+1)
+	sessionChunks = append(sessionChunks, chunks...)
+	sessionChunks = append(sessionChunks, PermissionsChunks)
+	return SessionSize, BaseComputeUnits + ED25519ComputeUnits + SessionComputeUnits, sessionChunks
+}
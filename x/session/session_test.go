@@ -0,0 +1,422 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	testAuthID     uint8 = 3
+	testRegisterID uint8 = 0
+	testRevokeID   uint8 = 1
+	testSpendID    uint8 = 2
+	testOtherID    uint8 = 4
+)
+
+var (
+	_ state.Mutable  = (*testDB)(nil)
+	_ BalanceHandler = (*testHandler)(nil)
+
+	testFeeAsset = ids.ID{1}
+)
+
+type testDB struct {
+	storage map[string][]byte
+}
+
+func newTestDB() *testDB {
+	return &testDB{storage: map[string][]byte{}}
+}
+
+func (db *testDB) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := db.storage[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (db *testDB) Insert(_ context.Context, key []byte, value []byte) error {
+	db.storage[string(key)] = value
+	return nil
+}
+
+func (db *testDB) Remove(_ context.Context, key []byte) error {
+	delete(db.storage, string(key))
+	return nil
+}
+
+type testHandler struct{}
+
+func (*testHandler) StateKeys(addr codec.Address) []string {
+	return []string{string(addr[:])}
+}
+
+func (*testHandler) StateKeysMaxChunks() []uint16 {
+	return []uint16{1}
+}
+
+func (*testHandler) CanDeduct(context.Context, codec.Address, state.Immutable, uint64) error {
+	return nil
+}
+
+func (*testHandler) Deduct(context.Context, codec.Address, state.Mutable, uint64) error {
+	return nil
+}
+
+func (*testHandler) Refund(context.Context, codec.Address, state.Mutable, uint64) error {
+	return nil
+}
+
+func (*testHandler) Spend(action chain.Action) (map[ids.ID]uint64, error) {
+	a, ok := action.(*testAction)
+	if !ok {
+		return nil, nil
+	}
+	return map[ids.ID]uint64{a.asset: a.amount}, nil
+}
+
+// testAction spends [amount] of [asset]
+type testAction struct {
+	chain.Action
+
+	typeID uint8
+	asset  ids.ID
+	amount uint64
+}
+
+func (a *testAction) GetTypeID() uint8 {
+	return a.typeID
+}
+
+type testAuth struct {
+	chain.Auth
+
+	actor codec.Address
+}
+
+func (a *testAuth) Actor() codec.Address {
+	return a.actor
+}
+
+func newTestModule(t *testing.T) *Module {
+	m, err := NewModule(Config{
+		AuthID:     testAuthID,
+		RegisterID: testRegisterID,
+		RevokeID:   testRevokeID,
+		Prefix:     []byte{0xf},
+		FeeAsset:   testFeeAsset,
+	}, &testHandler{})
+	require.NoError(t, err)
+	return m
+}
+
+func generateKey(t *testing.T) ed25519.PrivateKey {
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	return priv
+}
+
+func execute(t *testing.T, mu state.Mutable, action chain.Action, timestamp int64, actor codec.Address) []byte {
+	success, _, output, _, err := action.Execute(
+		context.Background(),
+		nil,
+		mu,
+		timestamp,
+		&testAuth{actor: actor},
+		ids.Empty,
+		false,
+	)
+	require.NoError(t, err)
+	if success {
+		return nil
+	}
+	require.NotNil(t, output)
+	return output
+}
+
+func sign(t *testing.T, f *Factory, msg []byte) *Session {
+	auth, err := f.Sign(msg, nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.AsyncVerify(msg))
+	return auth.(*Session)
+}
+
+func TestNewModule(t *testing.T) {
+	_, err := NewModule(Config{RegisterID: 1, RevokeID: 1}, &testHandler{})
+	require.ErrorIs(t, err, ErrDuplicateTypeID)
+}
+
+func TestMarshal(t *testing.T) {
+	require := require.New(t)
+	m := newTestModule(t)
+	owner := generateKey(t)
+	sessionKey := generateKey(t)
+	msg := []byte("digest")
+
+	for _, f := range []*Factory{
+		m.NewOwnerFactory(owner),
+		m.NewSessionFactory(owner.PublicKey(), sessionKey, 100),
+	} {
+		auth := sign(t, f, msg)
+		bandwidth, _, _ := f.MaxUnits()
+		require.Equal(int(bandwidth), auth.Size())
+		p := codec.NewWriter(auth.Size(), consts.NetworkSizeLimit)
+		auth.Marshal(p)
+		require.NoError(p.Err())
+
+		rauth, err := m.Unmarshal(codec.NewReader(p.Bytes(), len(p.Bytes())), nil)
+		require.NoError(err)
+		require.Equal(auth.Owner, rauth.(*Session).Owner)
+		require.Equal(auth.Signer, rauth.(*Session).Signer)
+		require.Equal(auth.Expiry, rauth.(*Session).Expiry)
+		require.Equal(f.Address(), rauth.Actor())
+		require.Equal(testAuthID, rauth.Actor()[0])
+		require.NoError(rauth.AsyncVerify(msg))
+	}
+
+	// A session key can't be the owner key
+	auth := &Session{Owner: owner.PublicKey(), Signer: sessionKey.PublicKey(), module: m}
+	p := codec.NewWriter(auth.Size(), consts.NetworkSizeLimit)
+	auth.Marshal(p)
+	b := p.Bytes()
+	copy(b[consts.BoolLen+ed25519.PublicKeyLen:], auth.Owner[:])
+	_, err := m.Unmarshal(codec.NewReader(b, len(b)), nil)
+	require.ErrorIs(err, ErrSessionKeyIsOwner)
+
+	register := m.NewRegister(sessionKey.PublicKey(), &Permissions{
+		Expiry:  100,
+		Actions: []uint8{testSpendID},
+		Caps:    []Cap{{Asset: ids.GenerateTestID(), Amount: 10}},
+	})
+	p = codec.NewWriter(register.Size(), consts.NetworkSizeLimit)
+	register.Marshal(p)
+	require.NoError(p.Err())
+	action, err := m.UnmarshalRegister(codec.NewReader(p.Bytes(), len(p.Bytes())), nil)
+	require.NoError(err)
+	require.Equal(register.SessionKey, action.(*Register).SessionKey)
+	require.Equal(register.Permissions, action.(*Register).Permissions)
+	require.Equal(testRegisterID, action.GetTypeID())
+}
+
+func TestRegister(t *testing.T) {
+	require := require.New(t)
+	m := newTestModule(t)
+	db := newTestDB()
+	owner := generateKey(t)
+	addr := m.Address(owner.PublicKey())
+	sessionKey := generateKey(t).PublicKey()
+	asset := ids.GenerateTestID()
+	permissions := &Permissions{
+		Expiry:  100,
+		Actions: []uint8{testSpendID},
+		Caps:    []Cap{{Asset: asset, Amount: 1}},
+	}
+
+	// Only session accounts can register session keys
+	other := codec.CreateAddress(testAuthID+1, ids.GenerateTestID())
+	require.Equal(OutputInvalidActor, execute(t, db, m.NewRegister(sessionKey, permissions), 10, other))
+
+	// The owner key can't be a session key
+	require.NotNil(execute(t, db, m.NewRegister(owner.PublicKey(), permissions), 10, addr))
+
+	// Session keys must expire in the future
+	require.NotNil(execute(t, db, m.NewRegister(sessionKey, permissions), 100, addr))
+
+	// Permissions must be unique
+	require.NotNil(execute(t, db, m.NewRegister(sessionKey, &Permissions{
+		Expiry:  100,
+		Actions: []uint8{testSpendID, testSpendID},
+	}), 10, addr))
+	require.NotNil(execute(t, db, m.NewRegister(sessionKey, &Permissions{
+		Expiry: 100,
+		Caps:   []Cap{{Asset: asset, Amount: 1}, {Asset: asset, Amount: 2}},
+	}), 10, addr))
+	require.Empty(db.storage)
+
+	require.Nil(execute(t, db, m.NewRegister(sessionKey, permissions), 10, addr))
+	stored, err := m.getPermissions(context.Background(), db, addr, sessionKey)
+	require.NoError(err)
+	require.Equal(permissions, stored)
+
+	// Re-registering replaces permissions
+	permissions = &Permissions{
+		Expiry:  200,
+		Actions: []uint8{testOtherID},
+		Caps:    []Cap{{Asset: asset, Amount: 2}},
+	}
+	require.Nil(execute(t, db, m.NewRegister(sessionKey, permissions), 10, addr))
+	stored, err = m.getPermissions(context.Background(), db, addr, sessionKey)
+	require.NoError(err)
+	require.Equal(permissions, stored)
+
+	// Revoke
+	require.Equal(OutputInvalidActor, execute(t, db, m.NewRevoke(sessionKey), 10, other))
+	require.Nil(execute(t, db, m.NewRevoke(sessionKey), 10, addr))
+	require.Empty(db.storage)
+	require.Equal(OutputSessionMissing, execute(t, db, m.NewRevoke(sessionKey), 10, addr))
+}
+
+func TestVerify(t *testing.T) {
+	require := require.New(t)
+	m := newTestModule(t)
+	db := newTestDB()
+	ctx := context.Background()
+	owner := generateKey(t)
+	addr := m.Address(owner.PublicKey())
+	sessionKey := generateKey(t)
+	asset := ids.GenerateTestID()
+	msg := []byte("digest")
+
+	spend := func(amount uint64) *testAction {
+		return &testAction{typeID: testSpendID, asset: asset, amount: amount}
+	}
+
+	// Session key is not registered
+	auth := sign(t, m.NewSessionFactory(owner.PublicKey(), sessionKey, 100), msg)
+	_, err := auth.Verify(ctx, nil, db, []chain.Action{spend(1)})
+	require.ErrorIs(err, ErrUnknownSession)
+
+	// Owner is not restricted
+	ownerAuth := sign(t, m.NewOwnerFactory(owner), msg)
+	_, end := ownerAuth.ValidRange(nil)
+	require.Equal(int64(-1), end)
+	_, err = ownerAuth.Verify(ctx, nil, db, []chain.Action{m.NewRegister(sessionKey.PublicKey(), &Permissions{})})
+	require.NoError(err)
+
+	require.Nil(execute(t, db, m.NewRegister(sessionKey.PublicKey(), &Permissions{
+		Expiry:  100,
+		Actions: []uint8{testSpendID, testRevokeID},
+		Caps:    []Cap{{Asset: asset, Amount: 10}},
+	}), 10, addr))
+	require.Contains(auth.StateKeys(), string(m.PermissionsKey(addr, sessionKey.PublicKey())))
+	_, end = auth.ValidRange(nil)
+	require.Equal(int64(100), end)
+
+	units, err := auth.Verify(ctx, nil, db, []chain.Action{spend(4), spend(6)})
+	require.NoError(err)
+	require.Equal(auth.MaxComputeUnits(nil), units)
+
+	// Caps apply to the sum of all actions
+	_, err = auth.Verify(ctx, nil, db, []chain.Action{spend(4), spend(7)})
+	require.ErrorIs(err, ErrSpendCapExceeded)
+
+	// Assets without a cap can't be spent
+	_, err = auth.Verify(ctx, nil, db, []chain.Action{&testAction{typeID: testSpendID, asset: ids.GenerateTestID(), amount: 1}})
+	require.ErrorIs(err, ErrSpendCapExceeded)
+
+	// Only allowed actions can be authorized
+	_, err = auth.Verify(ctx, nil, db, []chain.Action{&testAction{typeID: testOtherID}})
+	require.ErrorIs(err, ErrActionNotAllowed)
+
+	// Session keys can never modify session keys
+	_, err = auth.Verify(ctx, nil, db, []chain.Action{m.NewRevoke(sessionKey.PublicKey())})
+	require.ErrorIs(err, ErrActionNotAllowed)
+
+	// Expiry must match the registered session key
+	stale := sign(t, m.NewSessionFactory(owner.PublicKey(), sessionKey, 200), msg)
+	_, err = stale.Verify(ctx, nil, db, []chain.Action{spend(1)})
+	require.ErrorIs(err, ErrExpiryMismatch)
+
+	// Signatures must be valid
+	require.ErrorIs(auth.AsyncVerify([]byte("other")), crypto.ErrInvalidSignature)
+
+	// Revoked session keys can't be used
+	require.Nil(execute(t, db, m.NewRevoke(sessionKey.PublicKey()), 10, addr))
+	_, err = auth.Verify(ctx, nil, db, []chain.Action{spend(1)})
+	require.ErrorIs(err, ErrUnknownSession)
+}
+
+func TestSpend(t *testing.T) {
+	require := require.New(t)
+	m := newTestModule(t)
+	db := newTestDB()
+	ctx := context.Background()
+	owner := generateKey(t)
+	addr := m.Address(owner.PublicKey())
+	sessionKey := generateKey(t)
+	asset := ids.GenerateTestID()
+	msg := []byte("digest")
+
+	spend := func(asset ids.ID, amount uint64) []chain.Action {
+		return []chain.Action{&testAction{typeID: testSpendID, asset: asset, amount: amount}}
+	}
+	spent := func() map[ids.ID]uint64 {
+		stored, err := m.getPermissions(ctx, db, addr, sessionKey.PublicKey())
+		require.NoError(err)
+		amounts := map[ids.ID]uint64{}
+		for _, c := range stored.Caps {
+			amounts[c.Asset] = c.Spent
+		}
+		return amounts
+	}
+	permissions := &Permissions{
+		Expiry:  100,
+		Actions: []uint8{testSpendID},
+		Caps:    []Cap{{Asset: asset, Amount: 10}, {Asset: testFeeAsset, Amount: 5}},
+	}
+	require.Nil(execute(t, db, m.NewRegister(sessionKey.PublicKey(), permissions), 10, addr))
+	auth := sign(t, m.NewSessionFactory(owner.PublicKey(), sessionKey, 100), msg)
+
+	// Fees and actions are recorded when the transaction is executed
+	_, err := auth.Verify(ctx, nil, db, spend(asset, 6))
+	require.NoError(err)
+	require.NoError(auth.CanDeduct(ctx, db, 3))
+	require.Equal(map[ids.ID]uint64{asset: 0, testFeeAsset: 0}, spent())
+	require.NoError(auth.Deduct(ctx, db, 3))
+	require.NoError(auth.RecordActions(ctx, db))
+	require.NoError(auth.Refund(ctx, db, 1))
+	require.Equal(map[ids.ID]uint64{asset: 6, testFeeAsset: 2}, spent())
+
+	// Caps apply to all transactions until the session key expires
+	_, err = auth.Verify(ctx, nil, db, spend(asset, 6))
+	require.ErrorIs(err, ErrSpendCapExceeded)
+	_, err = auth.Verify(ctx, nil, db, spend(asset, 4))
+	require.NoError(err)
+
+	// Fees can't exceed the cap of the fee asset
+	require.ErrorIs(auth.CanDeduct(ctx, db, 4), ErrSpendCapExceeded)
+	require.ErrorIs(auth.Deduct(ctx, db, 4), ErrSpendCapExceeded)
+	require.NoError(auth.CanDeduct(ctx, db, 3))
+
+	// Fees and actions that spend the fee asset share its cap
+	_, err = auth.Verify(ctx, nil, db, spend(testFeeAsset, 2))
+	require.NoError(err)
+	require.ErrorIs(auth.CanDeduct(ctx, db, 2), ErrSpendCapExceeded)
+	require.NoError(auth.CanDeduct(ctx, db, 1))
+
+	// Actions are recorded even if the session key doesn't pay the fee
+	_, err = auth.Verify(ctx, nil, db, spend(asset, 4))
+	require.NoError(err)
+	require.NoError(auth.RecordActions(ctx, db))
+	require.Equal(map[ids.ID]uint64{asset: 10, testFeeAsset: 2}, spent())
+	_, err = auth.Verify(ctx, nil, db, spend(asset, 1))
+	require.ErrorIs(err, ErrSpendCapExceeded)
+
+	// The owner key is not restricted
+	ownerAuth := sign(t, m.NewOwnerFactory(owner), msg)
+	_, err = ownerAuth.Verify(ctx, nil, db, spend(asset, 100))
+	require.NoError(err)
+	require.NoError(ownerAuth.CanDeduct(ctx, db, 100))
+	require.NoError(ownerAuth.Deduct(ctx, db, 100))
+	require.NoError(ownerAuth.RecordActions(ctx, db))
+	require.Equal(map[ids.ID]uint64{asset: 10, testFeeAsset: 2}, spent())
+
+	// Re-registering resets the amount spent
+	require.Nil(execute(t, db, m.NewRegister(sessionKey.PublicKey(), permissions), 10, addr))
+	require.Equal(map[ids.ID]uint64{asset: 0, testFeeAsset: 0}, spent())
+}
@@ -0,0 +1,276 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package session
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// State (under [Config.Prefix])
+// 0x0/ (permissions)
+//   -> [address|sessionKey] => expiry|actions|caps (with spent)
+
+const permissionsPrefix = 0x0
+
+// PermissionsChunks is the max number of chunks used by [Permissions] (with
+// [MaxActions] and [MaxCaps]).
+var PermissionsChunks = func() uint16 {
+	chunks, ok := keys.NumChunks(make([]byte, maxPermissionsSize))
+	if !ok {
+		panic("permissions too large")
+	}
+	return chunks
+}()
+
+const maxPermissionsSize = consts.Int64Len +
+	consts.ByteLen + MaxActions +
+	consts.ByteLen + MaxCaps*(consts.IDLen+consts.Uint64Len*2)
+
+// ReadState reads multiple keys from the latest accepted state (used to serve
+// RPC queries).
+type ReadState func(context.Context, [][]byte) ([][]byte, []error)
+
+// Cap is the max amount of [Asset] that a session key can spend (across all
+// of its transactions) until it expires.
+type Cap struct {
+	Asset  ids.ID `json:"asset"`
+	Amount uint64 `json:"amount"`
+
+	// Spent is the amount of [Asset] the session key has spent so far
+	// (including fees). It is tracked in state and ignored by [Register].
+	Spent uint64 `json:"spent"`
+}
+
+// Permissions are the restrictions placed on a session key by the owner of
+// a session account.
+type Permissions struct {
+	// Expiry is the last timestamp (in ms) at which the session key can be used.
+	Expiry int64 `json:"expiry"`
+
+	// Actions are the type IDs of the actions that the session key can
+	// authorize.
+	Actions []uint8 `json:"actions"`
+
+	// Caps limit the amount of each asset that the session key can spend. Any
+	// asset without a [Cap] can't be spent (fees are paid with
+	// [Config.FeeAsset], so a session key can't be used without a [Cap] of it).
+	Caps []Cap `json:"caps"`
+}
+
+// Allows returns true if the session key can authorize actions with [typeID].
+func (p *Permissions) Allows(typeID uint8) bool {
+	for _, allowed := range p.Actions {
+		if allowed == typeID {
+			return true
+		}
+	}
+	return false
+}
+
+// Cap returns the max amount of [asset] that the session key can spend
+// until it expires (0 if [asset] has no [Cap]).
+func (p *Permissions) Cap(asset ids.ID) uint64 {
+	for _, c := range p.Caps {
+		if c.Asset == asset {
+			return c.Amount
+		}
+	}
+	return 0
+}
+
+// spend adds [amount] to the [Cap.Spent] of [asset]. It returns an error (and
+// doesn't modify [Permissions]) if this would exceed the [Cap] of [asset].
+func (p *Permissions) spend(asset ids.ID, amount uint64) error {
+	if amount == 0 {
+		return nil
+	}
+	for i, c := range p.Caps {
+		if c.Asset != asset {
+			continue
+		}
+		total, err := smath.Add64(c.Spent, amount)
+		if err != nil || total > c.Amount {
+			return fmt.Errorf("%w: asset=%s spent=%d amount=%d cap=%d", ErrSpendCapExceeded, asset, c.Spent, amount, c.Amount)
+		}
+		p.Caps[i].Spent = total
+		return nil
+	}
+	return fmt.Errorf("%w: asset=%s spent=0 amount=%d cap=0", ErrSpendCapExceeded, asset, amount)
+}
+
+// refund subtracts [amount] from the [Cap.Spent] of [asset].
+func (p *Permissions) refund(asset ids.ID, amount uint64) {
+	for i, c := range p.Caps {
+		if c.Asset == asset {
+			p.Caps[i].Spent -= smath.Min(c.Spent, amount)
+			return
+		}
+	}
+}
+
+// size returns the size of [Permissions] (with [Cap.Spent] if [withSpent]
+// is set).
+func (p *Permissions) size(withSpent bool) int {
+	capSize := consts.IDLen + consts.Uint64Len
+	if withSpent {
+		capSize += consts.Uint64Len
+	}
+	return consts.Int64Len +
+		consts.ByteLen + len(p.Actions) +
+		consts.ByteLen + len(p.Caps)*capSize
+}
+
+// marshal packs [Permissions]. [Cap.Spent] is only stored in state (it is
+// not included in [Register]).
+func (p *Permissions) marshal(pk *codec.Packer, withSpent bool) {
+	pk.PackInt64(p.Expiry)
+	pk.PackByte(uint8(len(p.Actions)))
+	pk.PackFixedBytes(p.Actions)
+	pk.PackByte(uint8(len(p.Caps)))
+	for _, c := range p.Caps {
+		pk.PackID(c.Asset)
+		pk.PackUint64(c.Amount)
+		if withSpent {
+			pk.PackUint64(c.Spent)
+		}
+	}
+}
+
+func unmarshalPermissions(pk *codec.Packer, withSpent bool) (*Permissions, error) {
+	var p Permissions
+	p.Expiry = pk.UnpackInt64(true)
+	numActions := pk.UnpackByte()
+	if numActions > MaxActions {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyActions, numActions, MaxActions)
+	}
+	p.Actions = make([]uint8, numActions)
+	pk.UnpackFixedBytes(int(numActions), &p.Actions)
+	numCaps := pk.UnpackByte()
+	if numCaps > MaxCaps {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyCaps, numCaps, MaxCaps)
+	}
+	p.Caps = make([]Cap, numCaps)
+	for i := range p.Caps {
+		pk.UnpackID(false, &p.Caps[i].Asset)
+		p.Caps[i].Amount = pk.UnpackUint64(false)
+		if withSpent {
+			p.Caps[i].Spent = pk.UnpackUint64(false)
+		}
+	}
+	return &p, pk.Err()
+}
+
+// verify ensures that [Permissions] are well-formed (there is a single
+// encoding of each set of [Permissions]).
+func (p *Permissions) verify() error {
+	if len(p.Actions) > MaxActions {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyActions, len(p.Actions), MaxActions)
+	}
+	if len(p.Caps) > MaxCaps {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyCaps, len(p.Caps), MaxCaps)
+	}
+	for i, typeID := range p.Actions {
+		for _, other := range p.Actions[i+1:] {
+			if typeID == other {
+				return fmt.Errorf("%w: %d", ErrDuplicateAction, typeID)
+			}
+		}
+	}
+	for i, c := range p.Caps {
+		for _, other := range p.Caps[i+1:] {
+			if c.Asset == other.Asset {
+				return fmt.Errorf("%w: %s", ErrDuplicateCap, c.Asset)
+			}
+		}
+	}
+	return nil
+}
+
+// [prefix] + [permissionsPrefix] + [address] + [sessionKey]
+func (m *Module) PermissionsKey(addr codec.Address, sessionKey ed25519.PublicKey) []byte {
+	k := make([]byte, len(m.config.Prefix)+1+codec.AddressLen+ed25519.PublicKeyLen+consts.Uint16Len)
+	copy(k, m.config.Prefix)
+	offset := len(m.config.Prefix)
+	k[offset] = permissionsPrefix
+	offset++
+	copy(k[offset:], addr[:])
+	offset += codec.AddressLen
+	copy(k[offset:], sessionKey[:])
+	offset += ed25519.PublicKeyLen
+	binary.BigEndian.PutUint16(k[offset:], PermissionsChunks)
+	return k
+}
+
+func (m *Module) getPermissions(
+	ctx context.Context,
+	im state.Immutable,
+	addr codec.Address,
+	sessionKey ed25519.PublicKey,
+) (*Permissions, error) {
+	v, err := im.GetValue(ctx, m.PermissionsKey(addr, sessionKey))
+	return innerGetPermissions(v, err)
+}
+
+// GetPermissionsFromState is used to serve RPC queries (nil if [sessionKey] is
+// not registered for [addr]).
+func (m *Module) GetPermissionsFromState(
+	ctx context.Context,
+	f ReadState,
+	addr codec.Address,
+	sessionKey ed25519.PublicKey,
+) (*Permissions, error) {
+	values, errs := f(ctx, [][]byte{m.PermissionsKey(addr, sessionKey)})
+	return innerGetPermissions(values[0], errs[0])
+}
+
+func innerGetPermissions(v []byte, err error) (*Permissions, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := unmarshalPermissions(codec.NewReader(v, len(v)), true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptRecord, err)
+	}
+	return permissions, nil
+}
+
+func (m *Module) setPermissions(
+	ctx context.Context,
+	mu state.Mutable,
+	addr codec.Address,
+	sessionKey ed25519.PublicKey,
+	permissions *Permissions,
+) error {
+	p := codec.NewWriter(permissions.size(true), consts.MaxInt)
+	permissions.marshal(p, true)
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, m.PermissionsKey(addr, sessionKey), p.Bytes())
+}
+
+func (m *Module) deletePermissions(
+	ctx context.Context,
+	mu state.Mutable,
+	addr codec.Address,
+	sessionKey ed25519.PublicKey,
+) error {
+	return mu.Remove(ctx, m.PermissionsKey(addr, sessionKey))
+}
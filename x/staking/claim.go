@@ -0,0 +1,129 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Claim)(nil)
+
+// Claim pays the rewards earned by the actor on [NodeID] and its
+// [Withdrawal] (if unlocked).
+type Claim struct {
+	NodeID ids.NodeID `json:"nodeID"`
+
+	module *Module
+}
+
+// NewClaim returns a [Claim] action for [module].
+func (m *Module) NewClaim(nodeID ids.NodeID) *Claim {
+	return &Claim{NodeID: nodeID, module: m}
+}
+
+func (c *Claim) GetTypeID() uint8 {
+	return c.module.config.ClaimID
+}
+
+func (c *Claim) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return c.module.balanceKeys(state.Keys{
+		string(c.module.PositionKey(c.NodeID, auth.Actor())): state.All,
+		string(c.module.TotalsKey()):                         state.Read,
+		string(c.module.WithdrawalKey(auth.Actor())):         state.All,
+	}, auth.Actor())
+}
+
+func (c *Claim) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{PositionChunks, TotalsChunks, WithdrawalChunks}, c.module.handler.StateKeysMaxChunks()...)
+}
+
+func (*Claim) OutputsWarpMessage() bool {
+	return false
+}
+
+func (c *Claim) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	actor := auth.Actor()
+	var amount uint64
+	position, err := c.module.getPosition(ctx, mu, c.NodeID, actor)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if position != nil {
+		totals, err := c.module.getTotals(ctx, mu)
+		if err != nil {
+			return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if err := settle(position, totals); err != nil {
+			return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		amount = position.Rewards
+		position.Rewards = 0
+		if err := c.module.setPosition(ctx, mu, c.NodeID, actor, position); err != nil {
+			return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+	withdrawal, err := c.module.getWithdrawal(ctx, mu, actor)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if withdrawal != nil && c.module.Epoch(timestamp) >= withdrawal.UnlockEpoch {
+		if amount, err = smath.Add64(amount, withdrawal.Amount); err != nil {
+			return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if err := c.module.setWithdrawal(ctx, mu, actor, &Withdrawal{}); err != nil {
+			return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+	if amount == 0 {
+		return false, BaseComputeUnits, OutputNothingToClaim, nil, nil
+	}
+	if err := c.module.handler.Credit(ctx, actor, mu, amount); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, BaseComputeUnits, nil, nil, nil
+}
+
+func (*Claim) MaxComputeUnits(chain.Rules) uint64 {
+	return BaseComputeUnits
+}
+
+func (*Claim) Size() int {
+	return ids.NodeIDLen
+}
+
+func (c *Claim) Marshal(p *codec.Packer) {
+	packNodeID(p, c.NodeID)
+}
+
+// UnmarshalClaim should be registered in the [chain.ActionRegistry] of a VM
+// with [ClaimID].
+func (m *Module) UnmarshalClaim(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	nodeID, err := unpackNodeID(p)
+	if err != nil {
+		return nil, err
+	}
+	return m.NewClaim(nodeID), nil
+}
+
+func (*Claim) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Delegate)(nil)
+
+// Delegate locks [Amount] on a validator owned by another account. Delegated
+// stake earns the same rewards (per unit) as the stake of the owner.
+type Delegate struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Amount uint64     `json:"amount"`
+
+	module *Module
+}
+
+// NewDelegate returns a [Delegate] action for [module].
+func (m *Module) NewDelegate(nodeID ids.NodeID, amount uint64) *Delegate {
+	return &Delegate{NodeID: nodeID, Amount: amount, module: m}
+}
+
+func (d *Delegate) GetTypeID() uint8 {
+	return d.module.config.DelegateID
+}
+
+func (d *Delegate) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return d.module.balanceKeys(state.Keys{
+		string(d.module.ValidatorKey(d.NodeID)):              state.All,
+		string(d.module.PositionKey(d.NodeID, auth.Actor())): state.All,
+		string(d.module.TotalsKey()):                         state.All,
+	}, auth.Actor())
+}
+
+func (d *Delegate) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{ValidatorChunks, PositionChunks, TotalsChunks}, d.module.handler.StateKeysMaxChunks()...)
+}
+
+func (*Delegate) OutputsWarpMessage() bool {
+	return false
+}
+
+func (d *Delegate) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if d.Amount == 0 {
+		return false, BaseComputeUnits, OutputValueZero, nil, nil
+	}
+	validator, err := d.module.getValidator(ctx, mu, d.NodeID)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if validator == nil {
+		return false, BaseComputeUnits, OutputValidatorMissing, nil, nil
+	}
+	if validator.Owner == auth.Actor() {
+		return false, BaseComputeUnits, OutputOwnerCannotDelegate, nil, nil
+	}
+	if validator.Stake < d.module.config.MinValidatorStake {
+		// The owner has unstaked
+		return false, BaseComputeUnits, OutputBelowMinStake, nil, nil
+	}
+	if err := d.module.handler.Deduct(ctx, auth.Actor(), mu, d.Amount); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := d.module.modifyStake(ctx, mu, timestamp, d.NodeID, validator, auth.Actor(), d.Amount, true); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, BaseComputeUnits, nil, nil, nil
+}
+
+func (*Delegate) MaxComputeUnits(chain.Rules) uint64 {
+	return BaseComputeUnits
+}
+
+func (*Delegate) Size() int {
+	return ids.NodeIDLen + consts.Uint64Len
+}
+
+func (d *Delegate) Marshal(p *codec.Packer) {
+	packNodeID(p, d.NodeID)
+	p.PackUint64(d.Amount)
+}
+
+// UnmarshalDelegate should be registered in the [chain.ActionRegistry] of a
+// VM with [DelegateID].
+func (m *Module) UnmarshalDelegate(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	nodeID, err := unpackNodeID(p)
+	if err != nil {
+		return nil, err
+	}
+	d := m.NewDelegate(nodeID, p.UnpackUint64(true))
+	return d, p.Err()
+}
+
+func (*Delegate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
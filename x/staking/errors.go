@@ -0,0 +1,13 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import "errors"
+
+var (
+	ErrInvalidEpochDuration = errors.New("invalid epoch duration")
+	ErrDuplicateTypeID      = errors.New("duplicate type ID")
+	ErrRewardOverflow       = errors.New("reward overflow")
+	ErrCorruptRecord        = errors.New("corrupt record")
+)
@@ -0,0 +1,14 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+var (
+	OutputValueZero           = []byte("value is zero")
+	OutputValidatorMissing    = []byte("validator is missing")
+	OutputWrongOwner          = []byte("wrong owner")
+	OutputOwnerCannotDelegate = []byte("owner cannot delegate")
+	OutputBelowMinStake       = []byte("below min validator stake")
+	OutputInsufficientStake   = []byte("insufficient stake")
+	OutputNothingToClaim      = []byte("nothing to claim")
+)
@@ -0,0 +1,124 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Stake)(nil)
+
+// Stake locks [Amount] on [NodeID]. The first account to stake on [NodeID]
+// becomes its owner and must stake at least [Config.MinValidatorStake]. Other
+// accounts can only [Delegate] to [NodeID].
+type Stake struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Amount uint64     `json:"amount"`
+
+	module *Module
+}
+
+// NewStake returns a [Stake] action for [module].
+func (m *Module) NewStake(nodeID ids.NodeID, amount uint64) *Stake {
+	return &Stake{NodeID: nodeID, Amount: amount, module: m}
+}
+
+func (s *Stake) GetTypeID() uint8 {
+	return s.module.config.StakeID
+}
+
+func (s *Stake) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return s.module.balanceKeys(state.Keys{
+		string(s.module.ValidatorKey(s.NodeID)):              state.All,
+		string(s.module.PositionKey(s.NodeID, auth.Actor())): state.All,
+		string(s.module.TotalsKey()):                         state.All,
+	}, auth.Actor())
+}
+
+func (s *Stake) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{ValidatorChunks, PositionChunks, TotalsChunks}, s.module.handler.StateKeysMaxChunks()...)
+}
+
+func (*Stake) OutputsWarpMessage() bool {
+	return false
+}
+
+func (s *Stake) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if s.Amount == 0 {
+		return false, BaseComputeUnits, OutputValueZero, nil, nil
+	}
+	validator, err := s.module.getValidator(ctx, mu, s.NodeID)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if validator == nil {
+		// Stake added in the epoch a validator is registered is not counted
+		// until the next epoch.
+		validator = &Validator{Owner: auth.Actor(), Epoch: s.module.Epoch(timestamp)}
+	}
+	if validator.Owner != auth.Actor() {
+		return false, BaseComputeUnits, OutputWrongOwner, nil, nil
+	}
+	stake, err := smath.Add64(validator.Stake, s.Amount)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if stake < s.module.config.MinValidatorStake {
+		return false, BaseComputeUnits, OutputBelowMinStake, nil, nil
+	}
+	if err := s.module.handler.Deduct(ctx, auth.Actor(), mu, s.Amount); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := s.module.modifyStake(ctx, mu, timestamp, s.NodeID, validator, auth.Actor(), s.Amount, true); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, BaseComputeUnits, nil, nil, nil
+}
+
+func (*Stake) MaxComputeUnits(chain.Rules) uint64 {
+	return BaseComputeUnits
+}
+
+func (*Stake) Size() int {
+	return ids.NodeIDLen + consts.Uint64Len
+}
+
+func (s *Stake) Marshal(p *codec.Packer) {
+	packNodeID(p, s.NodeID)
+	p.PackUint64(s.Amount)
+}
+
+// UnmarshalStake should be registered in the [chain.ActionRegistry] of a VM
+// with [StakeID].
+func (m *Module) UnmarshalStake(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	nodeID, err := unpackNodeID(p)
+	if err != nil {
+		return nil, err
+	}
+	s := m.NewStake(nodeID, p.UnpackUint64(true))
+	return s, p.Err()
+}
+
+func (*Stake) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,313 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package staking tracks stake (and delegations) on validators in the state of
+// a VM and distributes fees to stakers as rewards.
+//
+// Stake is tracked independently of the P-Chain validator set of the Subnet.
+// VMs can combine both (i.e. only reward nodes that are currently validating)
+// but the [Module] only relies on state, so it can be queried by any [chain.Auth]
+// or [chain.Action] during execution (see [Module.GetStake]).
+package staking
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	BaseComputeUnits = 5
+)
+
+// RewardPrecision is the factor applied to [Totals.RewardIndex] so that small
+// rewards can be distributed over a large amount of stake.
+var RewardPrecision = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// BalanceHandler is implemented by each VM that registers the [Module] to lock
+// (and unlock) the staked asset (which is also the asset used to pay rewards).
+type BalanceHandler interface {
+	// StateKeys are the keys touched by [Deduct] and [Credit].
+	StateKeys(addr codec.Address) []string
+	// StateKeysMaxChunks are the max chunks of each key in [StateKeys].
+	StateKeysMaxChunks() []uint16
+
+	Deduct(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+	Credit(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+}
+
+// Config is the configuration of a [Module].
+type Config struct {
+	// Type IDs of the actions provided by the [Module] (must be unique in the
+	// [chain.ActionRegistry] of the VM)
+	StakeID    uint8
+	DelegateID uint8
+	UnstakeID  uint8
+	ClaimID    uint8
+
+	// Prefix of all keys used by the [Module] (must not be a prefix of any other
+	// key used by the VM).
+	Prefix []byte
+
+	// EpochDuration is the duration of each epoch (in ms). The stake of a
+	// validator returned by [Module.GetStake] only changes at the start of an
+	// epoch and unstaked amounts can only be claimed once the next epoch starts.
+	EpochDuration int64
+
+	// MinValidatorStake is the minimum amount the owner of a validator must
+	// stake on it.
+	MinValidatorStake uint64
+}
+
+// Module binds the staking actions to the [Config] and [BalanceHandler] used
+// by a specific VM.
+type Module struct {
+	config  Config
+	handler BalanceHandler
+}
+
+func NewModule(config Config, handler BalanceHandler) (*Module, error) {
+	if config.EpochDuration <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidEpochDuration, config.EpochDuration)
+	}
+	typeIDs := []uint8{config.StakeID, config.DelegateID, config.UnstakeID, config.ClaimID}
+	for i := range typeIDs {
+		for j := i + 1; j < len(typeIDs); j++ {
+			if typeIDs[i] == typeIDs[j] {
+				return nil, fmt.Errorf("%w: %d", ErrDuplicateTypeID, typeIDs[i])
+			}
+		}
+	}
+	return &Module{config, handler}, nil
+}
+
+func (m *Module) StakeID() uint8    { return m.config.StakeID }
+func (m *Module) DelegateID() uint8 { return m.config.DelegateID }
+func (m *Module) UnstakeID() uint8  { return m.config.UnstakeID }
+func (m *Module) ClaimID() uint8    { return m.config.ClaimID }
+
+// Epoch returns the epoch that contains [timestamp].
+func (m *Module) Epoch(timestamp int64) uint64 {
+	if timestamp < 0 {
+		return 0
+	}
+	return uint64(timestamp / m.config.EpochDuration)
+}
+
+// StakeStateKeys are the keys that must be readable to call [GetStake] for
+// [nodeID] (with [ValidatorChunks]).
+func (m *Module) StakeStateKeys(nodeID ids.NodeID) []string {
+	return []string{string(m.ValidatorKey(nodeID))}
+}
+
+// GetStake returns the stake of [nodeID] at the start of the epoch that
+// contains [timestamp] (0 if [nodeID] is not registered).
+//
+// Because stake only changes at the start of an epoch, this can be used to
+// weight validators consistently for an entire epoch.
+func (m *Module) GetStake(ctx context.Context, im state.Immutable, nodeID ids.NodeID, timestamp int64) (uint64, error) {
+	validator, err := m.getValidator(ctx, im, nodeID)
+	if err != nil {
+		return 0, err
+	}
+	if validator == nil {
+		return 0, nil
+	}
+	return m.ValidatorStake(validator, timestamp), nil
+}
+
+// ValidatorStake returns the stake of [validator] at the start of the epoch
+// that contains [timestamp].
+func (m *Module) ValidatorStake(validator *Validator, timestamp int64) uint64 {
+	if m.Epoch(timestamp) > validator.Epoch {
+		return validator.Total()
+	}
+	return validator.EpochStake
+}
+
+// TotalStakeStateKeys are the keys that must be readable to call
+// [GetTotalStake] (with [TotalsChunks]).
+func (m *Module) TotalStakeStateKeys() []string {
+	return []string{string(m.TotalsKey())}
+}
+
+// GetTotalStake returns the stake of all validators at the start of the epoch
+// that contains [timestamp].
+func (m *Module) GetTotalStake(ctx context.Context, im state.Immutable, timestamp int64) (uint64, error) {
+	totals, err := m.getTotals(ctx, im)
+	if err != nil {
+		return 0, err
+	}
+	if m.Epoch(timestamp) > totals.Epoch {
+		return totals.Stake, nil
+	}
+	return totals.EpochStake, nil
+}
+
+// DistributeStateKeys are the keys modified by [Distribute] (should be
+// included in the [chain.FeeDistributor.StateKeys] of the VM).
+func (m *Module) DistributeStateKeys() []string {
+	return []string{string(m.TotalsKey())}
+}
+
+// Distribute distributes up to [amount] (which must already have been removed
+// from the balance of some account) to all stake as rewards that can be
+// claimed with [Claim]. It returns the amount that was distributed (which may
+// be less than [amount] because of rounding or 0 if there is no stake). The
+// VM is responsible for the remainder.
+func (m *Module) Distribute(ctx context.Context, mu state.Mutable, amount uint64) (uint64, error) {
+	if amount == 0 {
+		return 0, nil
+	}
+	totals, err := m.getTotals(ctx, mu)
+	if err != nil {
+		return 0, err
+	}
+	if totals.Stake == 0 {
+		return 0, nil
+	}
+	stake := new(big.Int).SetUint64(totals.Stake)
+	increase := new(big.Int).SetUint64(amount)
+	increase.Mul(increase, RewardPrecision)
+	increase.Div(increase, stake)
+	if increase.Sign() == 0 {
+		return 0, nil
+	}
+	totals.RewardIndex.Add(totals.RewardIndex, increase)
+	if err := m.setTotals(ctx, mu, totals); err != nil {
+		return 0, err
+	}
+	distributed := increase.Mul(increase, stake)
+	distributed.Div(distributed, RewardPrecision)
+	return distributed.Uint64(), nil
+}
+
+// PendingRewards returns the rewards that can be claimed by [position] given
+// [totals].
+func PendingRewards(position *Position, totals *Totals) (uint64, error) {
+	accrued := new(big.Int).Sub(totals.RewardIndex, position.RewardIndex)
+	accrued.Mul(accrued, new(big.Int).SetUint64(position.Amount))
+	accrued.Div(accrued, RewardPrecision)
+	if !accrued.IsUint64() {
+		return 0, ErrRewardOverflow
+	}
+	rewards, err := smath.Add64(position.Rewards, accrued.Uint64())
+	if err != nil {
+		return 0, ErrRewardOverflow
+	}
+	return rewards, nil
+}
+
+// settle updates the rewards of [position] to [totals.RewardIndex] (this must
+// be called before [position.Amount] is modified).
+func settle(position *Position, totals *Totals) error {
+	rewards, err := PendingRewards(position, totals)
+	if err != nil {
+		return err
+	}
+	position.Rewards = rewards
+	position.RewardIndex = new(big.Int).Set(totals.RewardIndex)
+	return nil
+}
+
+// advance records the stake at the start of [epoch] (if [epoch] is after
+// the last epoch in which stake was modified).
+func advance(epoch uint64, current uint64, lastEpoch *uint64, epochStake *uint64) {
+	if epoch > *lastEpoch {
+		*lastEpoch = epoch
+		*epochStake = current
+	}
+}
+
+// modifyStake adds [amount] (or removes it if [add] is false) to the stake of
+// [addr] on [nodeID]. The caller is responsible for moving funds.
+func (m *Module) modifyStake(
+	ctx context.Context,
+	mu state.Mutable,
+	timestamp int64,
+	nodeID ids.NodeID,
+	validator *Validator,
+	addr codec.Address,
+	amount uint64,
+	add bool,
+) error {
+	epoch := m.Epoch(timestamp)
+	totals, err := m.getTotals(ctx, mu)
+	if err != nil {
+		return err
+	}
+	position, err := m.getPosition(ctx, mu, nodeID, addr)
+	if err != nil {
+		return err
+	}
+	if position == nil {
+		position = &Position{RewardIndex: new(big.Int).Set(totals.RewardIndex)}
+	}
+	if err := settle(position, totals); err != nil {
+		return err
+	}
+
+	// Record stake at the start of the epoch before modifying it
+	advance(epoch, validator.Total(), &validator.Epoch, &validator.EpochStake)
+	advance(epoch, totals.Stake, &totals.Epoch, &totals.EpochStake)
+
+	update := smath.Add64
+	if !add {
+		update = smath.Sub[uint64]
+	}
+	if position.Amount, err = update(position.Amount, amount); err != nil {
+		return err
+	}
+	if addr == validator.Owner {
+		validator.Stake, err = update(validator.Stake, amount)
+	} else {
+		validator.Delegated, err = update(validator.Delegated, amount)
+	}
+	if err != nil {
+		return err
+	}
+	if totals.Stake, err = update(totals.Stake, amount); err != nil {
+		return err
+	}
+	if err := m.setPosition(ctx, mu, nodeID, addr, position); err != nil {
+		return err
+	}
+	if err := m.setValidator(ctx, mu, nodeID, validator); err != nil {
+		return err
+	}
+	return m.setTotals(ctx, mu, totals)
+}
+
+// balanceKeys adds the keys used by the [BalanceHandler] for [addr] to [keys].
+func (m *Module) balanceKeys(keys state.Keys, addr codec.Address) state.Keys {
+	for _, k := range m.handler.StateKeys(addr) {
+		keys.Add(k, state.All)
+	}
+	return keys
+}
+
+func packNodeID(p *codec.Packer, nodeID ids.NodeID) {
+	p.PackFixedBytes(nodeID[:])
+}
+
+func unpackNodeID(p *codec.Packer) (ids.NodeID, error) {
+	b := make([]byte, ids.NodeIDLen)
+	p.UnpackFixedBytes(ids.NodeIDLen, &b)
+	if err := p.Err(); err != nil {
+		return ids.EmptyNodeID, err
+	}
+	nodeID, err := ids.ToNodeID(b)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	if nodeID == ids.EmptyNodeID {
+		return ids.EmptyNodeID, fmt.Errorf("%w: NodeID field is not populated", codec.ErrFieldNotPopulated)
+	}
+	return nodeID, nil
+}
@@ -0,0 +1,318 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	testEpochDuration     = 10
+	testMinValidatorStake = 100
+)
+
+var (
+	_ state.Mutable  = (*testDB)(nil)
+	_ BalanceHandler = (*testHandler)(nil)
+)
+
+type testDB struct {
+	storage map[string][]byte
+}
+
+func newTestDB() *testDB {
+	return &testDB{storage: map[string][]byte{}}
+}
+
+func (db *testDB) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := db.storage[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (db *testDB) Insert(_ context.Context, key []byte, value []byte) error {
+	db.storage[string(key)] = value
+	return nil
+}
+
+func (db *testDB) Remove(_ context.Context, key []byte) error {
+	delete(db.storage, string(key))
+	return nil
+}
+
+type testHandler struct {
+	balances map[codec.Address]uint64
+}
+
+func (*testHandler) StateKeys(addr codec.Address) []string {
+	return []string{string(addr[:])}
+}
+
+func (*testHandler) StateKeysMaxChunks() []uint16 {
+	return []uint16{1}
+}
+
+func (h *testHandler) Deduct(_ context.Context, addr codec.Address, _ state.Mutable, amount uint64) error {
+	if h.balances[addr] < amount {
+		return database.ErrNotFound
+	}
+	h.balances[addr] -= amount
+	return nil
+}
+
+func (h *testHandler) Credit(_ context.Context, addr codec.Address, _ state.Mutable, amount uint64) error {
+	h.balances[addr] += amount
+	return nil
+}
+
+type testAuth struct {
+	chain.Auth
+
+	actor codec.Address
+}
+
+func (a *testAuth) Actor() codec.Address {
+	return a.actor
+}
+
+func newTestModule(t *testing.T) (*Module, *testHandler) {
+	handler := &testHandler{balances: map[codec.Address]uint64{}}
+	m, err := NewModule(Config{
+		StakeID:           0,
+		DelegateID:        1,
+		UnstakeID:         2,
+		ClaimID:           3,
+		Prefix:            []byte{0xf},
+		EpochDuration:     testEpochDuration,
+		MinValidatorStake: testMinValidatorStake,
+	}, handler)
+	require.NoError(t, err)
+	return m, handler
+}
+
+func execute(t *testing.T, mu state.Mutable, action chain.Action, timestamp int64, actor codec.Address) []byte {
+	success, _, output, _, err := action.Execute(
+		context.Background(),
+		nil,
+		mu,
+		timestamp,
+		&testAuth{actor: actor},
+		ids.Empty,
+		false,
+	)
+	require.NoError(t, err)
+	if success {
+		return nil
+	}
+	require.NotNil(t, output)
+	return output
+}
+
+func TestNewModule(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewModule(Config{StakeID: 0, DelegateID: 1, UnstakeID: 2, ClaimID: 3}, nil)
+	require.ErrorIs(err, ErrInvalidEpochDuration)
+
+	_, err = NewModule(Config{StakeID: 0, DelegateID: 1, UnstakeID: 1, ClaimID: 3, EpochDuration: 1}, nil)
+	require.ErrorIs(err, ErrDuplicateTypeID)
+}
+
+func TestMarshal(t *testing.T) {
+	require := require.New(t)
+	m, _ := newTestModule(t)
+	nodeID := ids.GenerateTestNodeID()
+
+	for _, action := range []chain.Action{
+		m.NewStake(nodeID, 10),
+		m.NewDelegate(nodeID, 11),
+		m.NewUnstake(nodeID, 12),
+		m.NewClaim(nodeID),
+	} {
+		p := codec.NewWriter(action.Size(), action.Size())
+		action.Marshal(p)
+		require.NoError(p.Err())
+
+		var unmarshal func(*codec.Packer) (chain.Action, error)
+		switch action.GetTypeID() {
+		case m.StakeID():
+			unmarshal = func(p *codec.Packer) (chain.Action, error) { return m.UnmarshalStake(p, nil) }
+		case m.DelegateID():
+			unmarshal = func(p *codec.Packer) (chain.Action, error) { return m.UnmarshalDelegate(p, nil) }
+		case m.UnstakeID():
+			unmarshal = func(p *codec.Packer) (chain.Action, error) { return m.UnmarshalUnstake(p, nil) }
+		case m.ClaimID():
+			unmarshal = func(p *codec.Packer) (chain.Action, error) { return m.UnmarshalClaim(p, nil) }
+		}
+		parsed, err := unmarshal(codec.NewReader(p.Bytes(), action.Size()))
+		require.NoError(err)
+		require.Equal(action, parsed)
+	}
+
+	// Empty NodeID
+	p := codec.NewWriter(m.NewClaim(ids.EmptyNodeID).Size(), consts.MaxInt)
+	m.NewClaim(ids.EmptyNodeID).Marshal(p)
+	_, err := m.UnmarshalClaim(codec.NewReader(p.Bytes(), consts.MaxInt), nil)
+	require.ErrorIs(err, codec.ErrFieldNotPopulated)
+}
+
+func TestStakeAndDelegate(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	m, handler := newTestModule(t)
+	db := newTestDB()
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		owner     = codec.CreateAddress(0, ids.GenerateTestID())
+		delegator = codec.CreateAddress(0, ids.GenerateTestID())
+	)
+	handler.balances[owner] = 1_000
+	handler.balances[delegator] = 1_000
+
+	// Delegate before the validator is registered
+	require.Equal(OutputValidatorMissing, execute(t, db, m.NewDelegate(nodeID, 50), 5, delegator))
+
+	// Stake below the min
+	require.Equal(OutputBelowMinStake, execute(t, db, m.NewStake(nodeID, 50), 5, owner))
+
+	// Register validator
+	require.Nil(execute(t, db, m.NewStake(nodeID, 100), 5, owner))
+	require.Equal(uint64(900), handler.balances[owner])
+
+	// Only owner can stake
+	require.Equal(OutputWrongOwner, execute(t, db, m.NewStake(nodeID, 100), 5, delegator))
+	require.Equal(OutputOwnerCannotDelegate, execute(t, db, m.NewDelegate(nodeID, 100), 5, owner))
+
+	// Delegate
+	require.Nil(execute(t, db, m.NewDelegate(nodeID, 300), 6, delegator))
+	require.Equal(uint64(700), handler.balances[delegator])
+
+	// Stake is only counted once the next epoch starts
+	stake, err := m.GetStake(ctx, db, nodeID, 9)
+	require.NoError(err)
+	require.Zero(stake)
+	stake, err = m.GetStake(ctx, db, nodeID, 10)
+	require.NoError(err)
+	require.Equal(uint64(400), stake)
+	totalStake, err := m.GetTotalStake(ctx, db, 10)
+	require.NoError(err)
+	require.Equal(uint64(400), totalStake)
+
+	// Modifications in epoch 1 don't change the stake in epoch 1
+	require.Nil(execute(t, db, m.NewStake(nodeID, 100), 12, owner))
+	stake, err = m.GetStake(ctx, db, nodeID, 15)
+	require.NoError(err)
+	require.Equal(uint64(400), stake)
+	stake, err = m.GetStake(ctx, db, nodeID, 20)
+	require.NoError(err)
+	require.Equal(uint64(500), stake)
+
+	validator, err := m.getValidator(ctx, db, nodeID)
+	require.NoError(err)
+	require.Equal(owner, validator.Owner)
+	require.Equal(uint64(200), validator.Stake)
+	require.Equal(uint64(300), validator.Delegated)
+}
+
+func TestRewards(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	m, handler := newTestModule(t)
+	db := newTestDB()
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		owner     = codec.CreateAddress(0, ids.GenerateTestID())
+		delegator = codec.CreateAddress(0, ids.GenerateTestID())
+	)
+	handler.balances[owner] = 1_000
+	handler.balances[delegator] = 1_000
+
+	// Nothing to distribute to
+	distributed, err := m.Distribute(ctx, db, 100)
+	require.NoError(err)
+	require.Zero(distributed)
+
+	require.Nil(execute(t, db, m.NewStake(nodeID, 100), 0, owner))
+	require.Nil(execute(t, db, m.NewDelegate(nodeID, 300), 0, delegator))
+
+	distributed, err = m.Distribute(ctx, db, 100)
+	require.NoError(err)
+	require.Equal(uint64(100), distributed)
+
+	require.Nil(execute(t, db, m.NewClaim(nodeID), 1, owner))
+	require.Equal(uint64(900+25), handler.balances[owner])
+	require.Equal(OutputNothingToClaim, execute(t, db, m.NewClaim(nodeID), 1, owner))
+
+	// Rewards are settled when stake is modified
+	require.Nil(execute(t, db, m.NewDelegate(nodeID, 400), 1, delegator))
+	distributed, err = m.Distribute(ctx, db, 80)
+	require.NoError(err)
+	require.Equal(uint64(80), distributed)
+	require.Nil(execute(t, db, m.NewClaim(nodeID), 2, delegator))
+	require.Equal(uint64(300+75+70), handler.balances[delegator])
+	require.Nil(execute(t, db, m.NewClaim(nodeID), 2, owner))
+	require.Equal(uint64(925+10), handler.balances[owner])
+}
+
+func TestUnstake(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	m, handler := newTestModule(t)
+	db := newTestDB()
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		owner     = codec.CreateAddress(0, ids.GenerateTestID())
+		delegator = codec.CreateAddress(0, ids.GenerateTestID())
+	)
+	handler.balances[owner] = 1_000
+	handler.balances[delegator] = 1_000
+
+	require.Nil(execute(t, db, m.NewStake(nodeID, 200), 0, owner))
+	require.Nil(execute(t, db, m.NewDelegate(nodeID, 100), 0, delegator))
+
+	// Owner must keep the min stake (or remove everything)
+	require.Equal(OutputBelowMinStake, execute(t, db, m.NewUnstake(nodeID, 150), 12, owner))
+	require.Equal(OutputInsufficientStake, execute(t, db, m.NewUnstake(nodeID, 300), 12, owner))
+	require.Nil(execute(t, db, m.NewUnstake(nodeID, 200), 12, owner))
+
+	// Can't delegate once the owner has unstaked
+	require.Equal(OutputBelowMinStake, execute(t, db, m.NewDelegate(nodeID, 100), 12, delegator))
+
+	// Stake is removed once the next epoch starts
+	stake, err := m.GetStake(ctx, db, nodeID, 15)
+	require.NoError(err)
+	require.Equal(uint64(300), stake)
+	stake, err = m.GetStake(ctx, db, nodeID, 20)
+	require.NoError(err)
+	require.Equal(uint64(100), stake)
+
+	// Withdrawal is locked until the next epoch
+	require.Equal(OutputNothingToClaim, execute(t, db, m.NewClaim(nodeID), 19, owner))
+	require.Equal(uint64(800), handler.balances[owner])
+	require.Nil(execute(t, db, m.NewClaim(nodeID), 20, owner))
+	require.Equal(uint64(1_000), handler.balances[owner])
+
+	// All records of the owner are removed
+	position, err := m.getPosition(ctx, db, nodeID, owner)
+	require.NoError(err)
+	require.Nil(position)
+	withdrawal, err := m.getWithdrawal(ctx, db, owner)
+	require.NoError(err)
+	require.Nil(withdrawal)
+}
@@ -0,0 +1,335 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// State (under [Config.Prefix])
+// 0x0/ (validators)
+//   -> [nodeID] => owner|stake|delegated|epoch|epochStake
+// 0x1/ (positions)
+//   -> [nodeID|address] => amount|rewardIndex|rewards
+// 0x2/ (withdrawals)
+//   -> [address] => amount|unlockEpoch
+// 0x3/ (totals)
+//   -> [] => stake|rewardIndex|epoch|epochStake
+
+const (
+	validatorPrefix  = 0x0
+	positionPrefix   = 0x1
+	withdrawalPrefix = 0x2
+	totalsPrefix     = 0x3
+)
+
+const (
+	ValidatorChunks  uint16 = 2
+	PositionChunks   uint16 = 1
+	WithdrawalChunks uint16 = 1
+	TotalsChunks     uint16 = 1
+
+	// indexLen is the length of an encoded reward index
+	indexLen = 32
+)
+
+// ReadState reads multiple keys from the latest accepted state (used to serve
+// RPC queries).
+type ReadState func(context.Context, [][]byte) ([][]byte, []error)
+
+// Validator is the stake registered for a node.
+type Validator struct {
+	// Owner registered the validator and is the only account that can add to
+	// (or remove) its [Stake].
+	Owner codec.Address `json:"owner"`
+
+	Stake     uint64 `json:"stake"`     // staked by [Owner]
+	Delegated uint64 `json:"delegated"` // staked by all other accounts
+
+	// EpochStake is the total stake of the validator at the start of [Epoch]
+	// (the last epoch in which the validator was modified).
+	Epoch      uint64 `json:"epoch"`
+	EpochStake uint64 `json:"epochStake"`
+}
+
+// Total is the sum of [Stake] and [Delegated].
+func (v *Validator) Total() uint64 {
+	return v.Stake + v.Delegated
+}
+
+// Position is the amount staked by a single account on a validator.
+type Position struct {
+	Amount uint64 `json:"amount"`
+
+	// RewardIndex is the value of [Totals.RewardIndex] when [Rewards] were
+	// last updated.
+	RewardIndex *big.Int `json:"rewardIndex"`
+	Rewards     uint64   `json:"rewards"`
+}
+
+// Withdrawal is an unstaked amount that can be claimed once [UnlockEpoch]
+// starts.
+type Withdrawal struct {
+	Amount      uint64 `json:"amount"`
+	UnlockEpoch uint64 `json:"unlockEpoch"`
+}
+
+// Totals track the stake of all validators and the rewards that have been
+// distributed to each unit of stake.
+type Totals struct {
+	Stake uint64 `json:"stake"`
+
+	// RewardIndex is the sum of all rewards distributed per unit of stake
+	// (multiplied by [RewardPrecision]).
+	RewardIndex *big.Int `json:"rewardIndex"`
+
+	Epoch      uint64 `json:"epoch"`
+	EpochStake uint64 `json:"epochStake"`
+}
+
+func (m *Module) key(size int, prefix byte) []byte {
+	k := make([]byte, len(m.config.Prefix)+1+size+consts.Uint16Len)
+	copy(k, m.config.Prefix)
+	k[len(m.config.Prefix)] = prefix
+	return k
+}
+
+// [prefix] + [validatorPrefix] + [nodeID]
+func (m *Module) ValidatorKey(nodeID ids.NodeID) []byte {
+	k := m.key(ids.NodeIDLen, validatorPrefix)
+	offset := len(m.config.Prefix) + 1
+	copy(k[offset:], nodeID[:])
+	binary.BigEndian.PutUint16(k[offset+ids.NodeIDLen:], ValidatorChunks)
+	return k
+}
+
+// [prefix] + [positionPrefix] + [nodeID] + [address]
+func (m *Module) PositionKey(nodeID ids.NodeID, addr codec.Address) []byte {
+	k := m.key(ids.NodeIDLen+codec.AddressLen, positionPrefix)
+	offset := len(m.config.Prefix) + 1
+	copy(k[offset:], nodeID[:])
+	copy(k[offset+ids.NodeIDLen:], addr[:])
+	binary.BigEndian.PutUint16(k[offset+ids.NodeIDLen+codec.AddressLen:], PositionChunks)
+	return k
+}
+
+// [prefix] + [withdrawalPrefix] + [address]
+func (m *Module) WithdrawalKey(addr codec.Address) []byte {
+	k := m.key(codec.AddressLen, withdrawalPrefix)
+	offset := len(m.config.Prefix) + 1
+	copy(k[offset:], addr[:])
+	binary.BigEndian.PutUint16(k[offset+codec.AddressLen:], WithdrawalChunks)
+	return k
+}
+
+// [prefix] + [totalsPrefix]
+func (m *Module) TotalsKey() []byte {
+	k := m.key(0, totalsPrefix)
+	binary.BigEndian.PutUint16(k[len(m.config.Prefix)+1:], TotalsChunks)
+	return k
+}
+
+func packIndex(p *codec.Packer, index *big.Int) {
+	b := make([]byte, indexLen)
+	index.FillBytes(b)
+	p.PackFixedBytes(b)
+}
+
+func unpackIndex(p *codec.Packer) *big.Int {
+	b := make([]byte, indexLen)
+	p.UnpackFixedBytes(indexLen, &b)
+	return new(big.Int).SetBytes(b)
+}
+
+func (m *Module) getValidator(ctx context.Context, im state.Immutable, nodeID ids.NodeID) (*Validator, error) {
+	v, err := im.GetValue(ctx, m.ValidatorKey(nodeID))
+	return innerGetValidator(v, err)
+}
+
+// GetValidatorFromState is used to serve RPC queries (nil if [nodeID] is not
+// registered).
+func (m *Module) GetValidatorFromState(ctx context.Context, f ReadState, nodeID ids.NodeID) (*Validator, error) {
+	values, errs := f(ctx, [][]byte{m.ValidatorKey(nodeID)})
+	return innerGetValidator(values[0], errs[0])
+}
+
+func innerGetValidator(v []byte, err error) (*Validator, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(v, len(v))
+	var validator Validator
+	p.UnpackAddress(&validator.Owner)
+	validator.Stake = p.UnpackUint64(false)
+	validator.Delegated = p.UnpackUint64(false)
+	validator.Epoch = p.UnpackUint64(false)
+	validator.EpochStake = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptRecord, err)
+	}
+	return &validator, nil
+}
+
+func (m *Module) setValidator(ctx context.Context, mu state.Mutable, nodeID ids.NodeID, validator *Validator) error {
+	p := codec.NewWriter(codec.AddressLen+consts.Uint64Len*4, consts.MaxInt)
+	p.PackAddress(validator.Owner)
+	p.PackUint64(validator.Stake)
+	p.PackUint64(validator.Delegated)
+	p.PackUint64(validator.Epoch)
+	p.PackUint64(validator.EpochStake)
+	return mu.Insert(ctx, m.ValidatorKey(nodeID), p.Bytes())
+}
+
+func (m *Module) getPosition(
+	ctx context.Context,
+	im state.Immutable,
+	nodeID ids.NodeID,
+	addr codec.Address,
+) (*Position, error) {
+	v, err := im.GetValue(ctx, m.PositionKey(nodeID, addr))
+	return innerGetPosition(v, err)
+}
+
+// GetPositionFromState is used to serve RPC queries (nil if [addr] has not
+// staked on [nodeID]).
+func (m *Module) GetPositionFromState(
+	ctx context.Context,
+	f ReadState,
+	nodeID ids.NodeID,
+	addr codec.Address,
+) (*Position, error) {
+	values, errs := f(ctx, [][]byte{m.PositionKey(nodeID, addr)})
+	return innerGetPosition(values[0], errs[0])
+}
+
+func innerGetPosition(v []byte, err error) (*Position, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(v, len(v))
+	var position Position
+	position.Amount = p.UnpackUint64(false)
+	position.RewardIndex = unpackIndex(p)
+	position.Rewards = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptRecord, err)
+	}
+	return &position, nil
+}
+
+func (m *Module) setPosition(
+	ctx context.Context,
+	mu state.Mutable,
+	nodeID ids.NodeID,
+	addr codec.Address,
+	position *Position,
+) error {
+	k := m.PositionKey(nodeID, addr)
+	if position.Amount == 0 && position.Rewards == 0 {
+		// If there is nothing left, we should delete the record instead of
+		// storing an empty one.
+		return mu.Remove(ctx, k)
+	}
+	p := codec.NewWriter(consts.Uint64Len*2+indexLen, consts.MaxInt)
+	p.PackUint64(position.Amount)
+	packIndex(p, position.RewardIndex)
+	p.PackUint64(position.Rewards)
+	return mu.Insert(ctx, k, p.Bytes())
+}
+
+func (m *Module) getWithdrawal(ctx context.Context, im state.Immutable, addr codec.Address) (*Withdrawal, error) {
+	v, err := im.GetValue(ctx, m.WithdrawalKey(addr))
+	return innerGetWithdrawal(v, err)
+}
+
+// GetWithdrawalFromState is used to serve RPC queries (nil if [addr] has
+// nothing to withdraw).
+func (m *Module) GetWithdrawalFromState(ctx context.Context, f ReadState, addr codec.Address) (*Withdrawal, error) {
+	values, errs := f(ctx, [][]byte{m.WithdrawalKey(addr)})
+	return innerGetWithdrawal(values[0], errs[0])
+}
+
+func innerGetWithdrawal(v []byte, err error) (*Withdrawal, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(v, len(v))
+	var withdrawal Withdrawal
+	withdrawal.Amount = p.UnpackUint64(false)
+	withdrawal.UnlockEpoch = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptRecord, err)
+	}
+	return &withdrawal, nil
+}
+
+func (m *Module) setWithdrawal(ctx context.Context, mu state.Mutable, addr codec.Address, withdrawal *Withdrawal) error {
+	k := m.WithdrawalKey(addr)
+	if withdrawal.Amount == 0 {
+		return mu.Remove(ctx, k)
+	}
+	p := codec.NewWriter(consts.Uint64Len*2, consts.MaxInt)
+	p.PackUint64(withdrawal.Amount)
+	p.PackUint64(withdrawal.UnlockEpoch)
+	return mu.Insert(ctx, k, p.Bytes())
+}
+
+func (m *Module) getTotals(ctx context.Context, im state.Immutable) (*Totals, error) {
+	v, err := im.GetValue(ctx, m.TotalsKey())
+	return innerGetTotals(v, err)
+}
+
+// GetTotalsFromState is used to serve RPC queries.
+func (m *Module) GetTotalsFromState(ctx context.Context, f ReadState) (*Totals, error) {
+	values, errs := f(ctx, [][]byte{m.TotalsKey()})
+	return innerGetTotals(values[0], errs[0])
+}
+
+func innerGetTotals(v []byte, err error) (*Totals, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return &Totals{RewardIndex: new(big.Int)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(v, len(v))
+	var totals Totals
+	totals.Stake = p.UnpackUint64(false)
+	totals.RewardIndex = unpackIndex(p)
+	totals.Epoch = p.UnpackUint64(false)
+	totals.EpochStake = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptRecord, err)
+	}
+	return &totals, nil
+}
+
+func (m *Module) setTotals(ctx context.Context, mu state.Mutable, totals *Totals) error {
+	p := codec.NewWriter(consts.Uint64Len*3+indexLen, consts.MaxInt)
+	p.PackUint64(totals.Stake)
+	packIndex(p, totals.RewardIndex)
+	p.PackUint64(totals.Epoch)
+	p.PackUint64(totals.EpochStake)
+	return mu.Insert(ctx, m.TotalsKey(), p.Bytes())
+}
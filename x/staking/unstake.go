@@ -0,0 +1,142 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Unstake)(nil)
+
+// Unstake removes [Amount] from the stake (or delegation) of the actor on
+// [NodeID]. The unstaked amount is added to the [Withdrawal] of the actor and
+// can be claimed (with [Claim]) once the next epoch starts.
+//
+// If the actor already has a [Withdrawal], its unlock epoch is reset.
+type Unstake struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Amount uint64     `json:"amount"`
+
+	module *Module
+}
+
+// NewUnstake returns an [Unstake] action for [module].
+func (m *Module) NewUnstake(nodeID ids.NodeID, amount uint64) *Unstake {
+	return &Unstake{NodeID: nodeID, Amount: amount, module: m}
+}
+
+func (u *Unstake) GetTypeID() uint8 {
+	return u.module.config.UnstakeID
+}
+
+func (u *Unstake) StateKeys(auth chain.Auth, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(u.module.ValidatorKey(u.NodeID)):              state.All,
+		string(u.module.PositionKey(u.NodeID, auth.Actor())): state.All,
+		string(u.module.TotalsKey()):                         state.All,
+		string(u.module.WithdrawalKey(auth.Actor())):         state.All,
+	}
+}
+
+func (*Unstake) StateKeysMaxChunks() []uint16 {
+	return []uint16{ValidatorChunks, PositionChunks, TotalsChunks, WithdrawalChunks}
+}
+
+func (*Unstake) OutputsWarpMessage() bool {
+	return false
+}
+
+func (u *Unstake) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if u.Amount == 0 {
+		return false, BaseComputeUnits, OutputValueZero, nil, nil
+	}
+	actor := auth.Actor()
+	validator, err := u.module.getValidator(ctx, mu, u.NodeID)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if validator == nil {
+		return false, BaseComputeUnits, OutputValidatorMissing, nil, nil
+	}
+	position, err := u.module.getPosition(ctx, mu, u.NodeID, actor)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if position == nil || position.Amount < u.Amount {
+		return false, BaseComputeUnits, OutputInsufficientStake, nil, nil
+	}
+	if actor == validator.Owner {
+		// The owner can either remove all of its stake or keep at least
+		// [MinValidatorStake] on the validator.
+		remaining := validator.Stake - u.Amount
+		if remaining > 0 && remaining < u.module.config.MinValidatorStake {
+			return false, BaseComputeUnits, OutputBelowMinStake, nil, nil
+		}
+	}
+	if err := u.module.modifyStake(ctx, mu, timestamp, u.NodeID, validator, actor, u.Amount, false); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	withdrawal, err := u.module.getWithdrawal(ctx, mu, actor)
+	if err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if withdrawal == nil {
+		withdrawal = &Withdrawal{}
+	}
+	if withdrawal.Amount, err = smath.Add64(withdrawal.Amount, u.Amount); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	withdrawal.UnlockEpoch = u.module.Epoch(timestamp) + 1
+	if err := u.module.setWithdrawal(ctx, mu, actor, withdrawal); err != nil {
+		return false, BaseComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, BaseComputeUnits, nil, nil, nil
+}
+
+func (*Unstake) MaxComputeUnits(chain.Rules) uint64 {
+	return BaseComputeUnits
+}
+
+func (*Unstake) Size() int {
+	return ids.NodeIDLen + consts.Uint64Len
+}
+
+func (u *Unstake) Marshal(p *codec.Packer) {
+	packNodeID(p, u.NodeID)
+	p.PackUint64(u.Amount)
+}
+
+// UnmarshalUnstake should be registered in the [chain.ActionRegistry] of a VM
+// with [UnstakeID].
+func (m *Module) UnmarshalUnstake(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	nodeID, err := unpackNodeID(p)
+	if err != nil {
+		return nil, err
+	}
+	u := m.NewUnstake(nodeID, p.UnpackUint64(true))
+	return u, p.Err()
+}
+
+func (*Unstake) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}